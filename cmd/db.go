@@ -15,13 +15,16 @@ import (
 	"github.com/supabase/cli/internal/db/diff"
 	"github.com/supabase/cli/internal/db/dump"
 	"github.com/supabase/cli/internal/db/lint"
+	"github.com/supabase/cli/internal/db/psql"
 	"github.com/supabase/cli/internal/db/pull"
 	"github.com/supabase/cli/internal/db/push"
 	"github.com/supabase/cli/internal/db/remote/changes"
 	"github.com/supabase/cli/internal/db/remote/commit"
 	"github.com/supabase/cli/internal/db/reset"
+	"github.com/supabase/cli/internal/db/restore"
 	"github.com/supabase/cli/internal/db/start"
 	"github.com/supabase/cli/internal/db/test"
+	dbUrl "github.com/supabase/cli/internal/db/url"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
 )
@@ -87,22 +90,37 @@ var (
 	useMigra    bool
 	usePgAdmin  bool
 	usePgSchema bool
+	useNative   bool
 	schema      []string
 	file        string
 
+	diffOutput = utils.EnumFlag{
+		Allowed: []string{diff.FormatSql, diff.FormatJson, diff.FormatSummary},
+		Value:   diff.FormatSql,
+	}
+
 	dbDiffCmd = &cobra.Command{
 		Use:   "diff",
 		Short: "Diffs the local database for schema changes",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if usePgAdmin {
-				return diff.RunPgAdmin(cmd.Context(), schema, file, flags.DbConfig, afero.NewOsFs())
+				if err := diff.RunPgAdmin(cmd.Context(), schema, file, flags.DbConfig, afero.NewOsFs()); err != nil || !includeRoles {
+					return err
+				}
+				return dump.SaveRoles(cmd.Context(), flags.DbConfig, false, afero.NewOsFs())
 			}
 			differ := diff.DiffSchemaMigra
 			if usePgSchema {
 				differ = diff.DiffPgSchema
 				fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "--use-pg-schema flag is experimental and may not include all entities, such as RLS policies, enums, and grants.")
+			} else if useNative {
+				differ = diff.DiffNative
+				fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "--use-native flag is experimental and does not diff grants or composite types.")
+			}
+			if err := diff.Run(cmd.Context(), schema, file, diffOutput.Value, flags.DbConfig, differ, afero.NewOsFs()); err != nil || !includeRoles {
+				return err
 			}
-			return diff.Run(cmd.Context(), schema, file, flags.DbConfig, differ, afero.NewOsFs())
+			return dump.SaveRoles(cmd.Context(), flags.DbConfig, false, afero.NewOsFs())
 		},
 	}
 
@@ -111,17 +129,21 @@ var (
 	roleOnly     bool
 	keepComments bool
 	excludeTable []string
+	maskConfig   string
 
 	dbDumpCmd = &cobra.Command{
 		Use:   "dump",
 		Short: "Dumps data or schemas from the remote database",
 		PreRun: func(cmd *cobra.Command, args []string) {
-			if useCopy || len(excludeTable) > 0 {
+			if useCopy || len(excludeTable) > 0 || len(maskConfig) > 0 {
 				cobra.CheckErr(cmd.MarkFlagRequired("data-only"))
 			}
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return dump.Run(cmd.Context(), file, flags.DbConfig, schema, excludeTable, dataOnly, roleOnly, keepComments, useCopy, dryRun, afero.NewOsFs())
+			if err := dump.Run(cmd.Context(), file, flags.DbConfig, schema, excludeTable, maskConfig, dataOnly, roleOnly, keepComments, useCopy, dryRun, afero.NewOsFs()); err != nil || !includeRoles {
+				return err
+			}
+			return dump.SaveRoles(cmd.Context(), flags.DbConfig, dryRun, afero.NewOsFs())
 		},
 		PostRun: func(cmd *cobra.Command, args []string) {
 			if len(file) > 0 {
@@ -139,7 +161,7 @@ var (
 		Use:   "push",
 		Short: "Push new migrations to the remote database",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return push.Run(cmd.Context(), dryRun, includeAll, includeRoles, includeSeed, flags.DbConfig, afero.NewOsFs())
+			return push.Run(cmd.Context(), dryRun, includeAll, includeRoles, includeSeed, schema, flags.DbConfig, afero.NewOsFs())
 		},
 	}
 
@@ -192,7 +214,7 @@ var (
 			if noSeed {
 				utils.Config.Db.Seed.Enabled = false
 			}
-			return reset.Run(cmd.Context(), migrationVersion, flags.DbConfig, afero.NewOsFs())
+			return reset.Run(cmd.Context(), migrationVersion, schema, flags.DbConfig, afero.NewOsFs())
 		},
 	}
 
@@ -206,6 +228,25 @@ var (
 		Value:   "none",
 	}
 
+	dbPsqlCmd = &cobra.Command{
+		Use:   "psql [-- psql args]",
+		Short: "Opens an interactive psql session on the database",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return psql.Run(cmd.Context(), args, flags.DbConfig, afero.NewOsFs())
+		},
+		Example: `  supabase db psql
+  supabase db psql --linked -- -c "select version()"`,
+	}
+
+	dbRestoreCmd = &cobra.Command{
+		Use:   "restore <file>",
+		Short: "Restores a database from a dump file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return restore.Run(cmd.Context(), args[0], flags.DbConfig, afero.NewOsFs())
+		},
+	}
+
 	dbLintCmd = &cobra.Command{
 		Use:   "lint",
 		Short: "Checks local database for typing error",
@@ -222,6 +263,31 @@ var (
 		},
 	}
 
+	usePooler bool
+	urlOutput = utils.EnumFlag{
+		Allowed: append([]string{utils.OutputEnv}, utils.OutputDefaultAllowed...),
+		Value:   utils.OutputPretty,
+	}
+
+	dbUrlCmd = &cobra.Command{
+		Use:   "url",
+		Short: "Prints the database connection string",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if usePooler && utils.IsLocalDatabase(flags.DbConfig) {
+				// Pooler is only available for the linked project
+				if err := cmd.Flags().Set("linked", "true"); err != nil {
+					return err
+				}
+				if err := flags.ParseDatabaseConfig(cmd.Flags(), afero.NewOsFs()); err != nil {
+					return err
+				}
+			}
+			return dbUrl.Run(flags.DbConfig, flags.ProjectRef, usePooler, urlOutput.Value, os.Stdout)
+		},
+		Example: `  eval $(supabase db url -o env)
+  supabase db url --linked --pooler`,
+	}
+
 	dbTestCmd = &cobra.Command{
 		Hidden: true,
 		Use:    "test [path] ...",
@@ -244,13 +310,18 @@ func init() {
 	diffFlags.BoolVar(&useMigra, "use-migra", true, "Use migra to generate schema diff.")
 	diffFlags.BoolVar(&usePgAdmin, "use-pgadmin", false, "Use pgAdmin to generate schema diff.")
 	diffFlags.BoolVar(&usePgSchema, "use-pg-schema", false, "Use pg-schema-diff to generate schema diff.")
+	diffFlags.BoolVar(&useNative, "use-native", false, "Use the built-in engine to generate schema diff instead of a diff tool container.")
 	dbDiffCmd.MarkFlagsMutuallyExclusive("use-migra", "use-pgadmin")
+	dbDiffCmd.MarkFlagsMutuallyExclusive("use-native", "use-pgadmin")
+	dbDiffCmd.MarkFlagsMutuallyExclusive("use-native", "use-pg-schema")
 	diffFlags.String("db-url", "", "Diffs against the database specified by the connection string (must be percent-encoded).")
 	diffFlags.Bool("linked", false, "Diffs local migration files against the linked project.")
 	diffFlags.Bool("local", true, "Diffs local migration files against the local database.")
 	dbDiffCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
 	diffFlags.StringVarP(&file, "file", "f", "", "Saves schema diff to a new migration file.")
 	diffFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema to include.")
+	diffFlags.VarP(&diffOutput, "output", "o", "Output format of the schema diff.")
+	diffFlags.BoolVar(&includeRoles, "include-roles", false, "Saves custom roles and memberships to "+utils.CustomRolesPath+".")
 	dbCmd.AddCommand(dbDiffCmd)
 	// Build dump command
 	dumpFlags := dbDumpCmd.Flags()
@@ -258,8 +329,11 @@ func init() {
 	dumpFlags.BoolVar(&dataOnly, "data-only", false, "Dumps only data records.")
 	dumpFlags.BoolVar(&useCopy, "use-copy", false, "Uses copy statements in place of inserts.")
 	dumpFlags.StringSliceVarP(&excludeTable, "exclude", "x", []string{}, "List of schema.tables to exclude from data-only dump.")
+	dumpFlags.StringVar(&maskConfig, "mask", "", "Path to a YAML file of column masking rules for data-only dump.")
 	dumpFlags.BoolVar(&roleOnly, "role-only", false, "Dumps only cluster roles.")
 	dbDumpCmd.MarkFlagsMutuallyExclusive("role-only", "data-only")
+	dumpFlags.BoolVar(&includeRoles, "include-roles", false, "Saves custom roles and memberships to "+utils.CustomRolesPath+".")
+	dbDumpCmd.MarkFlagsMutuallyExclusive("include-roles", "data-only", "role-only")
 	dumpFlags.BoolVar(&keepComments, "keep-comments", false, "Keeps commented lines from pg_dump output.")
 	dbDumpCmd.MarkFlagsMutuallyExclusive("keep-comments", "data-only")
 	dumpFlags.StringVarP(&file, "file", "f", "", "File path to save the dumped contents.")
@@ -277,7 +351,8 @@ func init() {
 	pushFlags.BoolVar(&includeAll, "include-all", false, "Include all migrations not found on remote history table.")
 	pushFlags.BoolVar(&includeRoles, "include-roles", false, "Include custom roles from "+utils.CustomRolesPath+".")
 	pushFlags.BoolVar(&includeSeed, "include-seed", false, "Include seed data from your config.")
-	pushFlags.BoolVar(&dryRun, "dry-run", false, "Print the migrations that would be applied, but don't actually apply them.")
+	pushFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema that migrations are allowed to modify.")
+	pushFlags.BoolVar(&dryRun, "dry-run", false, "Print the execution plan of migrations that would be applied, but don't actually apply them.")
 	pushFlags.String("db-url", "", "Pushes to the database specified by the connection string (must be percent-encoded).")
 	pushFlags.Bool("linked", true, "Pushes to the linked project.")
 	pushFlags.Bool("local", false, "Pushes to the local database.")
@@ -312,6 +387,7 @@ func init() {
 	resetFlags.BoolVar(&noSeed, "no-seed", false, "Skip running the seed script after reset.")
 	dbResetCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
 	resetFlags.StringVar(&migrationVersion, "version", "", "Reset up to the specified version.")
+	resetFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema to reset without recreating the database.")
 	dbCmd.AddCommand(dbResetCmd)
 	// Build lint command
 	lintFlags := dbLintCmd.Flags()
@@ -325,6 +401,29 @@ func init() {
 	dbCmd.AddCommand(dbLintCmd)
 	// Build start command
 	dbCmd.AddCommand(dbStartCmd)
+	// Build psql command
+	psqlFlags := dbPsqlCmd.Flags()
+	psqlFlags.String("db-url", "", "Connects to the database specified by the connection string (must be percent-encoded).")
+	psqlFlags.Bool("linked", false, "Connects to the linked project.")
+	psqlFlags.Bool("local", true, "Connects to the local database.")
+	dbPsqlCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	dbCmd.AddCommand(dbPsqlCmd)
+	// Build restore command
+	restoreFlags := dbRestoreCmd.Flags()
+	restoreFlags.String("db-url", "", "Restores to the database specified by the connection string (must be percent-encoded).")
+	restoreFlags.Bool("linked", false, "Restores to the linked project.")
+	restoreFlags.Bool("local", true, "Restores to the local database.")
+	dbRestoreCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	dbCmd.AddCommand(dbRestoreCmd)
+	// Build url command
+	urlFlags := dbUrlCmd.Flags()
+	urlFlags.Bool("linked", false, "Prints the direct connection string of the linked project.")
+	urlFlags.Bool("local", true, "Prints the connection string of the local database.")
+	urlFlags.BoolVar(&usePooler, "pooler", false, "Prints the connection pooler string of the linked project.")
+	dbUrlCmd.MarkFlagsMutuallyExclusive("linked", "local")
+	dbUrlCmd.MarkFlagsMutuallyExclusive("pooler", "local")
+	urlFlags.VarP(&urlOutput, "output", "o", "Output format of the connection string.")
+	dbCmd.AddCommand(dbUrlCmd)
 	// Build test command
 	dbCmd.AddCommand(dbTestCmd)
 	testFlags := dbTestCmd.Flags()
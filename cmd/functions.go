@@ -2,15 +2,25 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/functions/cache"
 	"github.com/supabase/cli/internal/functions/delete"
 	"github.com/supabase/cli/internal/functions/deploy"
 	"github.com/supabase/cli/internal/functions/download"
+	"github.com/supabase/cli/internal/functions/invoke"
+	"github.com/supabase/cli/internal/functions/lint"
 	"github.com/supabase/cli/internal/functions/list"
+	"github.com/supabase/cli/internal/functions/logs"
 	new_ "github.com/supabase/cli/internal/functions/new"
+	"github.com/supabase/cli/internal/functions/replay"
 	"github.com/supabase/cli/internal/functions/serve"
+	"github.com/supabase/cli/internal/functions/stats"
+	"github.com/supabase/cli/internal/functions/stop"
+	"github.com/supabase/cli/internal/functions/test"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
 	"github.com/supabase/cli/pkg/cast"
@@ -32,12 +42,19 @@ var (
 		},
 	}
 
+	assumeYes bool
+
 	functionsDeleteCmd = &cobra.Command{
 		Use:   "delete <Function name>",
 		Short: "Delete a Function from Supabase",
 		Long:  "Delete a Function from the linked Supabase project. This does NOT remove the Function locally.",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if !assumeYes {
+				if err := delete.PreRun(cmd.Context(), args[0], flags.ProjectRef); err != nil {
+					return err
+				}
+			}
 			return delete.Run(cmd.Context(), args[0], flags.ProjectRef, afero.NewOsFs())
 		},
 	}
@@ -48,7 +65,13 @@ var (
 		Long:  "Download the source code for a Function from the linked Supabase project.",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return download.Run(cmd.Context(), args[0], flags.ProjectRef, useLegacyBundle, afero.NewOsFs())
+			fsys := afero.NewOsFs()
+			if !assumeYes {
+				if err := download.PreRun(cmd.Context(), args[0], fsys); err != nil {
+					return err
+				}
+			}
+			return download.Run(cmd.Context(), args[0], flags.ProjectRef, useLegacyBundle, fsys)
 		},
 	}
 
@@ -56,16 +79,85 @@ var (
 	useLegacyBundle bool
 	importMapPath   string
 
+	deployAll     bool
+	deployJobs    uint
+	deployDryRun  bool
+	checkRemote   bool
+	deploySave    bool
+	deployPrune   bool
+	analyzeBundle bool
+	deployRefs    []string
+	refFilePath   string
+
 	functionsDeployCmd = &cobra.Command{
 		Use:   "deploy [Function name]",
 		Short: "Deploy a Function to Supabase",
 		Long:  "Deploy a Function to the linked Supabase project.",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(refFilePath) > 0 {
+				refs, err := deploy.LoadRefFile(refFilePath, afero.NewOsFs())
+				if err != nil {
+					return err
+				}
+				deployRefs = append(deployRefs, refs...)
+			}
+			for _, ref := range deployRefs {
+				if err := utils.AssertProjectRefIsValid(ref); err != nil {
+					return err
+				}
+			}
+			// Single project deploys use the first ref
+			if len(deployRefs) > 0 {
+				flags.ProjectRef = deployRefs[0]
+			}
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Fallback to config if user did not set the flag.
 			if !cmd.Flags().Changed("no-verify-jwt") {
 				noVerifyJWT = nil
 			}
-			return deploy.Run(cmd.Context(), args, flags.ProjectRef, noVerifyJWT, importMapPath, afero.NewOsFs())
+			if deployAll && len(args) > 0 {
+				return errors.New("Cannot specify Function names with --all flag.")
+			}
+			if deployDryRun && len(deployRefs) > 1 {
+				return errors.New("Cannot specify multiple projects with --dry-run flag.")
+			}
+			if deployDryRun {
+				return deploy.RunDryRun(cmd.Context(), args, flags.ProjectRef, noVerifyJWT, importMapPath, checkRemote, analyzeBundle, afero.NewOsFs())
+			}
+			if deploySave && noVerifyJWT == nil && len(importMapPath) == 0 {
+				return errors.New("Must specify --no-verify-jwt or --import-map with --save flag.")
+			}
+			fsys := afero.NewOsFs()
+			var err error
+			if len(deployRefs) > 1 {
+				err = deploy.RunMulti(cmd.Context(), args, deployRefs, noVerifyJWT, importMapPath, checkRemote, analyzeBundle, deployJobs, fsys)
+			} else if deployAll {
+				err = deploy.RunAll(cmd.Context(), flags.ProjectRef, noVerifyJWT, importMapPath, checkRemote, analyzeBundle, deployJobs, fsys)
+			} else {
+				err = deploy.Run(cmd.Context(), args, flags.ProjectRef, noVerifyJWT, importMapPath, checkRemote, analyzeBundle, fsys)
+			}
+			if err != nil {
+				return err
+			}
+			if deploySave {
+				if err := deploy.SaveFunctionConfig(args, noVerifyJWT, importMapPath, fsys); err != nil {
+					return err
+				}
+			}
+			if deployPrune {
+				refs := deployRefs
+				if len(refs) == 0 {
+					refs = []string{flags.ProjectRef}
+				}
+				for _, ref := range refs {
+					if err := deploy.RunPrune(cmd.Context(), ref, fsys); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
 		},
 	}
 
@@ -78,13 +170,19 @@ var (
 			return cmd.Root().PersistentPreRunE(cmd, args)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return new_.Run(cmd.Context(), args[0], afero.NewOsFs())
+			return new_.Run(cmd.Context(), args[0], newTemplate.Value, afero.NewOsFs())
 		},
 	}
 
-	envFilePath string
-	inspectBrk  bool
-	inspectMode = utils.EnumFlag{
+	newTemplate = utils.EnumFlag{
+		Allowed: new_.Templates,
+		Value:   new_.TemplateDefault,
+	}
+
+	envFilePath  string
+	envFilePaths []string
+	inspectBrk   bool
+	inspectMode  = utils.EnumFlag{
 		Allowed: []string{
 			string(serve.InspectModeRun),
 			string(serve.InspectModeBrk),
@@ -92,6 +190,7 @@ var (
 		},
 	}
 	runtimeOption serve.RuntimeOption
+	logMaxSize    int64
 
 	functionsServeCmd = &cobra.Command{
 		Use:   "serve",
@@ -114,8 +213,140 @@ var (
 			if runtimeOption.InspectMode == nil && runtimeOption.InspectMain {
 				return fmt.Errorf("--inspect-main must be used together with one of these flags: [inspect inspect-mode]")
 			}
+			runtimeOption.LogMaxSize = logMaxSize * 1024 * 1024
 
-			return serve.Run(cmd.Context(), envFilePath, noVerifyJWT, importMapPath, runtimeOption, afero.NewOsFs())
+			return serve.Run(cmd.Context(), envFilePaths, noVerifyJWT, importMapPath, runtimeOption, afero.NewOsFs())
+		},
+	}
+
+	localLogs  bool
+	followLogs bool
+	tailLogs   uint
+	logsSince  time.Duration
+	logsFilter []string
+
+	functionsLogsCmd = &cobra.Command{
+		Use:   "logs [Function name]",
+		Short: "Show logs of served or deployed Functions",
+		Args:  cobra.MaximumNArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if localLogs {
+				cmd.GroupID = groupLocalDev
+			} else if len(args) == 0 {
+				return errors.New("Must specify a Function name unless --local is set.")
+			}
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if localLogs {
+				return logs.RunLocal(cmd.Context(), followLogs, tailLogs, afero.NewOsFs())
+			}
+			return logs.RunRemote(cmd.Context(), args[0], flags.ProjectRef, followLogs, logsSince, logsFilter, afero.NewOsFs())
+		},
+		Example: `  supabase functions logs --local --follow
+  supabase functions logs hello --since 1h --filter level=error`,
+	}
+
+	checkCache bool
+
+	functionsCacheCmd = &cobra.Command{
+		Use:   "cache [Function name]",
+		Short: "Cache remote dependencies of Functions",
+		Long:  "Cache remote dependencies of local Functions in the shared Deno cache volume.",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cmd.GroupID = groupLocalDev
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cache.Run(cmd.Context(), args, checkCache, analyzeBundle, afero.NewOsFs())
+		},
+	}
+
+	functionsReplayCmd = &cobra.Command{
+		Use:   "replay <path>",
+		Short: "Replay recorded requests against locally served Functions",
+		Args:  cobra.ExactArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cmd.GroupID = groupLocalDev
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return replay.Run(cmd.Context(), args[0], afero.NewOsFs())
+		},
+	}
+
+	invokeOption invoke.InvokeOptions
+
+	functionsInvokeCmd = &cobra.Command{
+		Use:   "invoke <Function name>",
+		Short: "Invoke a Function locally or on the linked project",
+		Args:  cobra.ExactArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// Only remote invocations require logging in to the management api
+			if !cmd.Flags().Changed("linked") && !cmd.Flags().Changed("project-ref") {
+				cmd.GroupID = groupLocalDev
+			}
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			projectRef := flags.ProjectRef
+			if cmd.GroupID == groupLocalDev {
+				projectRef = ""
+			}
+			return invoke.Run(cmd.Context(), args[0], projectRef, invokeOption, afero.NewOsFs())
+		},
+		Example: `  supabase functions invoke hello --body '{"name":"world"}'
+  supabase functions invoke hello --linked --service-role --header x-region=us-east-1`,
+	}
+
+	functionsTestCmd = &cobra.Command{
+		Use:   "test [Function name]",
+		Short: "Run deno tests of Functions against the local stack",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cmd.GroupID = groupLocalDev
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return test.Run(cmd.Context(), args, envFilePaths, afero.NewOsFs())
+		},
+	}
+
+	functionsLintCmd = &cobra.Command{
+		Use:   "lint [Function name]",
+		Short: "Check env references of Functions against project secrets",
+		Long:  "Scan Function sources for Deno.env.get calls and report env vars that are not set as secrets on the linked project.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return lint.Run(cmd.Context(), args, flags.ProjectRef, envFilePaths, afero.NewOsFs())
+		},
+	}
+
+	statsWindow time.Duration
+
+	functionsStatsCmd = &cobra.Command{
+		Use:   "stats [Function name]",
+		Short: "Show invocation metrics of deployed Functions",
+		Long:  "Show invocation counts, error rates and p50/p95 execution times of Functions on the linked project.",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var slug string
+			if len(args) > 0 {
+				slug = args[0]
+			}
+			return stats.Run(cmd.Context(), slug, flags.ProjectRef, statsWindow, afero.NewOsFs())
+		},
+		Example: `  supabase functions stats --window 1h
+  supabase functions stats hello --window 168h --output json`,
+	}
+
+	functionsStopCmd = &cobra.Command{
+		Use:   "stop",
+		Short: "Stop serving Functions locally",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			cmd.GroupID = groupLocalDev
+			return cmd.Root().PersistentPreRunE(cmd, args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return stop.Run(cmd.Context(), afero.NewOsFs())
 		},
 	}
 )
@@ -123,27 +354,88 @@ var (
 func init() {
 	functionsListCmd.Flags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 	functionsDeleteCmd.Flags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	functionsDeleteCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Skip the confirmation prompt.")
 	functionsDeployCmd.Flags().BoolVar(noVerifyJWT, "no-verify-jwt", false, "Disable JWT verification for the Function.")
-	functionsDeployCmd.Flags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	functionsDeployCmd.Flags().StringArrayVar(&deployRefs, "project-ref", []string{}, "Project ref of the Supabase project. Repeat to deploy to multiple projects.")
+	functionsDeployCmd.Flags().StringVar(&refFilePath, "ref-file", "", "Path to a file of project refs to deploy to, one per line.")
 	functionsDeployCmd.Flags().BoolVar(&useLegacyBundle, "legacy-bundle", false, "Use legacy bundling mechanism.")
 	functionsDeployCmd.Flags().StringVar(&importMapPath, "import-map", "", "Path to import map file.")
 	cobra.CheckErr(functionsDeployCmd.Flags().MarkHidden("legacy-bundle"))
+	functionsDeployCmd.Flags().BoolVar(&deployAll, "all", false, "Deploy all Functions concurrently.")
+	functionsDeployCmd.Flags().UintVarP(&deployJobs, "concurrency", "j", 4, "Maximum number of Functions to deploy concurrently with --all.")
+	functionsDeployCmd.Flags().BoolVar(&checkRemote, "check-remote", false, "Verify that remote modules in import maps can be fetched before deploying.")
+	functionsDeployCmd.Flags().BoolVar(&deployDryRun, "dry-run", false, "Print the Functions that would be created or updated without deploying them. Only Function settings are compared, not source code.")
+	functionsDeployCmd.Flags().BoolVar(&analyzeBundle, "analyze", false, "Print the size of every module in each Function bundle.")
+	functionsDeployCmd.Flags().BoolVar(&deploySave, "save", false, "Save --no-verify-jwt and --import-map to the Function config in "+utils.ConfigPath+".")
+	functionsDeployCmd.MarkFlagsMutuallyExclusive("save", "dry-run")
+	functionsDeployCmd.Flags().BoolVar(&deployPrune, "prune", false, "Delete Functions from the project that no longer exist locally. Functions ignored by supabase/functions/.supabaseignore are kept.")
+	functionsDeployCmd.MarkFlagsMutuallyExclusive("prune", "dry-run")
 	functionsServeCmd.Flags().BoolVar(noVerifyJWT, "no-verify-jwt", false, "Disable JWT verification for the Function.")
-	functionsServeCmd.Flags().StringVar(&envFilePath, "env-file", "", "Path to an env file to be populated to the Function environment.")
+	functionsServeCmd.Flags().StringArrayVar(&envFilePaths, "env-file", []string{}, "Path to an env file to be populated to the Function environment. Repeat to merge multiple files in order.")
 	functionsServeCmd.Flags().StringVar(&importMapPath, "import-map", "", "Path to import map file.")
 	functionsServeCmd.Flags().BoolVar(&inspectBrk, "inspect", false, "Alias of --inspect-mode brk.")
 	functionsServeCmd.Flags().Var(&inspectMode, "inspect-mode", "Activate inspector capability for debugging.")
 	functionsServeCmd.Flags().BoolVar(&runtimeOption.InspectMain, "inspect-main", false, "Allow inspecting the main worker.")
 	functionsServeCmd.MarkFlagsMutuallyExclusive("inspect", "inspect-mode")
+	functionsServeCmd.Flags().BoolVarP(&runtimeOption.Detach, "detach", "d", false, "Run the Functions runtime in the background.")
+	functionsServeCmd.Flags().BoolVar(&runtimeOption.Metrics, "metrics", false, "Expose Prometheus metrics of served Functions on /functions/v1/metrics.")
+	functionsServeCmd.Flags().StringArrayVar(&runtimeOption.DenoArgs, "deno-args", []string{}, "Additional flag to pass through to the runtime. Repeat to pass multiple flags.")
+	functionsServeCmd.Flags().StringVar(&runtimeOption.RecordPath, "record", "", "Path to an NDJSON file for recording incoming requests.")
+	functionsServeCmd.Flags().BoolVar(&runtimeOption.UseLinkedSecrets, "use-linked-secrets", false, "Verify and populate secrets of the linked project.")
+	functionsServeCmd.Flags().BoolVar(&runtimeOption.Tls, "tls", false, "Serve Functions over HTTPS with a self-signed certificate.")
+	functionsServeCmd.Flags().StringVar(&runtimeOption.TlsCert, "tls-cert", "", "Path to a TLS certificate for serving over HTTPS.")
+	functionsServeCmd.Flags().StringVar(&runtimeOption.TlsKey, "tls-key", "", "Path to the private key of the TLS certificate.")
+	functionsServeCmd.MarkFlagsRequiredTogether("tls-cert", "tls-key")
+	functionsServeCmd.Flags().StringVar(&runtimeOption.LogFile, "log-file", "", "Path to a file for saving a copy of the runtime logs.")
+	functionsServeCmd.Flags().Int64Var(&logMaxSize, "log-max-size", 10, "Rotate the log file after it grows beyond this size in MB.")
+	functionsServeCmd.Flags().DurationVar(&runtimeOption.LogMaxAge, "log-max-age", 24*time.Hour, "Rotate the log file after it has been written for this duration.")
+	functionsServeCmd.MarkFlagsMutuallyExclusive("detach", "log-file")
+	functionsServeCmd.Flags().BoolVarP(&runtimeOption.Quiet, "quiet", "q", false, "Hide module downloads and runtime messages from the logs.")
+	functionsServeCmd.Flags().BoolVar(&runtimeOption.CachedOnly, "cached-only", false, "Check that all dependencies are cached or vendored before serving. The runtime still has network access.")
 	functionsServeCmd.Flags().Bool("all", true, "Serve all Functions.")
 	cobra.CheckErr(functionsServeCmd.Flags().MarkHidden("all"))
 	functionsDownloadCmd.Flags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 	functionsDownloadCmd.Flags().BoolVar(&useLegacyBundle, "legacy-bundle", false, "Use legacy bundling mechanism.")
+	functionsDownloadCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Overwrite local files without prompting.")
+	functionsLogsCmd.Flags().BoolVar(&localLogs, "local", false, "Show logs from the locally served Functions.")
+	functionsLogsCmd.Flags().BoolVarP(&followLogs, "follow", "f", false, "Follow log output.")
+	functionsLogsCmd.Flags().UintVar(&tailLogs, "tail", 0, "Number of lines to show from the end of the local logs.")
+	functionsLogsCmd.Flags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	functionsLogsCmd.Flags().DurationVar(&logsSince, "since", time.Hour, "Show deployed Function logs within this duration.")
+	functionsLogsCmd.Flags().StringArrayVar(&logsFilter, "filter", []string{}, "Filter deployed Function logs by level or event_type, ie. level=error.")
+	functionsLogsCmd.MarkFlagsMutuallyExclusive("local", "project-ref")
+	functionsLogsCmd.MarkFlagsMutuallyExclusive("local", "since")
+	functionsLogsCmd.MarkFlagsMutuallyExclusive("local", "filter")
+	invokeFlags := functionsInvokeCmd.Flags()
+	invokeFlags.Bool("local", true, "Invokes the locally served Function.")
+	invokeFlags.Bool("linked", false, "Invokes the Function deployed to the linked project.")
+	invokeFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	functionsInvokeCmd.MarkFlagsMutuallyExclusive("local", "linked", "project-ref")
+	invokeFlags.StringVarP(&invokeOption.Method, "method", "X", "POST", "HTTP method of the request.")
+	invokeFlags.StringVarP(&invokeOption.Body, "body", "d", "", "JSON body of the request.")
+	invokeFlags.StringArrayVarP(&invokeOption.Headers, "header", "H", []string{}, "Additional request headers formatted as key=value.")
+	invokeFlags.BoolVar(&invokeOption.ServiceRole, "service-role", false, "Authorize with the service role key instead of the anon key.")
+	functionsTestCmd.Flags().StringArrayVar(&envFilePaths, "env-file", []string{}, "Path to an env file to be populated to the Function environment when serving for tests.")
+	functionsLintCmd.Flags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	functionsLintCmd.Flags().StringArrayVar(&envFilePaths, "env-file", []string{}, "Path to a local env file to check against project secrets.")
+	functionsStatsCmd.Flags().StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
+	functionsStatsCmd.Flags().DurationVar(&statsWindow, "window", 24*time.Hour, "Aggregate metrics of Function invocations within this duration.")
+	functionsNewCmd.Flags().Var(&newTemplate, "template", "Template to create the Function from.")
+	functionsCacheCmd.Flags().BoolVar(&checkCache, "check", false, "Stop at the first Function whose dependencies cannot be resolved.")
+	functionsCacheCmd.Flags().BoolVar(&analyzeBundle, "analyze", false, "Print the size of every module in each Function bundle.")
 	functionsCmd.AddCommand(functionsListCmd)
 	functionsCmd.AddCommand(functionsDeleteCmd)
 	functionsCmd.AddCommand(functionsDeployCmd)
 	functionsCmd.AddCommand(functionsNewCmd)
 	functionsCmd.AddCommand(functionsServeCmd)
 	functionsCmd.AddCommand(functionsDownloadCmd)
+	functionsCmd.AddCommand(functionsLogsCmd)
+	functionsCmd.AddCommand(functionsStopCmd)
+	functionsCmd.AddCommand(functionsCacheCmd)
+	functionsCmd.AddCommand(functionsReplayCmd)
+	functionsCmd.AddCommand(functionsInvokeCmd)
+	functionsCmd.AddCommand(functionsTestCmd)
+	functionsCmd.AddCommand(functionsLintCmd)
+	functionsCmd.AddCommand(functionsStatsCmd)
 	rootCmd.AddCommand(functionsCmd)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/supabase/cli/internal/gen/keys"
+	genSchema "github.com/supabase/cli/internal/gen/schema"
 	"github.com/supabase/cli/internal/gen/types"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/internal/utils/flags"
@@ -32,9 +33,12 @@ var (
 		Value: utils.OutputEnv,
 	}
 
+	keySeed  string
+	keyLocal bool
+
 	genKeysCmd = &cobra.Command{
 		Use:   "keys",
-		Short: "Generate keys for preview branch",
+		Short: "Generate keys for preview branch or the local stack",
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 			es, err := env.EnvironToEnvSet(override)
 			if err != nil {
@@ -43,12 +47,23 @@ var (
 			if err := env.Unmarshal(es, &keyNames); err != nil {
 				return err
 			}
-			cmd.GroupID = groupManagementAPI
+			if len(keySeed) > 0 && !keyLocal {
+				return errors.New("--seed can only be used together with --local")
+			}
+			// Local keys are generated without logging in to the management api
+			if !keyLocal {
+				cmd.GroupID = groupManagementAPI
+			}
 			return cmd.Root().PersistentPreRunE(cmd, args)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if keyLocal {
+				return keys.RunLocal(keySeed, keyOutput.Value, keyNames, afero.NewOsFs())
+			}
 			return keys.Run(cmd.Context(), flags.ProjectRef, keyOutput.Value, keyNames, afero.NewOsFs())
 		},
+		Example: `  supabase gen keys --project-ref abcdefghijklmnopqrst
+  supabase gen keys --local --seed my-team -o env`,
 	}
 
 	lang = utils.EnumFlag{
@@ -56,6 +71,8 @@ var (
 			types.LangTypescript,
 			types.LangGo,
 			types.LangSwift,
+			types.LangKotlin,
+			types.LangPython,
 		},
 		Value: types.LangTypescript,
 	}
@@ -67,17 +84,59 @@ var (
 		},
 		Value: types.SwiftInternalAccessControl,
 	}
+	goNative   bool
+	goNullable = utils.EnumFlag{
+		Allowed: []string{
+			types.GoNullablePointer,
+			types.GoNullableSql,
+		},
+		Value: types.GoNullablePointer,
+	}
+	pythonFlavor = utils.EnumFlag{
+		Allowed: []string{
+			types.PythonFlavorPydantic,
+			types.PythonFlavorDataclass,
+		},
+		Value: types.PythonFlavorPydantic,
+	}
+	enumStyle = utils.EnumFlag{
+		Allowed: []string{
+			types.EnumStyleUnion,
+			types.EnumStyleEnum,
+			types.EnumStyleConstObject,
+		},
+	}
+	watchTypes bool
+	typesFile  string
+	includes   []string
+	excludes   []string
+	splitDir   string
 
 	genTypesCmd = &cobra.Command{
 		Use:   "types",
 		Short: "Generate types from Postgres schema",
+		Long: `Generate types from Postgres schema.
+
+Go types are generated by pg-meta by default. Pass --go-native to generate them from the database catalog instead. The native generator is not compatible with pg-meta output: each table has a single struct instead of separate Select, Insert and Update structs, timestamptz columns are typed as time.Time while date and timestamp columns are kept as strings, and nullable columns are typed according to --go-nullable.`,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			if postgrestV9Compat && !cmd.Flags().Changed("db-url") {
 				return errors.New("--postgrest-v9-compat can only be used together with --db-url")
 			}
 			// Legacy commands specify language using arg, eg. gen types typescript
-			if len(args) > 0 && args[0] != types.LangTypescript && !cmd.Flags().Changed("lang") {
-				return errors.New("use --lang flag to specify the typegen language")
+			if len(args) > 0 && !cmd.Flags().Changed("lang") {
+				if err := lang.Set(args[0]); err != nil {
+					return errors.New("use --lang flag to specify the typegen language")
+				}
+			}
+			if watchTypes && !cmd.Flags().Changed("local") && !cmd.Flags().Changed("db-url") {
+				return errors.New("--watch can only be used together with --local or --db-url")
+			}
+			if cmd.Flags().Changed("go-nullable") && !goNative {
+				return errors.New("--go-nullable can only be used together with --go-native")
+			}
+			// Swift types from pg-meta cannot be filtered by table yet
+			if (len(includes) > 0 || len(excludes) > 0) && lang.Value == types.LangSwift {
+				return errors.Errorf("--include and --exclude are not supported for %s types", lang.Value)
 			}
 			return nil
 		},
@@ -91,12 +150,52 @@ var (
 					return err
 				}
 			}
-			return types.Run(ctx, flags.ProjectRef, flags.DbConfig, lang.Value, schema, postgrestV9Compat, swiftAccessControl.Value, afero.NewOsFs())
+			// An empty nullable representation selects pg-meta for Go types
+			nullable := goNullable.Value
+			if !goNative {
+				nullable = ""
+			}
+			if len(splitDir) > 0 {
+				return types.RunSplit(ctx, flags.DbConfig, lang.Value, schema, includes, excludes, nullable, pythonFlavor.Value, enumStyle.Value, splitDir, afero.NewOsFs())
+			}
+			if watchTypes {
+				return types.Watch(ctx, flags.DbConfig, lang.Value, schema, includes, excludes, postgrestV9Compat, swiftAccessControl.Value, nullable, pythonFlavor.Value, enumStyle.Value, typesFile, afero.NewOsFs())
+			}
+			return types.Run(ctx, flags.ProjectRef, flags.DbConfig, lang.Value, schema, includes, excludes, postgrestV9Compat, swiftAccessControl.Value, nullable, pythonFlavor.Value, enumStyle.Value, afero.NewOsFs())
 		},
 		Example: `  supabase gen types --local
   supabase gen types --linked --lang=go
+  supabase gen types go --local --go-native --go-nullable sql > types.go
+  supabase gen types kotlin --db-url 'postgresql://...' > Database.kt
+  supabase gen types python --local --flavor dataclass > database.py
+  supabase gen types --local --enum-style const-object > database.types.ts
   supabase gen types --project-id abc-def-123 --schema public --schema private
-  supabase gen types --db-url 'postgresql://...' --schema public --schema auth`,
+  supabase gen types --db-url 'postgresql://...' --schema public --schema auth
+  supabase gen types go --local --go-native --include 'public.*' --exclude '*_audit'
+  supabase gen types --local --watch -f src/database.types.ts
+  supabase gen types python --local --split-dir types/`,
+	}
+
+	schemaFormat = utils.EnumFlag{
+		Allowed: []string{
+			genSchema.FormatOpenApi,
+			genSchema.FormatJsonSchema,
+		},
+	}
+	schemaServiceRole bool
+
+	genSchemaCmd = &cobra.Command{
+		Use:   "schema <openapi|jsonschema>",
+		Short: "Generate API schema of the local PostgREST",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := schemaFormat.Set(args[0]); err != nil {
+				return err
+			}
+			return genSchema.Run(cmd.Context(), schemaFormat.Value, schemaServiceRole, afero.NewOsFs())
+		},
+		Example: `  supabase gen schema openapi > openapi.json
+  supabase gen schema jsonschema --service-role > schema.json`,
 	}
 )
 
@@ -109,13 +208,31 @@ func init() {
 	genTypesCmd.MarkFlagsMutuallyExclusive("local", "linked", "project-id", "db-url")
 	typeFlags.Var(&lang, "lang", "Output language of the generated types.")
 	typeFlags.StringSliceVarP(&schema, "schema", "s", []string{}, "Comma separated list of schema to include.")
+	typeFlags.StringSliceVar(&includes, "include", []string{}, "Glob patterns of tables to include, ie. public.* or profiles. Not supported for Swift types.")
+	typeFlags.StringSliceVar(&excludes, "exclude", []string{}, "Glob patterns of tables to exclude. Not supported for Swift types.")
 	typeFlags.Var(&swiftAccessControl, "swift-access-control", "Access control for Swift generated types.")
+	typeFlags.BoolVar(&goNative, "go-native", false, "Generate Go types from the database catalog instead of pg-meta.")
+	typeFlags.Var(&goNullable, "go-nullable", "Representation of nullable columns in Go generated types. Only use together with --go-native.")
+	typeFlags.Var(&pythonFlavor, "flavor", "Model flavor of Python generated types.")
+	typeFlags.Var(&enumStyle, "enum-style", "Representation of enums in generated types. Defaults to union for TypeScript and enum for other languages.")
 	typeFlags.BoolVar(&postgrestV9Compat, "postgrest-v9-compat", false, "Generate types compatible with PostgREST v9 and below. Only use together with --db-url.")
+	typeFlags.BoolVar(&watchTypes, "watch", false, "Regenerate types whenever the database schema changes.")
+	typeFlags.StringVarP(&typesFile, "file", "f", "", "Path to write generated types in watch mode.")
+	genTypesCmd.MarkFlagsRequiredTogether("watch", "file")
+	typeFlags.StringVar(&splitDir, "split-dir", "", "Directory to write one file per table or view. Only supported for Go, Kotlin, and Python types.")
+	genTypesCmd.MarkFlagsMutuallyExclusive("split-dir", "watch")
+	genTypesCmd.MarkFlagsMutuallyExclusive("split-dir", "project-id")
 	genCmd.AddCommand(genTypesCmd)
+	schemaFlags := genSchemaCmd.Flags()
+	schemaFlags.BoolVar(&schemaServiceRole, "service-role", false, "Include tables and functions only accessible to the service role.")
+	genCmd.AddCommand(genSchemaCmd)
 	keyFlags := genKeysCmd.Flags()
 	keyFlags.StringVar(&flags.ProjectRef, "project-ref", "", "Project ref of the Supabase project.")
 	keyFlags.VarP(&keyOutput, "output", "o", "Output format of key variables.")
 	keyFlags.StringSliceVar(&override, "override-name", []string{}, "Override specific variable names.")
+	keyFlags.BoolVar(&keyLocal, "local", false, "Generate JWT secret and API keys for the local stack.")
+	keyFlags.StringVar(&keySeed, "seed", "", "Derive the local JWT secret from a shared seed instead of randomly.")
+	genKeysCmd.MarkFlagsMutuallyExclusive("local", "project-ref")
 	genCmd.AddCommand(genKeysCmd)
 	rootCmd.AddCommand(genCmd)
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/migration/down"
 	"github.com/supabase/cli/internal/migration/fetch"
 	"github.com/supabase/cli/internal/migration/list"
 	"github.com/supabase/cli/internal/migration/new"
@@ -44,21 +45,29 @@ var (
 		Short: "Create an empty migration script",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return new.Run(args[0], os.Stdin, afero.NewOsFs())
+			return new.Run(args[0], migrationTemplate.Value, os.Stdin, afero.NewOsFs())
 		},
 	}
 
+	migrationTemplate = utils.EnumFlag{
+		Allowed: new.Templates,
+	}
+
 	targetStatus = utils.EnumFlag{
 		Allowed: []string{
 			repair.Applied,
 			repair.Reverted,
 		},
 	}
+	recalculate bool
 
 	migrationRepairCmd = &cobra.Command{
 		Use:   "repair [version] ...",
 		Short: "Repair the migration history table",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if recalculate {
+				return repair.RunRecalculate(cmd.Context(), flags.DbConfig, args, afero.NewOsFs())
+			}
 			return repair.Run(cmd.Context(), flags.DbConfig, args, targetStatus.Value, afero.NewOsFs())
 		},
 		PostRun: func(cmd *cobra.Command, args []string) {
@@ -67,12 +76,17 @@ var (
 	}
 
 	migrationVersion string
+	squashFrom       string
+	squashArchive    bool
 
 	migrationSquashCmd = &cobra.Command{
 		Use:   "squash",
 		Short: "Squash migrations to a single file",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return squash.Run(cmd.Context(), migrationVersion, flags.DbConfig, afero.NewOsFs())
+			if len(squashFrom) > 0 {
+				return squash.RunRange(cmd.Context(), squashFrom, migrationVersion, squashArchive, flags.DbConfig, afero.NewOsFs())
+			}
+			return squash.Run(cmd.Context(), migrationVersion, squashArchive, flags.DbConfig, afero.NewOsFs())
 		},
 		PostRun: func(cmd *cobra.Command, args []string) {
 			fmt.Println("Finished " + utils.Aqua("supabase migration squash") + ".")
@@ -90,6 +104,19 @@ var (
 		},
 	}
 
+	downCount uint
+
+	migrationDownCmd = &cobra.Command{
+		Use:   "down",
+		Short: "Revert the last migrations using their down scripts",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return down.Run(cmd.Context(), downCount, flags.DbConfig, afero.NewOsFs())
+		},
+		PostRun: func(cmd *cobra.Command, args []string) {
+			fmt.Println("Finished " + utils.Aqua("supabase migration down") + ".")
+		},
+	}
+
 	migrationFetchCmd = &cobra.Command{
 		Use:   "fetch",
 		Short: "Fetch migration files from history table",
@@ -113,7 +140,9 @@ func init() {
 	// Build repair command
 	repairFlags := migrationRepairCmd.Flags()
 	repairFlags.Var(&targetStatus, "status", "Version status to update.")
-	cobra.CheckErr(migrationRepairCmd.MarkFlagRequired("status"))
+	repairFlags.BoolVar(&recalculate, "recalculate", false, "Update the recorded statements of applied migrations to match local files.")
+	migrationRepairCmd.MarkFlagsOneRequired("status", "recalculate")
+	migrationRepairCmd.MarkFlagsMutuallyExclusive("status", "recalculate")
 	repairFlags.String("db-url", "", "Repairs migrations of the database specified by the connection string (must be percent-encoded).")
 	repairFlags.Bool("linked", true, "Repairs the migration history of the linked project.")
 	repairFlags.Bool("local", false, "Repairs the migration history of the local database.")
@@ -125,6 +154,10 @@ func init() {
 	// Build squash command
 	squashFlags := migrationSquashCmd.Flags()
 	squashFlags.StringVar(&migrationVersion, "version", "", "Squash up to the specified version.")
+	squashFlags.StringVar(&migrationVersion, "to", "", "Squash up to the specified version.")
+	squashFlags.StringVar(&squashFrom, "from", "", "Squash from the specified version, keeping earlier migrations.")
+	squashFlags.BoolVar(&squashArchive, "archive", false, "Move squashed migration files to "+utils.MigrationsArchiveDir+" instead of deleting them.")
+	migrationSquashCmd.MarkFlagsMutuallyExclusive("version", "to")
 	squashFlags.String("db-url", "", "Squashes migrations of the database specified by the connection string (must be percent-encoded).")
 	squashFlags.Bool("linked", false, "Squashes the migration history of the linked project.")
 	squashFlags.Bool("local", true, "Squashes the migration history of the local database.")
@@ -141,7 +174,15 @@ func init() {
 	upFlags.Bool("local", true, "Applies pending migrations to the local database.")
 	migrationUpCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
 	migrationCmd.AddCommand(migrationUpCmd)
-	// Build up command
+	// Build down command
+	downFlags := migrationDownCmd.Flags()
+	downFlags.UintVar(&downCount, "count", 1, "Number of migrations to revert.")
+	downFlags.String("db-url", "", "Reverts migrations of the database specified by the connection string (must be percent-encoded).")
+	downFlags.Bool("linked", false, "Reverts migrations applied to the linked project.")
+	downFlags.Bool("local", true, "Reverts migrations applied to the local database.")
+	migrationDownCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
+	migrationCmd.AddCommand(migrationDownCmd)
+	// Build fetch command
 	fetchFlags := migrationFetchCmd.Flags()
 	fetchFlags.String("db-url", "", "Fetches migrations from the database specified by the connection string (must be percent-encoded).")
 	fetchFlags.Bool("linked", true, "Fetches migration history from the linked project.")
@@ -149,6 +190,7 @@ func init() {
 	migrationFetchCmd.MarkFlagsMutuallyExclusive("db-url", "linked", "local")
 	migrationCmd.AddCommand(migrationFetchCmd)
 	// Build new command
+	migrationNewCmd.Flags().Var(&migrationTemplate, "template", "Template to generate the migration from.")
 	migrationCmd.AddCommand(migrationNewCmd)
 	rootCmd.AddCommand(migrationCmd)
 }
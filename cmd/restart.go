@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"github.com/supabase/cli/internal/start"
+)
+
+var (
+	restartIgnoreHealthCheck bool
+
+	restartCmd = &cobra.Command{
+		GroupID: groupLocalDev,
+		Use:     "restart <service>",
+		Short:   "Recreate a single local Supabase container",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, _ := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			return start.Restart(ctx, args[0], restartIgnoreHealthCheck, afero.NewOsFs())
+		},
+	}
+)
+
+func init() {
+	restartCmd.Flags().BoolVar(&restartIgnoreHealthCheck, "ignore-health-check", false, "Ignore unhealthy services and exit 0")
+	rootCmd.AddCommand(restartCmd)
+}
@@ -117,7 +117,7 @@ func Run(ctx context.Context, starter StarterTemplate, fsys afero.Fs, options ..
 	}
 	policy.Reset()
 	if err := backoff.RetryNotify(func() error {
-		return push.Run(ctx, false, false, true, true, config, fsys)
+		return push.Run(ctx, false, false, true, true, nil, config, fsys)
 	}, policy, newErrorCallback()); err != nil {
 		return err
 	}
@@ -0,0 +1,481 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/pkg/pgxv5"
+)
+
+type objectKind int
+
+// Kinds are declared in the order they must be created. Functions come after tables
+// because they may reference row types, while column defaults may call functions.
+const (
+	kindSchema objectKind = iota
+	kindEnum
+	kindTable
+	kindSequence
+	kindColumn
+	kindFunction
+	kindColumnDefault
+	kindSequenceOwner
+	kindConstraint
+	kindForeignKey
+	kindIndex
+	kindView
+	kindTrigger
+	kindRowSecurity
+	kindPolicy
+)
+
+type column struct {
+	Type      string
+	Default   string
+	NotNull   bool
+	Identity  string
+	Generated string
+}
+
+func (c column) definition() string {
+	def := c.Type
+	if c.Generated == "s" {
+		def += fmt.Sprintf(" generated always as (%s) stored", c.Default)
+	} else if len(c.Default) > 0 {
+		def += " default " + c.Default
+	}
+	switch c.Identity {
+	case "a":
+		def += " generated always as identity"
+	case "d":
+		def += " generated by default as identity"
+	}
+	if c.NotNull {
+		def += " not null"
+	}
+	return def
+}
+
+type dbObject struct {
+	kind objectKind
+	// Quoted identity of the object, qualified by its parent if any
+	name   string
+	parent string
+	order  int
+	create string
+	drop   string
+	// Objects that can be updated in place by their create statement, as long as
+	// their signature, ie. function result or view columns, is unchanged
+	replace   bool
+	signature string
+	column    *column
+	labels    []string
+}
+
+type catalog map[string]dbObject
+
+func (c catalog) add(obj dbObject) {
+	c[fmt.Sprintf("%d:%s", obj.kind, obj.name)] = obj
+}
+
+// Excludes objects created by extensions, which are managed by create extension.
+const notExtensionMember = `not exists (
+  select 1 from pg_depend d
+  where d.classid = '%s'::regclass and d.objid = %s and d.deptype = 'e'
+)`
+
+var (
+	schemaQuery = `select format('%I', nspname) as name
+from pg_namespace
+where nspname = any($1)`
+
+	enumQuery = `select format('%I.%I', n.nspname, t.typname) as name,
+  array_agg(quote_literal(e.enumlabel) order by e.enumsortorder)::text[] as labels
+from pg_type t
+join pg_namespace n on n.oid = t.typnamespace
+join pg_enum e on e.enumtypid = t.oid
+where n.nspname = any($1) and ` + fmt.Sprintf(notExtensionMember, "pg_type", "t.oid") + `
+group by n.nspname, t.typname`
+
+	functionQuery = `select format('%I.%I(%s)', n.nspname, p.proname, pg_get_function_identity_arguments(p.oid)) as name,
+  p.prokind::text as kind,
+  pg_get_functiondef(p.oid) as def,
+  coalesce(pg_get_function_result(p.oid), '') as signature
+from pg_proc p
+join pg_namespace n on n.oid = p.pronamespace
+where n.nspname = any($1) and p.prokind in ('f', 'p') and ` + fmt.Sprintf(notExtensionMember, "pg_proc", "p.oid")
+
+	tableQuery = `select format('%I.%I', n.nspname, c.relname) as name,
+  c.relrowsecurity as rls
+from pg_class c
+join pg_namespace n on n.oid = c.relnamespace
+where n.nspname = any($1) and c.relkind in ('r', 'p') and not c.relispartition and ` + fmt.Sprintf(notExtensionMember, "pg_class", "c.oid")
+
+	// Identity sequences are created by their column definition.
+	sequenceQuery = `select format('%I.%I', n.nspname, c.relname) as name,
+  format_type(s.seqtypid, null) as type,
+  s.seqstart as start,
+  s.seqincrement as increment,
+  s.seqmin as min,
+  s.seqmax as max,
+  s.seqcache as cache,
+  s.seqcycle as cycle,
+  coalesce(format('%I.%I', tn.nspname, t.relname), '') as owner_table,
+  coalesce(format('%I', a.attname), '') as owner_column
+from pg_sequence s
+join pg_class c on c.oid = s.seqrelid
+join pg_namespace n on n.oid = c.relnamespace
+left join pg_depend d on d.classid = 'pg_class'::regclass and d.objid = c.oid
+  and d.refclassid = 'pg_class'::regclass and d.deptype = 'a'
+left join pg_class t on t.oid = d.refobjid
+left join pg_namespace tn on tn.oid = t.relnamespace
+left join pg_attribute a on a.attrelid = d.refobjid and a.attnum = d.refobjsubid
+where n.nspname = any($1) and not exists (
+    select 1 from pg_depend i
+    where i.classid = 'pg_class'::regclass and i.objid = c.oid and i.deptype = 'i'
+  ) and ` + fmt.Sprintf(notExtensionMember, "pg_class", "c.oid")
+
+	columnQuery = `select format('%I.%I', n.nspname, c.relname) as table,
+  format('%I', a.attname) as name,
+  a.attnum::int as position,
+  format_type(a.atttypid, a.atttypmod) as type,
+  coalesce(pg_get_expr(d.adbin, d.adrelid), '') as default,
+  a.attnotnull as not_null,
+  a.attidentity::text as identity,
+  a.attgenerated::text as generated
+from pg_attribute a
+join pg_class c on c.oid = a.attrelid
+join pg_namespace n on n.oid = c.relnamespace
+left join pg_attrdef d on d.adrelid = a.attrelid and d.adnum = a.attnum
+where n.nspname = any($1) and c.relkind in ('r', 'p') and not c.relispartition
+  and a.attnum > 0 and not a.attisdropped and ` + fmt.Sprintf(notExtensionMember, "pg_class", "c.oid")
+
+	constraintQuery = `select format('%I.%I', n.nspname, c.relname) as table,
+  format('%I', con.conname) as name,
+  con.contype::text as type,
+  pg_get_constraintdef(con.oid) as def
+from pg_constraint con
+join pg_class c on c.oid = con.conrelid
+join pg_namespace n on n.oid = c.relnamespace
+where n.nspname = any($1) and con.contype in ('p', 'u', 'c', 'f', 'x')
+  and c.relkind in ('r', 'p') and not c.relispartition and ` + fmt.Sprintf(notExtensionMember, "pg_class", "c.oid")
+
+	indexQuery = `select format('%I.%I', n.nspname, c.relname) as table,
+  format('%I.%I', n.nspname, i.relname) as name,
+  pg_get_indexdef(i.oid) as def
+from pg_index x
+join pg_class i on i.oid = x.indexrelid
+join pg_class c on c.oid = x.indrelid
+join pg_namespace n on n.oid = c.relnamespace
+where n.nspname = any($1) and c.relkind in ('r', 'p', 'm') and not c.relispartition
+  and not exists (
+    select 1 from pg_constraint con
+    where con.conindid = x.indexrelid and con.contype in ('p', 'u', 'x')
+  ) and ` + fmt.Sprintf(notExtensionMember, "pg_class", "c.oid")
+
+	viewQuery = `select format('%I.%I', n.nspname, c.relname) as name,
+  c.relkind::text as kind,
+  pg_get_viewdef(c.oid) as def,
+  coalesce((
+    select string_agg(format('%I %s', a.attname, format_type(a.atttypid, a.atttypmod)), ', ' order by a.attnum)
+    from pg_attribute a where a.attrelid = c.oid and a.attnum > 0 and not a.attisdropped
+  ), '') as signature
+from pg_class c
+join pg_namespace n on n.oid = c.relnamespace
+where n.nspname = any($1) and c.relkind in ('v', 'm') and ` + fmt.Sprintf(notExtensionMember, "pg_class", "c.oid")
+
+	triggerQuery = `select format('%I.%I', n.nspname, c.relname) as table,
+  format('%I', t.tgname) as name,
+  pg_get_triggerdef(t.oid) as def
+from pg_trigger t
+join pg_class c on c.oid = t.tgrelid
+join pg_namespace n on n.oid = c.relnamespace
+where n.nspname = any($1) and not t.tgisinternal and ` + fmt.Sprintf(notExtensionMember, "pg_class", "c.oid")
+
+	policyQuery = `select format('%I.%I', schemaname, tablename) as table,
+  format('%I', policyname) as name,
+  permissive,
+  (select string_agg(case when r = 'public' then r else quote_ident(r) end, ', ') from unnest(roles) r) as roles,
+  cmd,
+  coalesce(qual, '') as qual,
+  coalesce(with_check, '') as with_check
+from pg_policies
+where schemaname = any($1)`
+)
+
+type namedDef struct {
+	Table string `db:"table"`
+	Name  string `db:"name"`
+	Type  string `db:"type"`
+	Def   string `db:"def"`
+}
+
+func queryRows[T any](ctx context.Context, conn *pgx.Conn, sql string, schema []string) ([]T, error) {
+	rows, err := conn.Query(ctx, sql, schema)
+	if err != nil {
+		return nil, errors.Errorf("failed to query catalog: %w", err)
+	}
+	return pgxv5.CollectRows[T](rows)
+}
+
+// Introspects the schema objects managed by migrations.
+func loadCatalog(ctx context.Context, conn *pgx.Conn, schema []string) (catalog, error) {
+	result := catalog{}
+	schemas, err := queryRows[struct {
+		Name string `db:"name"`
+	}](ctx, conn, schemaQuery, schema)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range schemas {
+		result.add(dbObject{
+			kind:   kindSchema,
+			name:   s.Name,
+			create: "create schema if not exists " + s.Name,
+			drop:   "drop schema if exists " + s.Name,
+		})
+	}
+	enums, err := queryRows[struct {
+		Name   string   `db:"name"`
+		Labels []string `db:"labels"`
+	}](ctx, conn, enumQuery, schema)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range enums {
+		result.add(dbObject{
+			kind:   kindEnum,
+			name:   e.Name,
+			create: fmt.Sprintf("create type %s as enum (%s)", e.Name, strings.Join(e.Labels, ", ")),
+			drop:   "drop type " + e.Name,
+			labels: e.Labels,
+		})
+	}
+	functions, err := queryRows[struct {
+		Name      string `db:"name"`
+		Kind      string `db:"kind"`
+		Def       string `db:"def"`
+		Signature string `db:"signature"`
+	}](ctx, conn, functionQuery, schema)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range functions {
+		drop := "drop function " + f.Name
+		if f.Kind == "p" {
+			drop = "drop procedure " + f.Name
+		}
+		result.add(dbObject{
+			kind:      kindFunction,
+			name:      f.Name,
+			create:    strings.TrimSpace(f.Def),
+			drop:      drop,
+			replace:   true,
+			signature: f.Signature,
+		})
+	}
+	tables, err := queryRows[struct {
+		Name string `db:"name"`
+		Rls  bool   `db:"rls"`
+	}](ctx, conn, tableQuery, schema)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tables {
+		result.add(dbObject{
+			kind:   kindTable,
+			name:   t.Name,
+			create: fmt.Sprintf("create table %s ()", t.Name),
+			drop:   "drop table " + t.Name,
+		})
+		if t.Rls {
+			result.add(dbObject{
+				kind:   kindRowSecurity,
+				name:   t.Name,
+				parent: t.Name,
+				create: fmt.Sprintf("alter table %s enable row level security", t.Name),
+				drop:   fmt.Sprintf("alter table %s disable row level security", t.Name),
+			})
+		}
+	}
+	sequences, err := queryRows[struct {
+		Name        string `db:"name"`
+		Type        string `db:"type"`
+		Start       int64  `db:"start"`
+		Increment   int64  `db:"increment"`
+		Min         int64  `db:"min"`
+		Max         int64  `db:"max"`
+		Cache       int64  `db:"cache"`
+		Cycle       bool   `db:"cycle"`
+		OwnerTable  string `db:"owner_table"`
+		OwnerColumn string `db:"owner_column"`
+	}](ctx, conn, sequenceQuery, schema)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range sequences {
+		cycle := "no cycle"
+		if s.Cycle {
+			cycle = "cycle"
+		}
+		// Sequences owned by a dropped table are removed by drop table
+		result.add(dbObject{
+			kind:   kindSequence,
+			name:   s.Name,
+			parent: s.OwnerTable,
+			create: fmt.Sprintf("create sequence %s as %s increment by %d minvalue %d maxvalue %d start with %d cache %d %s", s.Name, s.Type, s.Increment, s.Min, s.Max, s.Start, s.Cache, cycle),
+			// Dropping the owning column also drops the sequence
+			drop: "drop sequence if exists " + s.Name,
+		})
+		if len(s.OwnerTable) > 0 {
+			result.add(dbObject{
+				kind:   kindSequenceOwner,
+				name:   s.Name,
+				parent: s.OwnerTable,
+				create: fmt.Sprintf("alter sequence %s owned by %s.%s", s.Name, s.OwnerTable, s.OwnerColumn),
+				drop:   fmt.Sprintf("alter sequence %s owned by none", s.Name),
+			})
+		}
+	}
+	columns, err := queryRows[struct {
+		Table     string `db:"table"`
+		Name      string `db:"name"`
+		Position  int    `db:"position"`
+		Type      string `db:"type"`
+		Default   string `db:"default"`
+		NotNull   bool   `db:"not_null"`
+		Identity  string `db:"identity"`
+		Generated string `db:"generated"`
+	}](ctx, conn, columnQuery, schema)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range columns {
+		col := column{
+			Type:      c.Type,
+			Default:   c.Default,
+			NotNull:   c.NotNull,
+			Identity:  c.Identity,
+			Generated: c.Generated,
+		}
+		result.add(dbObject{
+			kind:   kindColumn,
+			name:   c.Table + "." + c.Name,
+			parent: c.Table,
+			order:  c.Position,
+			create: fmt.Sprintf("alter table %s add column %s %s", c.Table, c.Name, col.definition()),
+			drop:   fmt.Sprintf("alter table %s drop column %s", c.Table, c.Name),
+			column: &col,
+		})
+	}
+	constraints, err := queryRows[namedDef](ctx, conn, constraintQuery, schema)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range constraints {
+		kind := kindConstraint
+		// Foreign keys depend on unique constraints of other tables
+		if c.Type == "f" {
+			kind = kindForeignKey
+		}
+		result.add(dbObject{
+			kind:   kind,
+			name:   c.Table + "." + c.Name,
+			parent: c.Table,
+			create: fmt.Sprintf("alter table %s add constraint %s %s", c.Table, c.Name, c.Def),
+			drop:   fmt.Sprintf("alter table %s drop constraint %s", c.Table, c.Name),
+		})
+	}
+	indexes, err := queryRows[struct {
+		Table string `db:"table"`
+		Name  string `db:"name"`
+		Def   string `db:"def"`
+	}](ctx, conn, indexQuery, schema)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range indexes {
+		result.add(dbObject{
+			kind:   kindIndex,
+			name:   i.Name,
+			parent: i.Table,
+			create: i.Def,
+			drop:   "drop index " + i.Name,
+		})
+	}
+	views, err := queryRows[struct {
+		Name      string `db:"name"`
+		Kind      string `db:"kind"`
+		Def       string `db:"def"`
+		Signature string `db:"signature"`
+	}](ctx, conn, viewQuery, schema)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range views {
+		def := strings.TrimSuffix(strings.TrimSpace(v.Def), ";")
+		obj := dbObject{
+			kind:      kindView,
+			name:      v.Name,
+			create:    fmt.Sprintf("create or replace view %s as\n%s", v.Name, def),
+			drop:      "drop view " + v.Name,
+			replace:   true,
+			signature: v.Signature,
+		}
+		if v.Kind == "m" {
+			obj.create = fmt.Sprintf("create materialized view %s as\n%s", v.Name, def)
+			obj.drop = "drop materialized view " + v.Name
+			obj.replace = false
+		}
+		result.add(obj)
+	}
+	triggers, err := queryRows[struct {
+		Table string `db:"table"`
+		Name  string `db:"name"`
+		Def   string `db:"def"`
+	}](ctx, conn, triggerQuery, schema)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range triggers {
+		result.add(dbObject{
+			kind:   kindTrigger,
+			name:   t.Table + "." + t.Name,
+			parent: t.Table,
+			create: t.Def,
+			drop:   fmt.Sprintf("drop trigger %s on %s", t.Name, t.Table),
+		})
+	}
+	policies, err := queryRows[struct {
+		Table      string `db:"table"`
+		Name       string `db:"name"`
+		Permissive string `db:"permissive"`
+		Roles      string `db:"roles"`
+		Cmd        string `db:"cmd"`
+		Qual       string `db:"qual"`
+		WithCheck  string `db:"with_check"`
+	}](ctx, conn, policyQuery, schema)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range policies {
+		create := fmt.Sprintf("create policy %s on %s as %s for %s to %s", p.Name, p.Table, strings.ToLower(p.Permissive), strings.ToLower(p.Cmd), p.Roles)
+		if len(p.Qual) > 0 {
+			create += fmt.Sprintf(" using (%s)", p.Qual)
+		}
+		if len(p.WithCheck) > 0 {
+			create += fmt.Sprintf(" with check (%s)", p.WithCheck)
+		}
+		result.add(dbObject{
+			kind:   kindPolicy,
+			name:   p.Table + "." + p.Name,
+			parent: p.Table,
+			create: create,
+			drop:   fmt.Sprintf("drop policy %s on %s", p.Name, p.Table),
+		})
+	}
+	return result, nil
+}
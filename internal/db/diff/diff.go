@@ -30,7 +30,7 @@ import (
 
 type DiffFunc func(context.Context, string, string, []string) (string, error)
 
-func Run(ctx context.Context, schema []string, file string, config pgconn.Config, differ DiffFunc, fsys afero.Fs, options ...func(*pgx.ConnConfig)) (err error) {
+func Run(ctx context.Context, schema []string, file, format string, config pgconn.Config, differ DiffFunc, fsys afero.Fs, options ...func(*pgx.ConnConfig)) (err error) {
 	// Sanity checks.
 	if err := utils.LoadConfigFS(fsys); err != nil {
 		return err
@@ -54,6 +54,7 @@ func Run(ctx context.Context, schema []string, file string, config pgconn.Config
 		if err != nil {
 			return err
 		}
+		schema = excludeSchemas(schema, utils.Config.Db.Diff.Exclude)
 	}
 	// 3. Run migra to diff schema
 	out, err := DiffDatabase(ctx, schema, config, os.Stderr, fsys, differ, options...)
@@ -62,8 +63,15 @@ func Run(ctx context.Context, schema []string, file string, config pgconn.Config
 	}
 	branch := keys.GetGitBranch(fsys)
 	fmt.Fprintln(os.Stderr, "Finished "+utils.Aqua("supabase db diff")+" on branch "+utils.Aqua(branch)+".\n")
-	if err := SaveDiff(out, file, fsys); err != nil {
-		return err
+	if format == FormatSql || len(file) > 0 {
+		if err := SaveDiff(out, file, fsys); err != nil {
+			return err
+		}
+	}
+	if format != FormatSql {
+		if err := printChanges(out, format); err != nil {
+			return err
+		}
 	}
 	drops := findDropStatements(out)
 	if len(drops) > 0 {
@@ -130,7 +138,15 @@ func loadSchema(ctx context.Context, config pgconn.Config, options ...func(*pgx.
 }
 
 func CreateShadowDatabase(ctx context.Context, port uint16) (string, error) {
+	if port == utils.Config.Db.ShadowPort {
+		removeCachedShadow(ctx)
+	}
+	return startShadowDatabase(ctx, port, nil, "")
+}
+
+func startShadowDatabase(ctx context.Context, port uint16, labels map[string]string, name string) (string, error) {
 	config := start.NewContainerConfig()
+	config.Labels = labels
 	hostPort := strconv.FormatUint(uint64(port), 10)
 	hostConfig := container.HostConfig{
 		PortBindings: nat.PortMap{"5432/tcp": []nat.PortBinding{{HostPort: hostPort}}},
@@ -141,7 +157,7 @@ func CreateShadowDatabase(ctx context.Context, port uint16) (string, error) {
 		config.Entrypoint = nil
 		hostConfig.Tmpfs = map[string]string{"/docker-entrypoint-initdb.d": ""}
 	}
-	return utils.DockerStart(ctx, config, hostConfig, networkingConfig, "")
+	return utils.DockerStart(ctx, config, hostConfig, networkingConfig, name)
 }
 
 func ConnectShadowDatabase(ctx context.Context, timeout time.Duration, options ...func(*pgx.ConnConfig)) (conn *pgx.Conn, err error) {
@@ -187,17 +203,23 @@ func migrateBaseDatabase(ctx context.Context, container string, fsys afero.Fs, o
 }
 
 func DiffDatabase(ctx context.Context, schema []string, config pgconn.Config, w io.Writer, fsys afero.Fs, differ func(context.Context, string, string, []string) (string, error), options ...func(*pgx.ConnConfig)) (string, error) {
-	fmt.Fprintln(w, "Creating shadow database...")
-	shadow, err := CreateShadowDatabase(ctx, utils.Config.Db.ShadowPort)
-	if err != nil {
-		return "", err
-	}
-	defer utils.DockerRemove(shadow)
-	if err := start.WaitForHealthyService(ctx, start.HealthTimeout, shadow); err != nil {
-		return "", err
-	}
-	if err := MigrateShadowDatabase(ctx, shadow, fsys, options...); err != nil {
-		return "", err
+	if utils.Config.Db.ShadowCache {
+		if err := prepareCachedShadow(ctx, w, fsys, options...); err != nil {
+			return "", err
+		}
+	} else {
+		fmt.Fprintln(w, "Creating shadow database...")
+		shadow, err := CreateShadowDatabase(ctx, utils.Config.Db.ShadowPort)
+		if err != nil {
+			return "", err
+		}
+		defer utils.DockerRemove(shadow)
+		if err := start.WaitForHealthyService(ctx, start.HealthTimeout, shadow); err != nil {
+			return "", err
+		}
+		if err := MigrateShadowDatabase(ctx, shadow, fsys, options...); err != nil {
+			return "", err
+		}
 	}
 	fmt.Fprintln(w, "Diffing schemas:", strings.Join(schema, ","))
 	source := utils.ToPostgresURL(pgconn.Config{
@@ -208,5 +230,9 @@ func DiffDatabase(ctx context.Context, schema []string, config pgconn.Config, w
 		Database: "postgres",
 	})
 	target := utils.ToPostgresURL(config)
-	return differ(ctx, source, target, schema)
+	out, err := differ(ctx, source, target, schema)
+	if err != nil {
+		return "", err
+	}
+	return filterExcluded(out, utils.Config.Db.Diff.Exclude), nil
 }
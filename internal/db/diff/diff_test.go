@@ -71,7 +71,7 @@ func TestRun(t *testing.T) {
 		conn := pgtest.NewConn()
 		defer conn.Close(t)
 		// Run test
-		err := Run(context.Background(), []string{"public"}, "file", dbConfig, DiffSchemaMigra, fsys, conn.Intercept)
+		err := Run(context.Background(), []string{"public"}, "file", FormatSql, dbConfig, DiffSchemaMigra, fsys, conn.Intercept)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -89,7 +89,7 @@ func TestRun(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), []string{"public"}, "", pgconn.Config{}, DiffSchemaMigra, fsys)
+		err := Run(context.Background(), []string{"public"}, "", FormatSql, pgconn.Config{}, DiffSchemaMigra, fsys)
 		// Check error
 		assert.ErrorIs(t, err, os.ErrNotExist)
 	})
@@ -106,7 +106,7 @@ func TestRun(t *testing.T) {
 		conn.Query(migration.ListSchemas, migration.ManagedSchemas).
 			ReplyError(pgerrcode.DuplicateTable, `relation "test" already exists`)
 		// Run test
-		err := Run(context.Background(), []string{}, "", dbConfig, DiffSchemaMigra, fsys, conn.Intercept)
+		err := Run(context.Background(), []string{}, "", FormatSql, dbConfig, DiffSchemaMigra, fsys, conn.Intercept)
 		// Check error
 		assert.ErrorContains(t, err, `ERROR: relation "test" already exists (SQLSTATE 42P07)`)
 	})
@@ -124,7 +124,7 @@ func TestRun(t *testing.T) {
 			Get("/v" + utils.Docker.ClientVersion() + "/images/" + utils.GetRegistryImageUrl(utils.Config.Db.Image) + "/json").
 			ReplyError(errors.New("network error"))
 		// Run test
-		err := Run(context.Background(), []string{"public"}, "file", dbConfig, DiffSchemaMigra, fsys)
+		err := Run(context.Background(), []string{"public"}, "file", FormatSql, dbConfig, DiffSchemaMigra, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "network error")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -341,3 +341,32 @@ func TestDropStatements(t *testing.T) {
 	drops := findDropStatements("create table t(); drop table t; alter table t drop column c")
 	assert.Equal(t, []string{"drop table t", "alter table t drop column c"}, drops)
 }
+
+func TestLoadDeclaredSchemas(t *testing.T) {
+	t.Run("loads sql files in lexical order", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		for _, name := range []string{"b_tables.sql", "a_types.sql", "nested/c_views.sql", "README.md"} {
+			path := filepath.Join(utils.SchemasDir, name)
+			require.NoError(t, afero.WriteFile(fsys, path, []byte{}, 0644))
+		}
+		// Run test
+		declared, err := loadDeclaredSchemas(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			filepath.Join(utils.SchemasDir, "a_types.sql"),
+			filepath.Join(utils.SchemasDir, "b_tables.sql"),
+			filepath.Join(utils.SchemasDir, "nested", "c_views.sql"),
+		}, declared)
+	})
+
+	t.Run("throws error on missing directory", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		_, err := loadDeclaredSchemas(fsys)
+		// Check error
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
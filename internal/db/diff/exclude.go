@@ -0,0 +1,104 @@
+package diff
+
+import (
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/supabase/cli/pkg/parser"
+)
+
+const qualifiedName = `((?:"[^"]+"|[\w$]+)(?:\.(?:"[^"]+"|[\w$]+))?)`
+
+// Patterns that capture the object targeted by a DDL statement, keyed by exclusion kind.
+var targetPatterns = []struct {
+	kind    string
+	pattern *regexp.Regexp
+}{
+	{"extension", regexp.MustCompile(`(?is)^(?:create|alter|drop|comment\s+on)\s+extension\s+(?:if\s+(?:not\s+)?exists\s+)?` + qualifiedName)},
+	{"schema", regexp.MustCompile(`(?is)^(?:create|alter|drop|comment\s+on)\s+schema\s+(?:if\s+(?:not\s+)?exists\s+)?` + qualifiedName)},
+	{"table", regexp.MustCompile(`(?is)^(?:create|alter|drop|comment\s+on)\s+(?:(?:unlogged|temp|temporary|foreign)\s+)?table\s+(?:if\s+(?:not\s+)?exists\s+)?(?:only\s+)?` + qualifiedName)},
+	{"view", regexp.MustCompile(`(?is)^(?:create(?:\s+or\s+replace)?|alter|drop|comment\s+on|refresh)\s+(?:materialized\s+)?view\s+(?:if\s+(?:not\s+)?exists\s+)?` + qualifiedName)},
+	{"function", regexp.MustCompile(`(?is)^(?:create(?:\s+or\s+replace)?|alter|drop|comment\s+on)\s+(?:function|procedure)\s+(?:if\s+exists\s+)?` + qualifiedName)},
+	{"type", regexp.MustCompile(`(?is)^(?:create|alter|drop|comment\s+on)\s+(?:type|domain)\s+(?:if\s+exists\s+)?` + qualifiedName)},
+	// Indexes, policies, triggers and grants belong to the table they are defined on
+	{"table", regexp.MustCompile(`(?is)^(?:create|alter|drop|comment\s+on)\s+(?:unique\s+)?(?:index|policy|trigger|rule|constraint\s+trigger)\s.*?\son\s+(?:only\s+)?` + qualifiedName)},
+	{"table", regexp.MustCompile(`(?is)^(?:grant|revoke)\s.*?\son\s+table\s+` + qualifiedName)},
+}
+
+type dbTarget struct {
+	kind string
+	name string
+}
+
+// Returns the object modified by a statement, qualified by schema where applicable.
+func findTarget(stat string) (dbTarget, bool) {
+	for _, p := range targetPatterns {
+		if m := p.pattern.FindStringSubmatch(stat); len(m) > 1 {
+			parts := splitIdentifier(m[1])
+			if p.kind != "extension" && p.kind != "schema" && len(parts) == 1 {
+				parts = append([]string{"public"}, parts...)
+			}
+			return dbTarget{kind: p.kind, name: strings.Join(parts, ".")}, true
+		}
+	}
+	return dbTarget{}, false
+}
+
+func splitIdentifier(name string) []string {
+	var parts []string
+	for _, p := range strings.Split(name, ".") {
+		if unquoted, ok := strings.CutPrefix(p, `"`); ok {
+			parts = append(parts, strings.TrimSuffix(unquoted, `"`))
+		} else {
+			parts = append(parts, strings.ToLower(p))
+		}
+	}
+	return parts
+}
+
+// Rules are of the form <kind>:<pattern>, where pattern is matched against the qualified
+// object name. Schema rules also exclude every object in the matching schemas.
+func isExcluded(target dbTarget, rules []string) bool {
+	schema, _, qualified := strings.Cut(target.name, ".")
+	for _, r := range rules {
+		kind, pattern, _ := strings.Cut(r, ":")
+		if kind == target.kind {
+			if ok, _ := path.Match(pattern, target.name); ok {
+				return true
+			}
+		}
+		if kind == "schema" && qualified {
+			if ok, _ := path.Match(pattern, schema); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Removes statements that modify excluded objects from the diff output.
+func filterExcluded(out string, rules []string) string {
+	if len(rules) == 0 {
+		return out
+	}
+	stats, err := parser.SplitAndTrim(strings.NewReader(out))
+	if err != nil {
+		return out
+	}
+	var result strings.Builder
+	for _, stat := range stats {
+		if target, ok := findTarget(stat); ok && isExcluded(target, rules) {
+			continue
+		}
+		result.WriteString(stat + ";\n\n")
+	}
+	return result.String()
+}
+
+func excludeSchemas(schema, rules []string) []string {
+	return slices.DeleteFunc(schema, func(s string) bool {
+		return isExcluded(dbTarget{kind: "schema", name: s}, rules)
+	})
+}
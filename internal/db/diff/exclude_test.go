@@ -0,0 +1,76 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterExcluded(t *testing.T) {
+	rules := []string{"extension:pg_stat_statements", "schema:graphql", "table:audit.*"}
+
+	t.Run("removes statements on excluded objects", func(t *testing.T) {
+		out := `create extension if not exists "pg_stat_statements" with schema "extensions";
+
+create table "audit"."logs" ("id" bigint not null);
+
+create unique index logs_pkey on audit.logs using btree (id);
+
+grant select on table "audit"."logs" to "anon";
+
+create or replace function graphql.resolve() returns void language sql as $$ select 1 $$;
+
+create table "public"."todos" ("id" bigint not null);
+
+create policy "owner" on "public"."todos" as permissive for all to authenticated using (true);
+`
+		// Run test
+		result := filterExcluded(out, rules)
+		// Check output
+		assert.Equal(t, `create table "public"."todos" ("id" bigint not null);
+
+create policy "owner" on "public"."todos" as permissive for all to authenticated using (true);
+
+`, result)
+	})
+
+	t.Run("keeps output without rules", func(t *testing.T) {
+		out := "create table audit.logs ();\n"
+		// Run test
+		result := filterExcluded(out, nil)
+		// Check output
+		assert.Equal(t, out, result)
+	})
+}
+
+func TestFindTarget(t *testing.T) {
+	t.Run("qualifies unqualified names", func(t *testing.T) {
+		// Run test
+		target, ok := findTarget("alter table Todos add column done boolean")
+		// Check output
+		assert.True(t, ok)
+		assert.Equal(t, dbTarget{kind: "table", name: "public.todos"}, target)
+	})
+
+	t.Run("preserves quoted names", func(t *testing.T) {
+		// Run test
+		target, ok := findTarget(`drop materialized view if exists "Stats"."Daily"`)
+		// Check output
+		assert.True(t, ok)
+		assert.Equal(t, dbTarget{kind: "view", name: "Stats.Daily"}, target)
+	})
+
+	t.Run("ignores schema grants", func(t *testing.T) {
+		// Run test
+		_, ok := findTarget("grant usage on schema audit to anon")
+		// Check output
+		assert.False(t, ok)
+	})
+}
+
+func TestExcludeSchemas(t *testing.T) {
+	// Run test
+	result := excludeSchemas([]string{"public", "graphql", "audit"}, []string{"schema:graph*"})
+	// Check output
+	assert.Equal(t, []string{"public", "audit"}, result)
+}
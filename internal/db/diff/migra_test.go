@@ -0,0 +1,46 @@
+package diff
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/config"
+)
+
+func TestDiffSchemaMigra(t *testing.T) {
+	t.Run("diffs grants on database objects", func(t *testing.T) {
+		const containerId = "test-migra"
+		imageUrl := utils.GetRegistryImageUrl(config.MigraImage)
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/images/" + imageUrl + "/json").
+			Reply(http.StatusOK).
+			JSON(types.ImageInspect{})
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v" + utils.Docker.ClientVersion() + "/containers/create").
+			BodyString("migra --with-privileges").
+			Reply(http.StatusOK).
+			JSON(container.CreateResponse{ID: containerId})
+		gock.New(utils.Docker.DaemonHost()).
+			Post("/v" + utils.Docker.ClientVersion() + "/containers/" + containerId + "/start").
+			Reply(http.StatusAccepted)
+		grant := "grant select on table public.todos to anon;\n"
+		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, grant))
+		// Run test
+		diff, err := DiffSchemaMigra(context.Background(), "postgresql://source", "postgresql://target", []string{"public"})
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, grant, diff)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
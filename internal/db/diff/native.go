@@ -0,0 +1,200 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Diffs schema objects by introspecting both databases directly, without running
+// any diff tool in a container.
+func DiffNative(ctx context.Context, source, target string, schema []string) (string, error) {
+	connSrc, err := utils.ConnectByUrl(ctx, source)
+	if err != nil {
+		return "", err
+	}
+	defer connSrc.Close(context.Background())
+	connDst, err := utils.ConnectByUrl(ctx, target)
+	if err != nil {
+		return "", err
+	}
+	defer connDst.Close(context.Background())
+	before, err := loadCatalog(ctx, connSrc, schema)
+	if err != nil {
+		return "", errors.Errorf("failed to load source schema: %w", err)
+	}
+	after, err := loadCatalog(ctx, connDst, schema)
+	if err != nil {
+		return "", errors.Errorf("failed to load target schema: %w", err)
+	}
+	var out strings.Builder
+	for _, stat := range diffCatalogs(before, after) {
+		out.WriteString(stat + ";\n\n")
+	}
+	return out.String(), nil
+}
+
+type change struct {
+	dbObject
+	stats []string
+}
+
+// Generates DDL statements that migrate source catalog to target.
+func diffCatalogs(source, target catalog) []string {
+	var drops, creates []change
+	droppedTables := map[string]bool{}
+	for key, obj := range source {
+		if _, ok := target[key]; !ok {
+			drops = append(drops, change{dbObject: obj, stats: []string{obj.drop}})
+			if obj.kind == kindTable {
+				droppedTables[obj.name] = true
+			}
+		}
+	}
+	createdTables := map[string]bool{}
+	for key, obj := range target {
+		if _, ok := source[key]; !ok && obj.kind == kindTable {
+			createdTables[obj.name] = true
+		}
+	}
+	for key, obj := range target {
+		prev, ok := source[key]
+		if !ok && obj.kind == kindColumn && createdTables[obj.parent] {
+			creates = append(creates, addColumn(obj)...)
+			continue
+		} else if !ok {
+			creates = append(creates, change{dbObject: obj, stats: []string{obj.create}})
+			continue
+		}
+		switch {
+		case obj.kind == kindColumn:
+			if stats := alterColumn(obj.parent, strings.TrimPrefix(obj.name, obj.parent+"."), *prev.column, *obj.column); len(stats) > 0 {
+				creates = append(creates, change{dbObject: obj, stats: stats})
+			}
+		case prev.create == obj.create:
+		case obj.kind == kindSequence:
+			// Sequences are altered in place to keep their current value
+			creates = append(creates, change{dbObject: obj, stats: []string{"alter" + strings.TrimPrefix(obj.create, "create")}})
+		case obj.kind == kindEnum:
+			if stats, ok := alterEnum(obj.name, prev.labels, obj.labels); ok {
+				creates = append(creates, change{dbObject: obj, stats: stats})
+				continue
+			}
+			drops = append(drops, change{dbObject: prev, stats: []string{prev.drop}})
+			creates = append(creates, change{dbObject: obj, stats: []string{obj.create}})
+		case obj.replace && prev.signature == obj.signature:
+			creates = append(creates, change{dbObject: obj, stats: []string{obj.create}})
+		default:
+			drops = append(drops, change{dbObject: prev, stats: []string{prev.drop}})
+			creates = append(creates, change{dbObject: obj, stats: []string{obj.create}})
+		}
+	}
+	// Children of dropped tables are removed by drop table
+	drops = slices.DeleteFunc(drops, func(c change) bool {
+		return droppedTables[c.parent]
+	})
+	sort.Slice(drops, func(i, j int) bool {
+		return drops[i].less(drops[j].dbObject, true)
+	})
+	sort.Slice(creates, func(i, j int) bool {
+		return creates[i].less(creates[j].dbObject, false)
+	})
+	var result []string
+	for _, c := range append(drops, creates...) {
+		result = append(result, c.stats...)
+	}
+	return result
+}
+
+// Columns of new tables are added without defaults, which are set after functions are created.
+func addColumn(obj dbObject) []change {
+	if len(obj.column.Default) == 0 || len(obj.column.Generated) > 0 {
+		return []change{{dbObject: obj, stats: []string{obj.create}}}
+	}
+	col := *obj.column
+	col.Default = ""
+	name := strings.TrimPrefix(obj.name, obj.parent+".")
+	def := obj
+	def.kind = kindColumnDefault
+	return []change{
+		{dbObject: obj, stats: []string{fmt.Sprintf("alter table %s add column %s %s", obj.parent, name, col.definition())}},
+		{dbObject: def, stats: []string{fmt.Sprintf("alter table %s alter column %s set default %s", obj.parent, name, obj.column.Default)}},
+	}
+}
+
+func (o dbObject) less(other dbObject, reverse bool) bool {
+	if o.kind != other.kind {
+		return (o.kind < other.kind) != reverse
+	}
+	if o.parent != other.parent {
+		return o.parent < other.parent
+	}
+	if o.order != other.order {
+		return o.order < other.order
+	}
+	return o.name < other.name
+}
+
+func alterColumn(table, name string, prev, next column) []string {
+	prefix := fmt.Sprintf("alter table %s alter column %s ", table, name)
+	// Identity and generated columns cannot be altered in place
+	if prev.Identity != next.Identity || prev.Generated != next.Generated || (len(next.Generated) > 0 && prev.Default != next.Default) {
+		return []string{
+			fmt.Sprintf("alter table %s drop column %s", table, name),
+			fmt.Sprintf("alter table %s add column %s %s", table, name, next.definition()),
+		}
+	}
+	var stats []string
+	if prev.Type != next.Type {
+		stats = append(stats, prefix+fmt.Sprintf("set data type %s using %s::%s", next.Type, name, next.Type))
+	}
+	if prev.Default != next.Default {
+		if len(next.Default) == 0 {
+			stats = append(stats, prefix+"drop default")
+		} else {
+			stats = append(stats, prefix+"set default "+next.Default)
+		}
+	}
+	if prev.NotNull != next.NotNull {
+		if next.NotNull {
+			stats = append(stats, prefix+"set not null")
+		} else {
+			stats = append(stats, prefix+"drop not null")
+		}
+	}
+	return stats
+}
+
+// Enum values can only be added, so any other change requires recreating the type.
+func alterEnum(name string, prev, next []string) ([]string, bool) {
+	existing := map[string]bool{}
+	for _, l := range prev {
+		existing[l] = true
+	}
+	var kept []string
+	for _, l := range next {
+		if existing[l] {
+			kept = append(kept, l)
+		}
+	}
+	if !slices.Equal(kept, prev) || len(kept) == 0 {
+		return nil, false
+	}
+	first := slices.Index(next, kept[0])
+	var stats []string
+	// Leading values are inserted before the next value, which must already exist
+	for i := first - 1; i >= 0; i-- {
+		stats = append(stats, fmt.Sprintf("alter type %s add value %s before %s", name, next[i], next[i+1]))
+	}
+	for i := first + 1; i < len(next); i++ {
+		if !existing[next[i]] {
+			stats = append(stats, fmt.Sprintf("alter type %s add value %s after %s", name, next[i], next[i-1]))
+		}
+	}
+	return stats, true
+}
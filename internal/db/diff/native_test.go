@@ -0,0 +1,267 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTable(name string, columns ...dbObject) catalog {
+	result := catalog{}
+	result.add(dbObject{
+		kind:   kindTable,
+		name:   name,
+		create: "create table " + name + " ()",
+		drop:   "drop table " + name,
+	})
+	for _, c := range columns {
+		result.add(c)
+	}
+	return result
+}
+
+func newColumn(table, name string, position int, col column) dbObject {
+	return dbObject{
+		kind:   kindColumn,
+		name:   table + "." + name,
+		parent: table,
+		order:  position,
+		create: "alter table " + table + " add column " + name + " " + col.definition(),
+		drop:   "alter table " + table + " drop column " + name,
+		column: &col,
+	}
+}
+
+func TestDiffCatalogs(t *testing.T) {
+	t.Run("creates table with columns in order", func(t *testing.T) {
+		target := newTable("public.todos",
+			newColumn("public.todos", "title", 2, column{Type: "text", NotNull: true}),
+			newColumn("public.todos", "id", 1, column{Type: "bigint", Identity: "a"}),
+		)
+		// Run test
+		stats := diffCatalogs(catalog{}, target)
+		// Check output
+		assert.Equal(t, []string{
+			"create table public.todos ()",
+			"alter table public.todos add column id bigint generated always as identity",
+			"alter table public.todos add column title text not null",
+		}, stats)
+	})
+
+	t.Run("creates functions after tables", func(t *testing.T) {
+		target := newTable("public.todos",
+			newColumn("public.todos", "id", 1, column{Type: "bigint", Default: "public.next_id()", NotNull: true}),
+		)
+		target.add(dbObject{kind: kindFunction, name: "public.next_id()", create: "create function public.next_id()", replace: true})
+		target.add(dbObject{kind: kindFunction, name: "public.title(public.todos)", create: "create function public.title(public.todos)", replace: true})
+		// Run test
+		stats := diffCatalogs(catalog{}, target)
+		// Check output
+		assert.Equal(t, []string{
+			"create table public.todos ()",
+			"alter table public.todos add column id bigint not null",
+			"create function public.next_id()",
+			"create function public.title(public.todos)",
+			"alter table public.todos alter column id set default public.next_id()",
+		}, stats)
+	})
+
+	t.Run("alters changed columns", func(t *testing.T) {
+		source := newTable("public.todos",
+			newColumn("public.todos", "done", 1, column{Type: "boolean"}),
+			newColumn("public.todos", "note", 2, column{Type: "text"}),
+		)
+		target := newTable("public.todos",
+			newColumn("public.todos", "done", 1, column{Type: "boolean", Default: "false", NotNull: true}),
+			newColumn("public.todos", "body", 3, column{Type: "text"}),
+		)
+		// Run test
+		stats := diffCatalogs(source, target)
+		// Check output
+		assert.Equal(t, []string{
+			"alter table public.todos drop column note",
+			"alter table public.todos alter column done set default false",
+			"alter table public.todos alter column done set not null",
+			"alter table public.todos add column body text",
+		}, stats)
+	})
+
+	t.Run("drops table without its children", func(t *testing.T) {
+		source := newTable("public.todos", newColumn("public.todos", "id", 1, column{Type: "bigint"}))
+		source.add(dbObject{
+			kind:   kindPolicy,
+			name:   "public.todos.owner",
+			parent: "public.todos",
+			create: "create policy owner on public.todos",
+			drop:   "drop policy owner on public.todos",
+		})
+		// Run test
+		stats := diffCatalogs(source, catalog{})
+		// Check output
+		assert.Equal(t, []string{"drop table public.todos"}, stats)
+	})
+
+	t.Run("replaces changed functions in place", func(t *testing.T) {
+		source := catalog{}
+		source.add(dbObject{kind: kindFunction, name: "public.f()", create: "create or replace function f() as 1", replace: true})
+		target := catalog{}
+		target.add(dbObject{kind: kindFunction, name: "public.f()", create: "create or replace function f() as 2", replace: true})
+		// Run test
+		stats := diffCatalogs(source, target)
+		// Check output
+		assert.Equal(t, []string{"create or replace function f() as 2"}, stats)
+	})
+
+	t.Run("recreates functions with changed result", func(t *testing.T) {
+		source := catalog{}
+		source.add(dbObject{kind: kindFunction, name: "public.f()", create: "create or replace function f() returns int", drop: "drop function public.f()", replace: true, signature: "integer"})
+		target := catalog{}
+		target.add(dbObject{kind: kindFunction, name: "public.f()", create: "create or replace function f() returns text", drop: "drop function public.f()", replace: true, signature: "text"})
+		// Run test
+		stats := diffCatalogs(source, target)
+		// Check output
+		assert.Equal(t, []string{"drop function public.f()", "create or replace function f() returns text"}, stats)
+	})
+
+	t.Run("replaces views with same columns", func(t *testing.T) {
+		source := catalog{}
+		source.add(dbObject{kind: kindView, name: "public.v", create: "create or replace view public.v as\nselect 1 as a", drop: "drop view public.v", replace: true, signature: "a integer"})
+		target := catalog{}
+		target.add(dbObject{kind: kindView, name: "public.v", create: "create or replace view public.v as\nselect 2 as a", drop: "drop view public.v", replace: true, signature: "a integer"})
+		// Run test
+		stats := diffCatalogs(source, target)
+		// Check output
+		assert.Equal(t, []string{"create or replace view public.v as\nselect 2 as a"}, stats)
+	})
+
+	t.Run("recreates views with changed columns", func(t *testing.T) {
+		source := catalog{}
+		source.add(dbObject{kind: kindView, name: "public.v", create: "create or replace view public.v as\nselect 1 as a, 2 as b", drop: "drop view public.v", replace: true, signature: "a integer, b integer"})
+		target := catalog{}
+		target.add(dbObject{kind: kindView, name: "public.v", create: "create or replace view public.v as\nselect 1 as a", drop: "drop view public.v", replace: true, signature: "a integer"})
+		// Run test
+		stats := diffCatalogs(source, target)
+		// Check output
+		assert.Equal(t, []string{"drop view public.v", "create or replace view public.v as\nselect 1 as a"}, stats)
+	})
+
+	t.Run("creates sequence of serial column", func(t *testing.T) {
+		target := newTable("public.todos",
+			newColumn("public.todos", "id", 1, column{Type: "integer", Default: "nextval('public.todos_id_seq'::regclass)", NotNull: true}),
+		)
+		target.add(dbObject{
+			kind:   kindSequence,
+			name:   "public.todos_id_seq",
+			parent: "public.todos",
+			create: "create sequence public.todos_id_seq as integer",
+			drop:   "drop sequence if exists public.todos_id_seq",
+		})
+		target.add(dbObject{
+			kind:   kindSequenceOwner,
+			name:   "public.todos_id_seq",
+			parent: "public.todos",
+			create: "alter sequence public.todos_id_seq owned by public.todos.id",
+			drop:   "alter sequence public.todos_id_seq owned by none",
+		})
+		// Run test
+		stats := diffCatalogs(catalog{}, target)
+		// Check output
+		assert.Equal(t, []string{
+			"create table public.todos ()",
+			"create sequence public.todos_id_seq as integer",
+			"alter table public.todos add column id integer not null",
+			"alter table public.todos alter column id set default nextval('public.todos_id_seq'::regclass)",
+			"alter sequence public.todos_id_seq owned by public.todos.id",
+		}, stats)
+	})
+
+	t.Run("alters changed sequence in place", func(t *testing.T) {
+		source := catalog{}
+		source.add(dbObject{kind: kindSequence, name: "public.s", create: "create sequence public.s as bigint increment by 1", drop: "drop sequence if exists public.s"})
+		target := catalog{}
+		target.add(dbObject{kind: kindSequence, name: "public.s", create: "create sequence public.s as bigint increment by 10", drop: "drop sequence if exists public.s"})
+		// Run test
+		stats := diffCatalogs(source, target)
+		// Check output
+		assert.Equal(t, []string{"alter sequence public.s as bigint increment by 10"}, stats)
+	})
+
+	t.Run("drops sequence with its table", func(t *testing.T) {
+		source := newTable("public.todos", newColumn("public.todos", "id", 1, column{Type: "integer", Default: "nextval('public.todos_id_seq'::regclass)"}))
+		source.add(dbObject{kind: kindSequence, name: "public.todos_id_seq", parent: "public.todos", drop: "drop sequence if exists public.todos_id_seq"})
+		// Run test
+		stats := diffCatalogs(source, catalog{})
+		// Check output
+		assert.Equal(t, []string{"drop table public.todos"}, stats)
+	})
+
+	t.Run("recreates changed constraints", func(t *testing.T) {
+		source := catalog{}
+		source.add(dbObject{kind: kindConstraint, name: "public.t.c", parent: "public.t", create: "add c check (a > 0)", drop: "drop c"})
+		target := catalog{}
+		target.add(dbObject{kind: kindConstraint, name: "public.t.c", parent: "public.t", create: "add c check (a > 1)", drop: "drop c"})
+		target.add(dbObject{kind: kindForeignKey, name: "public.t.fk", parent: "public.t", create: "add fk", drop: "drop fk"})
+		// Run test
+		stats := diffCatalogs(source, target)
+		// Check output
+		assert.Equal(t, []string{"drop c", "add c check (a > 1)", "add fk"}, stats)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		source := newTable("public.todos", newColumn("public.todos", "id", 1, column{Type: "bigint"}))
+		// Run test
+		stats := diffCatalogs(source, source)
+		// Check output
+		assert.Empty(t, stats)
+	})
+}
+
+func TestAlterEnum(t *testing.T) {
+	t.Run("adds values around existing ones", func(t *testing.T) {
+		// Run test
+		stats, ok := alterEnum("public.mood", []string{"'ok'"}, []string{"'sad'", "'meh'", "'ok'", "'happy'"})
+		// Check output
+		assert.True(t, ok)
+		assert.Equal(t, []string{
+			"alter type public.mood add value 'meh' before 'ok'",
+			"alter type public.mood add value 'sad' before 'meh'",
+			"alter type public.mood add value 'happy' after 'ok'",
+		}, stats)
+	})
+
+	t.Run("recreates on removed value", func(t *testing.T) {
+		// Run test
+		_, ok := alterEnum("public.mood", []string{"'ok'", "'sad'"}, []string{"'ok'"})
+		// Check output
+		assert.False(t, ok)
+	})
+
+	t.Run("recreates on reordered values", func(t *testing.T) {
+		// Run test
+		_, ok := alterEnum("public.mood", []string{"'ok'", "'sad'"}, []string{"'sad'", "'ok'"})
+		// Check output
+		assert.False(t, ok)
+	})
+}
+
+func TestAlterColumn(t *testing.T) {
+	t.Run("changes type and drops default", func(t *testing.T) {
+		// Run test
+		stats := alterColumn("public.t", "a", column{Type: "integer", Default: "0"}, column{Type: "bigint"})
+		// Check output
+		assert.Equal(t, []string{
+			"alter table public.t alter column a set data type bigint using a::bigint",
+			"alter table public.t alter column a drop default",
+		}, stats)
+	})
+
+	t.Run("recreates generated column", func(t *testing.T) {
+		// Run test
+		stats := alterColumn("public.t", "b", column{Type: "integer", Default: "(a + 1)", Generated: "s"}, column{Type: "integer", Default: "(a + 2)", Generated: "s"})
+		// Check output
+		assert.Equal(t, []string{
+			"alter table public.t drop column b",
+			"alter table public.t add column b integer generated always as ((a + 2)) stored",
+		}, stats)
+	})
+}
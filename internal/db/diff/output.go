@@ -0,0 +1,96 @@
+package diff
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/parser"
+)
+
+const (
+	FormatSql     = "sql"
+	FormatJson    = "json"
+	FormatSummary = "summary"
+)
+
+type Change struct {
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	Operation string `json:"operation"`
+	Statement string `json:"statement"`
+}
+
+var (
+	changePattern = regexp.MustCompile(`(?is)^(create(?:\s+or\s+replace)?|alter|drop|comment\s+on)\s+(?:(?:unique|materialized|unlogged|temp|temporary|foreign|constraint|event)\s+)?(extension|schema|table|view|function|procedure|trigger|policy|index|type|domain|sequence|publication)\s+(?:concurrently\s+)?(?:if\s+(?:not\s+)?exists\s+)?(?:only\s+)?` + qualifiedName)
+	// Policies and triggers are named within the table they are defined on
+	onTablePattern = regexp.MustCompile(`(?is)\son\s+(?:only\s+)?` + qualifiedName)
+)
+
+// Describes each statement of a schema diff as a change to a database object.
+func parseChanges(out string) ([]Change, error) {
+	stats, err := parser.SplitAndTrim(strings.NewReader(out))
+	if err != nil {
+		return nil, err
+	}
+	changes := []Change{}
+	for _, stat := range stats {
+		changes = append(changes, describeChange(stat))
+	}
+	return changes, nil
+}
+
+func describeChange(stat string) Change {
+	m := changePattern.FindStringSubmatch(stat)
+	if len(m) < 4 {
+		keyword, _, _ := strings.Cut(strings.TrimSpace(stat), " ")
+		return Change{Type: "statement", Operation: strings.ToLower(keyword), Statement: stat}
+	}
+	result := Change{
+		Type:      strings.ToLower(m[2]),
+		Name:      strings.Join(splitIdentifier(m[3]), "."),
+		Statement: stat,
+	}
+	switch op := strings.ToLower(strings.Fields(m[1])[0]); op {
+	case "drop":
+		result.Operation = "drop"
+	case "create":
+		result.Operation = "create"
+	default:
+		result.Operation = "alter"
+	}
+	if result.Type == "policy" || result.Type == "trigger" {
+		if on := onTablePattern.FindStringSubmatch(stat[len(m[0]):]); len(on) > 1 {
+			result.Name = strings.Join(splitIdentifier(on[1]), ".") + "." + result.Name
+		}
+	}
+	return result
+}
+
+func printChanges(out, format string) error {
+	changes, err := parseChanges(out)
+	if err != nil {
+		return err
+	}
+	if format == FormatJson {
+		return utils.EncodeOutput(utils.OutputJson, os.Stdout, changes)
+	}
+	labels := map[string]string{"create": "added", "drop": "dropped", "alter": "altered"}
+	table := "|CHANGE|TYPE|NAME|\n|-|-|-|\n"
+	seen := map[string]bool{}
+	for _, c := range changes {
+		if c.Type == "statement" {
+			continue
+		}
+		// Multiple statements on the same object are summarised once
+		row := fmt.Sprintf("|`%s`|`%s`|`%s`|\n", labels[c.Operation], c.Type, c.Name)
+		if !seen[row] {
+			seen[row] = true
+			table += row
+		}
+	}
+	return list.RenderTable(table)
+}
@@ -0,0 +1,44 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseChanges(t *testing.T) {
+	t.Run("describes changed objects", func(t *testing.T) {
+		out := `create table "public"."todos" ("id" bigint not null);
+
+alter table "public"."todos" add column "done" boolean;
+
+create policy "owner" on "public"."todos" as permissive for all to authenticated using (true);
+
+drop function if exists public.stale();
+
+create or replace view stats as select 1;
+
+grant select on table "public"."todos" to "anon";
+`
+		// Run test
+		changes, err := parseChanges(out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []Change{
+			{Type: "table", Name: "public.todos", Operation: "create", Statement: `create table "public"."todos" ("id" bigint not null)`},
+			{Type: "table", Name: "public.todos", Operation: "alter", Statement: `alter table "public"."todos" add column "done" boolean`},
+			{Type: "policy", Name: "public.todos.owner", Operation: "create", Statement: `create policy "owner" on "public"."todos" as permissive for all to authenticated using (true)`},
+			{Type: "function", Name: "public.stale", Operation: "drop", Statement: "drop function if exists public.stale()"},
+			{Type: "view", Name: "stats", Operation: "create", Statement: "create or replace view stats as select 1"},
+			{Type: "statement", Operation: "grant", Statement: `grant select on table "public"."todos" to "anon"`},
+		}, changes)
+	})
+
+	t.Run("returns empty list on no changes", func(t *testing.T) {
+		// Run test
+		changes, err := parseChanges("")
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+}
@@ -0,0 +1,82 @@
+package diff
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/start"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+const shadowHashLabel = "com.supabase.cli.shadow.hash"
+
+// Hashes everything applied to the shadow database, so a cached one is only reused when unchanged.
+func hashShadowInputs(fsys afero.Fs) (string, error) {
+	paths, err := migration.ListLocalMigrations(utils.MigrationsDir, afero.NewIOFS(fsys))
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	fmt.Fprintln(hash, utils.Config.Db.Image, utils.Config.Db.Extensions)
+	for _, path := range append(paths, utils.CustomRolesPath) {
+		contents, err := afero.ReadFile(fsys, path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return "", errors.Errorf("failed to read file: %w", err)
+		}
+		fmt.Fprintln(hash, path, len(contents))
+		hash.Write(contents)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Reuses the cached shadow database if its migrations are unchanged, otherwise replaces it.
+func prepareCachedShadow(ctx context.Context, w io.Writer, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	hash, err := hashShadowInputs(fsys)
+	if err != nil {
+		return err
+	}
+	name := utils.GetId("shadow")
+	if resp, err := utils.Docker.ContainerInspect(ctx, name); err == nil {
+		if resp.State != nil && resp.State.Running && resp.Config != nil && resp.Config.Labels[shadowHashLabel] == hash {
+			fmt.Fprintln(w, "Reusing cached shadow database...")
+			return nil
+		}
+		utils.DockerRemove(resp.ID)
+	}
+	fmt.Fprintln(w, "Creating shadow database...")
+	shadow, err := startShadowDatabase(ctx, utils.Config.Db.ShadowPort, map[string]string{shadowHashLabel: hash}, name)
+	if err != nil {
+		return err
+	}
+	if err := start.WaitForHealthyService(ctx, start.HealthTimeout, shadow); err != nil {
+		utils.DockerRemove(shadow)
+		return err
+	}
+	if err := MigrateShadowDatabase(ctx, shadow, fsys, options...); err != nil {
+		utils.DockerRemove(shadow)
+		return err
+	}
+	return nil
+}
+
+// Removes the cached shadow database so that its port can be reused.
+func removeCachedShadow(ctx context.Context) {
+	if !utils.Config.Db.ShadowCache {
+		return
+	}
+	_ = utils.Docker.ContainerRemove(ctx, utils.GetId("shadow"), container.RemoveOptions{
+		RemoveVolumes: true,
+		Force:         true,
+	})
+}
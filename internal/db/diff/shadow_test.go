@@ -0,0 +1,110 @@
+package diff
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestHashShadowInputs(t *testing.T) {
+	t.Run("changes with migration contents", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_test.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("create table a()"), 0644))
+		before, err := hashShadowInputs(fsys)
+		require.NoError(t, err)
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("create table b()"), 0644))
+		// Run test
+		after, err := hashShadowInputs(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.NotEqual(t, before, after)
+	})
+
+	t.Run("ignores down migrations", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_test.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("create table a()"), 0644))
+		before, err := hashShadowInputs(fsys)
+		require.NoError(t, err)
+		down := filepath.Join(utils.MigrationsDir, "0_test_down.sql")
+		require.NoError(t, afero.WriteFile(fsys, down, []byte("drop table a"), 0644))
+		// Run test
+		after, err := hashShadowInputs(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, before, after)
+	})
+}
+
+func TestPrepareCachedShadow(t *testing.T) {
+	utils.Config.ProjectId = "test"
+
+	t.Run("reuses shadow with matching hash", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		hash, err := hashShadowInputs(fsys)
+		require.NoError(t, err)
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.GetId("shadow") + "/json").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{
+					ID:    "test-shadow-db",
+					State: &types.ContainerState{Running: true},
+				},
+				Config: &container.Config{Labels: map[string]string{shadowHashLabel: hash}},
+			})
+		// Run test
+		err = prepareCachedShadow(context.Background(), io.Discard, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("replaces shadow with stale hash", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.GetId("shadow") + "/json").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{
+				ContainerJSONBase: &types.ContainerJSONBase{
+					ID:    "test-shadow-db",
+					State: &types.ContainerState{Running: true},
+				},
+				Config: &container.Config{Labels: map[string]string{shadowHashLabel: "stale"}},
+			})
+		gock.New(utils.Docker.DaemonHost()).
+			Delete("/v" + utils.Docker.ClientVersion() + "/containers/test-shadow-db").
+			Reply(http.StatusOK)
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/images/" + utils.GetRegistryImageUrl(utils.Config.Db.Image) + "/json").
+			ReplyError(errors.New("network error"))
+		// Run test
+		err := prepareCachedShadow(context.Background(), io.Discard, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
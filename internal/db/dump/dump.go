@@ -26,7 +26,14 @@ var (
 	dumpRoleScript string
 )
 
-func Run(ctx context.Context, path string, config pgconn.Config, schema, excludeTable []string, dataOnly, roleOnly, keepComments, useCopy, dryRun bool, fsys afero.Fs) error {
+func Run(ctx context.Context, path string, config pgconn.Config, schema, excludeTable []string, maskPath string, dataOnly, roleOnly, keepComments, useCopy, dryRun bool, fsys afero.Fs) error {
+	var rules maskRules
+	if len(maskPath) > 0 {
+		var err error
+		if rules, err = loadMaskRules(maskPath, fsys); err != nil {
+			return err
+		}
+	}
 	// Initialize output stream
 	var outStream afero.File
 	if len(path) > 0 {
@@ -49,6 +56,10 @@ func Run(ctx context.Context, path string, config pgconn.Config, schema, exclude
 	}
 	if dataOnly {
 		fmt.Fprintf(os.Stderr, "Dumping data from %s database...\n", db)
+		if len(rules) > 0 {
+			fmt.Fprintln(os.Stderr, "Masking columns of tables:", strings.Join(rules.tables(), ", "))
+			return dumpMaskedData(ctx, config, schema, excludeTable, rules, dryRun, outStream)
+		}
 		return dumpData(ctx, config, schema, excludeTable, useCopy, dryRun, outStream)
 	} else if roleOnly {
 		fmt.Fprintf(os.Stderr, "Dumping roles from %s database...\n", db)
@@ -126,6 +137,15 @@ func dumpData(ctx context.Context, config pgconn.Config, schema, excludeTable []
 	return dump(ctx, config, dumpDataScript, env, dryRun, stdout)
 }
 
+// Masking rewrites rows of copy statements, which hold one record per line unlike inserts.
+func dumpMaskedData(ctx context.Context, config pgconn.Config, schema, excludeTable []string, rules maskRules, dryRun bool, stdout io.Writer) error {
+	w := newMaskWriter(stdout, rules)
+	if err := dumpData(ctx, config, schema, excludeTable, true, dryRun, w); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
 func quoteUpperCase(table string) string {
 	escaped := strings.ReplaceAll(table, ".", `"."`)
 	return fmt.Sprintf(`"%s"`, escaped)
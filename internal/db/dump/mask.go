@@ -0,0 +1,152 @@
+package dump
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	MaskNull   = "null"
+	MaskRedact = "redact"
+	MaskHash   = "hash"
+	MaskEmail  = "email"
+	MaskName   = "name"
+)
+
+var maskStrategies = []string{MaskNull, MaskRedact, MaskHash, MaskEmail, MaskName}
+
+// Masking rules keyed by schema qualified table name, then column name.
+type maskRules map[string]map[string]string
+
+func loadMaskRules(path string, fsys afero.Fs) (maskRules, error) {
+	data, err := afero.ReadFile(fsys, path)
+	if err != nil {
+		return nil, errors.Errorf("failed to read mask config: %w", err)
+	}
+	var config struct {
+		Tables maskRules `yaml:"tables"`
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, errors.Errorf("failed to parse mask config: %w", err)
+	}
+	rules := maskRules{}
+	for table, columns := range config.Tables {
+		if !strings.Contains(table, ".") {
+			table = "public." + table
+		}
+		for column, strategy := range columns {
+			if !slices.Contains(maskStrategies, strategy) {
+				return nil, errors.Errorf("Invalid mask strategy for %s.%s: %s. Must be one of: %v", table, column, strategy, maskStrategies)
+			}
+		}
+		rules[table] = columns
+	}
+	return rules, nil
+}
+
+// pg_dump quotes all identifiers in data dumps.
+var copyPattern = regexp.MustCompile(`^COPY "(.+?)"\."(.+?)" \((.*)\) FROM stdin;$`)
+
+// Rewrites rows of COPY blocks in a pg_dump stream, masking the configured columns.
+type maskWriter struct {
+	w       io.Writer
+	rules   maskRules
+	buf     []byte
+	columns map[int]string
+}
+
+func newMaskWriter(w io.Writer, rules maskRules) *maskWriter {
+	return &maskWriter{w: w, rules: rules}
+}
+
+func (m *maskWriter) Write(p []byte) (int, error) {
+	m.buf = append(m.buf, p...)
+	for {
+		i := bytes.IndexByte(m.buf, '\n')
+		if i < 0 {
+			break
+		}
+		line := string(m.buf[:i])
+		m.buf = m.buf[i+1:]
+		if _, err := io.WriteString(m.w, m.maskLine(line)+"\n"); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Writes any trailing output without a newline.
+func (m *maskWriter) Flush() error {
+	if len(m.buf) == 0 {
+		return nil
+	}
+	_, err := io.WriteString(m.w, m.maskLine(string(m.buf)))
+	m.buf = nil
+	return err
+}
+
+func (m *maskWriter) maskLine(line string) string {
+	if m.columns != nil {
+		if line == `\.` {
+			m.columns = nil
+			return line
+		}
+		fields := strings.Split(line, "\t")
+		for i, strategy := range m.columns {
+			if i < len(fields) {
+				fields[i] = maskValue(fields[i], strategy)
+			}
+		}
+		return strings.Join(fields, "\t")
+	}
+	if matches := copyPattern.FindStringSubmatch(line); len(matches) > 3 {
+		if columns, ok := m.rules[matches[1]+"."+matches[2]]; ok {
+			m.columns = map[int]string{}
+			for i, name := range strings.Split(matches[3], ", ") {
+				if strategy, ok := columns[strings.Trim(name, `"`)]; ok {
+					m.columns[i] = strategy
+				}
+			}
+		}
+	}
+	return line
+}
+
+// Masked values are deterministic so that joins on masked columns still match.
+func maskValue(value, strategy string) string {
+	if value == `\N` {
+		return value
+	}
+	digest := sha256.Sum256([]byte(value))
+	hash := hex.EncodeToString(digest[:])
+	switch strategy {
+	case MaskNull:
+		return `\N`
+	case MaskRedact:
+		return "REDACTED"
+	case MaskEmail:
+		return "user_" + hash[:12] + "@example.com"
+	case MaskName:
+		return "User " + hash[:8]
+	}
+	return hash
+}
+
+func (r maskRules) tables() []string {
+	var result []string
+	for table := range r {
+		result = append(result, table)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,83 @@
+package dump
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaskWriter(t *testing.T) {
+	rules := maskRules{"public.users": {"email": MaskEmail, "phone": MaskNull, "bio": MaskRedact}}
+
+	t.Run("masks configured columns of copy rows", func(t *testing.T) {
+		var out bytes.Buffer
+		w := newMaskWriter(&out, rules)
+		// Run test
+		_, err := w.Write([]byte("COPY \"public\".\"users\" (\"id\", \"email\", \"phone\", \"bio\") FROM stdin;\n1\ta@b.com\t555\thi\n2\t\\N\t\\N\tthere"))
+		require.NoError(t, err)
+		_, err = w.Write([]byte("\n\\.\n\nCOPY \"public\".\"posts\" (\"id\", \"email\") FROM stdin;\n1\ta@b.com\n\\.\n"))
+		require.NoError(t, err)
+		// Check output
+		assert.NoError(t, w.Flush())
+		email := maskValue("a@b.com", MaskEmail)
+		assert.Equal(t, "COPY \"public\".\"users\" (\"id\", \"email\", \"phone\", \"bio\") FROM stdin;\n"+
+			"1\t"+email+"\t\\N\tREDACTED\n"+
+			"2\t\\N\t\\N\tREDACTED\n"+
+			"\\.\n\n"+
+			"COPY \"public\".\"posts\" (\"id\", \"email\") FROM stdin;\n"+
+			"1\ta@b.com\n"+
+			"\\.\n", out.String())
+	})
+
+	t.Run("masks values deterministically", func(t *testing.T) {
+		// Run test
+		first := maskValue("a@b.com", MaskEmail)
+		// Check output
+		assert.Equal(t, first, maskValue("a@b.com", MaskEmail))
+		assert.NotEqual(t, first, maskValue("c@d.com", MaskEmail))
+		assert.Regexp(t, `^user_[0-9a-f]{12}@example\.com$`, first)
+	})
+}
+
+func TestLoadMaskRules(t *testing.T) {
+	t.Run("qualifies tables with public schema", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "mask.yaml", []byte(`
+tables:
+  users:
+    email: email
+  auth.users:
+    phone: "null"
+`), 0644))
+		// Run test
+		rules, err := loadMaskRules("mask.yaml", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, maskRules{
+			"public.users": {"email": MaskEmail},
+			"auth.users":   {"phone": MaskNull},
+		}, rules)
+	})
+
+	t.Run("throws error on unknown strategy", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "mask.yaml", []byte("tables:\n  users:\n    email: scramble\n"), 0644))
+		// Run test
+		_, err := loadMaskRules("mask.yaml", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid mask strategy for public.users.email: scramble")
+	})
+
+	t.Run("throws error on missing file", func(t *testing.T) {
+		// Run test
+		_, err := loadMaskRules("mask.yaml", afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
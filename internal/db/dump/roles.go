@@ -0,0 +1,41 @@
+package dump
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Saves cluster roles and memberships to the custom roles file, which is applied by db start, reset and push.
+func SaveRoles(ctx context.Context, config pgconn.Config, dryRun bool, fsys afero.Fs) error {
+	if dryRun {
+		return dumpRole(ctx, config, false, dryRun, os.Stdout)
+	}
+	if exists, err := afero.Exists(fsys, utils.CustomRolesPath); err != nil {
+		return errors.Errorf("failed to check roles file: %w", err)
+	} else if exists {
+		title := fmt.Sprintf("Do you want to overwrite existing %s file?", utils.Bold(utils.CustomRolesPath))
+		if shouldOverwrite, err := utils.NewConsole().PromptYesNo(ctx, title, false); err != nil {
+			return err
+		} else if !shouldOverwrite {
+			fmt.Fprintln(os.Stderr, "Skipped saving roles to "+utils.Bold(utils.CustomRolesPath)+".")
+			return nil
+		}
+	}
+	if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(utils.CustomRolesPath)); err != nil {
+		return err
+	}
+	f, err := fsys.OpenFile(utils.CustomRolesPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return errors.Errorf("failed to open roles file: %w", err)
+	}
+	defer f.Close()
+	fmt.Fprintln(os.Stderr, "Dumping roles to "+utils.Bold(utils.CustomRolesPath)+"...")
+	return dumpRole(ctx, config, false, dryRun, f)
+}
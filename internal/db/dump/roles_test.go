@@ -0,0 +1,59 @@
+package dump
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestSaveRoles(t *testing.T) {
+	imageUrl := utils.GetRegistryImageUrl(utils.Config.Db.Image)
+	const containerId = "test-container"
+
+	t.Run("writes roles file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
+		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, `CREATE ROLE "app_user";`))
+		// Run test
+		err := SaveRoles(context.Background(), dbConfig, false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		contents, err := afero.ReadFile(fsys, utils.CustomRolesPath)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(`CREATE ROLE "app_user";`), contents)
+	})
+
+	t.Run("keeps existing roles file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.CustomRolesPath, []byte("-- custom"), 0644))
+		// Run test
+		err := SaveRoles(context.Background(), dbConfig, false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		contents, err := afero.ReadFile(fsys, utils.CustomRolesPath)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("-- custom"), contents)
+	})
+
+	t.Run("throws error on permission denied", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewReadOnlyFs(afero.NewMemMapFs())
+		// Run test
+		err := SaveRoles(context.Background(), dbConfig, false, fsys)
+		// Check error
+		assert.ErrorIs(t, err, os.ErrPermission)
+	})
+}
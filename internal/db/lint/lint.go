@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"regexp"
 	"strings"
 
 	"github.com/go-errors/errors"
@@ -57,6 +58,7 @@ func Run(ctx context.Context, schema []string, level string, failOn string, conf
 	}
 
 	// Apply filtering based on the minimum level
+	result = applyRules(result, utils.Config.Db.Lint.Rules)
 	minLevel := toEnum(level)
 	filtered := filterResult(result, minLevel)
 	err = printResultJSON(filtered, os.Stdout)
@@ -92,6 +94,40 @@ func filterResult(result []Result, minLevel LintLevel) (filtered []Result) {
 	return filtered
 }
 
+var (
+	quotedPattern  = regexp.MustCompile(`"[^"]*"`)
+	nonWordPattern = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// Names a rule after its issue message, ie. unused variable "a" becomes unused_variable.
+func toRuleName(message string) string {
+	name := quotedPattern.ReplaceAllString(strings.ToLower(message), " ")
+	return strings.Trim(nonWordPattern.ReplaceAllString(name, "_"), "_")
+}
+
+// Overrides the level of issues by rule, dropping those that are turned off.
+func applyRules(result []Result, rules map[string]string) (configured []Result) {
+	for _, r := range result {
+		out := Result{Function: r.Function}
+		for _, issue := range r.Issues {
+			issue.Rule = toRuleName(issue.Message)
+			switch rules[issue.Rule] {
+			case "off":
+				continue
+			case "warn":
+				issue.Level = AllowedLevels[0]
+			case "error":
+				issue.Level = AllowedLevels[1]
+			}
+			out.Issues = append(out.Issues, issue)
+		}
+		if len(out.Issues) > 0 {
+			configured = append(configured, out)
+		}
+	}
+	return configured
+}
+
 func printResultJSON(result []Result, stdout io.Writer) error {
 	if len(result) == 0 {
 		return nil
@@ -173,6 +209,7 @@ type Statement struct {
 }
 
 type Issue struct {
+	Rule      string     `json:"rule,omitempty"`
 	Level     string     `json:"level"`
 	Message   string     `json:"message"`
 	Statement *Statement `json:"statement,omitempty"`
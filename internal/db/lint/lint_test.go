@@ -297,3 +297,51 @@ func TestPrintResult(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestApplyRules(t *testing.T) {
+	result := []Result{{
+		Function: "public.f1",
+		Issues: []Issue{{
+			Level:   "warning extra",
+			Message: `unused variable "a"`,
+		}, {
+			Level:   "error",
+			Message: "query returned more than one row",
+		}, {
+			Level:   "warning",
+			Message: "never read variable",
+		}},
+	}}
+
+	t.Run("overrides rule severity", func(t *testing.T) {
+		// Run test
+		configured := applyRules(result, map[string]string{
+			"unused_variable":                  "error",
+			"query_returned_more_than_one_row": "warn",
+			"never_read_variable":              "off",
+		})
+		// Check result
+		assert.Equal(t, []Result{{
+			Function: "public.f1",
+			Issues: []Issue{{
+				Rule:    "unused_variable",
+				Level:   "error",
+				Message: `unused variable "a"`,
+			}, {
+				Rule:    "query_returned_more_than_one_row",
+				Level:   "warning",
+				Message: "query returned more than one row",
+			}},
+		}}, configured)
+	})
+
+	t.Run("drops functions without issues", func(t *testing.T) {
+		// Run test
+		configured := applyRules([]Result{{
+			Function: "public.f2",
+			Issues:   []Issue{{Level: "error", Message: "too many rows"}},
+		}}, map[string]string{"too_many_rows": "off"})
+		// Check result
+		assert.Empty(t, configured)
+	})
+}
@@ -0,0 +1,193 @@
+package psql
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"golang.org/x/term"
+)
+
+func Run(ctx context.Context, args []string, config pgconn.Config, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	if utils.IsLocalDatabase(config) {
+		if err := utils.AssertSupabaseDbIsRunning(); err != nil {
+			return err
+		}
+	}
+	tty := term.IsTerminal(int(os.Stdin.Fd()))
+	// Prefers a locally installed psql, falling back to the client bundled in the Postgres image
+	if psqlPath, err := exec.LookPath("psql"); err == nil {
+		return runLocal(ctx, psqlPath, args, config)
+	}
+	if utils.IsLocalDatabase(config) {
+		// Connects from inside the database container instead of through the host port
+		local := config
+		local.Host = "127.0.0.1"
+		local.Port = 5432
+		return execInDatabase(ctx, buildCommand("psql", args, local), buildEnv(local), tty)
+	}
+	return runInContainer(ctx, buildCommand("psql", args, config), buildEnv(config), tty)
+}
+
+// Only the password is passed through env so that it is not visible in the process list.
+func buildCommand(psqlPath string, args []string, config pgconn.Config) []string {
+	return append([]string{
+		psqlPath,
+		"-h", config.Host,
+		"-p", strconv.Itoa(int(config.Port)),
+		"-U", config.User,
+		"-d", config.Database,
+	}, args...)
+}
+
+func buildEnv(config pgconn.Config) []string {
+	timeoutSecond := int64(config.ConnectTimeout.Seconds())
+	if timeoutSecond == 0 {
+		timeoutSecond = 10
+	}
+	return []string{
+		"PGPASSWORD=" + config.Password,
+		"PGCONNECT_TIMEOUT=" + strconv.FormatInt(timeoutSecond, 10),
+	}
+}
+
+func runLocal(ctx context.Context, psqlPath string, args []string, config pgconn.Config) error {
+	argv := buildCommand(psqlPath, args, config)
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Env = append(os.Environ(), buildEnv(config)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return errors.Errorf("psql exited with code %d", exitErr.ExitCode())
+		}
+		return errors.Errorf("failed to run psql: %w", err)
+	}
+	return nil
+}
+
+func execInDatabase(ctx context.Context, cmd, env []string, tty bool) error {
+	created, err := utils.Docker.ContainerExecCreate(ctx, utils.DbId, container.ExecOptions{
+		Env:          env,
+		Cmd:          cmd,
+		Tty:          tty,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return errors.Errorf("failed to exec docker create: %w", err)
+	}
+	resp, err := utils.Docker.ContainerExecAttach(ctx, created.ID, container.ExecStartOptions{Tty: tty})
+	if err != nil {
+		return errors.Errorf("failed to exec docker attach: %w", err)
+	}
+	defer resp.Close()
+	if tty {
+		if width, height, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+			_ = utils.Docker.ContainerExecResize(ctx, created.ID, container.ResizeOptions{
+				Height: uint(height),
+				Width:  uint(width),
+			})
+		}
+	}
+	if err := streamSession(resp, tty); err != nil {
+		return err
+	}
+	iresp, err := utils.Docker.ContainerExecInspect(ctx, created.ID)
+	if err != nil {
+		return errors.Errorf("failed to exec docker inspect: %w", err)
+	}
+	if iresp.ExitCode > 0 {
+		return errors.Errorf("psql exited with code %d", iresp.ExitCode)
+	}
+	return nil
+}
+
+func runInContainer(ctx context.Context, cmd, env []string, tty bool) error {
+	if err := utils.DockerPullImageIfNotCached(ctx, utils.Config.Db.Image); err != nil {
+		return err
+	}
+	config := container.Config{
+		Image:        utils.GetRegistryImageUrl(utils.Config.Db.Image),
+		Env:          env,
+		Cmd:          cmd,
+		Tty:          tty,
+		OpenStdin:    true,
+		StdinOnce:    true,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+	}
+	created, err := utils.Docker.ContainerCreate(ctx, &config, &container.HostConfig{}, nil, nil, "")
+	if err != nil {
+		return errors.Errorf("failed to create docker container: %w", err)
+	}
+	defer utils.DockerRemove(created.ID)
+	// Attach before starting so that no output is lost
+	resp, err := utils.Docker.ContainerAttach(ctx, created.ID, container.AttachOptions{
+		Stream: true,
+		Stdin:  true,
+		Stdout: true,
+		Stderr: true,
+	})
+	if err != nil {
+		return errors.Errorf("failed to attach docker container: %w", err)
+	}
+	defer resp.Close()
+	statusCh, errCh := utils.Docker.ContainerWait(ctx, created.ID, container.WaitConditionNextExit)
+	if err := utils.Docker.ContainerStart(ctx, created.ID, container.StartOptions{}); err != nil {
+		return errors.Errorf("failed to start docker container: %w", err)
+	}
+	if err := streamSession(resp, tty); err != nil {
+		return err
+	}
+	select {
+	case status := <-statusCh:
+		if status.StatusCode > 0 {
+			return errors.Errorf("psql exited with code %d", status.StatusCode)
+		}
+	case err := <-errCh:
+		return errors.Errorf("failed to wait for docker container: %w", err)
+	}
+	return nil
+}
+
+// Forwards the terminal to an attached docker session until its output is closed.
+func streamSession(resp types.HijackedResponse, tty bool) error {
+	if tty {
+		fd := int(os.Stdin.Fd())
+		if state, err := term.MakeRaw(fd); err == nil {
+			defer func() { _ = term.Restore(fd, state) }()
+		}
+	}
+	go func() {
+		_, _ = io.Copy(resp.Conn, os.Stdin)
+		_ = resp.CloseWrite()
+	}()
+	var err error
+	if tty {
+		// Tty sessions are not multiplexed
+		_, err = io.Copy(os.Stdout, resp.Reader)
+	} else {
+		_, err = stdcopy.StdCopy(os.Stdout, os.Stderr, resp.Reader)
+	}
+	if err != nil {
+		return errors.Errorf("failed to copy docker output: %w", err)
+	}
+	return nil
+}
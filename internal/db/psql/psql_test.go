@@ -0,0 +1,49 @@
+package psql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCommand(t *testing.T) {
+	local := pgconn.Config{
+		Host:     "127.0.0.1",
+		Port:     54322,
+		User:     "postgres",
+		Password: "password",
+		Database: "postgres",
+	}
+
+	t.Run("passes connection flags without password", func(t *testing.T) {
+		// Run test
+		argv := buildCommand("/usr/bin/psql", []string{"-c", "select 1"}, local)
+		// Check output
+		assert.Equal(t, []string{
+			"/usr/bin/psql",
+			"-h", "127.0.0.1",
+			"-p", "54322",
+			"-U", "postgres",
+			"-d", "postgres",
+			"-c", "select 1",
+		}, argv)
+		assert.NotContains(t, argv, local.Password)
+	})
+
+	t.Run("passes password through env", func(t *testing.T) {
+		remote := pgconn.Config{
+			Host:           "db.supabase.co",
+			Port:           5432,
+			User:           "postgres",
+			Password:       "secret",
+			Database:       "postgres",
+			ConnectTimeout: 5 * time.Second,
+		}
+		// Run test
+		env := buildEnv(remote)
+		// Check output
+		assert.Equal(t, []string{"PGPASSWORD=secret", "PGCONNECT_TIMEOUT=5"}, env)
+	})
+}
@@ -0,0 +1,85 @@
+package push
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+type MigrationPlan struct {
+	Version     string `json:"version" toml:"version" yaml:"version"`
+	Name        string `json:"name" toml:"name" yaml:"name"`
+	Path        string `json:"path" toml:"path" yaml:"path"`
+	Checksum    string `json:"checksum" toml:"checksum" yaml:"checksum"`
+	Statements  int    `json:"statements" toml:"statements" yaml:"statements"`
+	Transaction bool   `json:"transaction" toml:"transaction" yaml:"transaction"`
+}
+
+// Statements that end the implicit transaction of a migration batch, or cannot run inside one.
+var nonTransactionalPattern = regexp.MustCompile(`(?is)^\s*(begin|start\s+transaction|commit|end|rollback|vacuum|create\s+database|drop\s+database|alter\s+system|create\s+(unique\s+)?index\s+concurrently|drop\s+index\s+concurrently|reindex\s+.*concurrently|refresh\s+materialized\s+view\s+concurrently)\b`)
+
+func buildPlan(pending []string, fsys afero.Fs) ([]MigrationPlan, error) {
+	result := make([]MigrationPlan, len(pending))
+	for i, path := range pending {
+		contents, err := afero.ReadFile(fsys, path)
+		if err != nil {
+			return nil, errors.Errorf("failed to read migration file: %w", err)
+		}
+		file, err := migration.NewMigrationFromFile(path, afero.NewIOFS(fsys))
+		if err != nil {
+			return nil, err
+		}
+		digest := sha256.Sum256(contents)
+		result[i] = MigrationPlan{
+			Version:     file.Version,
+			Name:        file.Name,
+			Path:        path,
+			Checksum:    hex.EncodeToString(digest[:]),
+			Statements:  len(file.Statements),
+			Transaction: !file.NoTransaction && isTransactional(file.Statements),
+		}
+	}
+	return result, nil
+}
+
+func isTransactional(statements []string) bool {
+	for _, stat := range statements {
+		if nonTransactionalPattern.MatchString(stat) {
+			return false
+		}
+	}
+	return true
+}
+
+func printPlan(plan []MigrationPlan) error {
+	switch utils.OutputFormat.Value {
+	case utils.OutputPretty:
+		table := `|#|MIGRATION|CHECKSUM (SHA256)|STATEMENTS|TRANSACTION|
+|-|-|-|-|-|
+`
+		for i, p := range plan {
+			tx := "yes"
+			if !p.Transaction {
+				tx = "no"
+			}
+			table += fmt.Sprintf("|`%d`|`%s`|`%s`|`%d`|`%s`|\n", i+1, filepath.Base(p.Path), p.Checksum, p.Statements, tx)
+		}
+		return list.RenderTable(table)
+	case utils.OutputToml:
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, struct {
+			Migrations []MigrationPlan `toml:"migrations"`
+		}{
+			Migrations: plan,
+		})
+	}
+	return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, plan)
+}
@@ -0,0 +1,57 @@
+package push
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestBuildPlan(t *testing.T) {
+	t.Run("builds plan in order", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		first := filepath.Join(utils.MigrationsDir, "1_create_table.sql")
+		sql := []byte("create table test (id int);\ncreate index test_idx on test (id);")
+		require.NoError(t, afero.WriteFile(fsys, first, sql, 0644))
+		second := filepath.Join(utils.MigrationsDir, "2_add_index.sql")
+		require.NoError(t, afero.WriteFile(fsys, second, []byte("create index concurrently test_id on test (id);"), 0644))
+		// Run test
+		plan, err := buildPlan([]string{first, second}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		require.Len(t, plan, 2)
+		digest := sha256.Sum256(sql)
+		assert.Equal(t, MigrationPlan{
+			Version:     "1",
+			Name:        "create_table",
+			Path:        first,
+			Checksum:    hex.EncodeToString(digest[:]),
+			Statements:  2,
+			Transaction: true,
+		}, plan[0])
+		assert.Equal(t, "2", plan[1].Version)
+		assert.False(t, plan[1].Transaction)
+		// Check rendering
+		assert.NoError(t, printPlan(plan))
+	})
+
+	t.Run("throws error on missing file", func(t *testing.T) {
+		// Run test
+		plan, err := buildPlan([]string{"missing.sql"}, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to read migration file:")
+		assert.Empty(t, plan)
+	})
+}
+
+func TestIsTransactional(t *testing.T) {
+	assert.True(t, isTransactional([]string{"create table ending (id int)", "alter type mood add value 'ok'"}))
+	assert.False(t, isTransactional([]string{"BEGIN", "select 1", "COMMIT"}))
+	assert.False(t, isTransactional([]string{"VACUUM ANALYZE test"}))
+}
@@ -16,7 +16,7 @@ import (
 	"github.com/supabase/cli/pkg/migration"
 )
 
-func Run(ctx context.Context, dryRun, ignoreVersionMismatch bool, includeRoles, includeSeed bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+func Run(ctx context.Context, dryRun, ignoreVersionMismatch bool, includeRoles, includeSeed bool, schema []string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
 	if dryRun {
 		fmt.Fprintln(os.Stderr, "DRY RUN: migrations will *not* be pushed to the database.")
 	}
@@ -25,10 +25,17 @@ func Run(ctx context.Context, dryRun, ignoreVersionMismatch bool, includeRoles,
 		return err
 	}
 	defer conn.Close(context.Background())
+	checkMajorVersion(conn)
 	pending, err := up.GetPendingMigrations(ctx, ignoreVersionMismatch, conn, fsys)
 	if err != nil {
 		return err
 	}
+	if err := up.CheckModifiedMigrations(ctx, conn, fsys); err != nil {
+		return err
+	}
+	if err := checkSchemas(pending, schema, fsys); err != nil {
+		return err
+	}
 	var seeds []migration.SeedFile
 	if includeSeed {
 		if remote, _ := utils.Config.GetRemoteByProjectRef(flags.ProjectRef); !remote.Db.Seed.Enabled {
@@ -55,8 +62,14 @@ func Run(ctx context.Context, dryRun, ignoreVersionMismatch bool, includeRoles,
 			fmt.Fprintln(os.Stderr, "Would create custom roles "+utils.Bold(globals[0])+"...")
 		}
 		if len(pending) > 0 {
-			fmt.Fprintln(os.Stderr, "Would push these migrations:")
-			fmt.Fprint(os.Stderr, confirmPushAll(pending))
+			plan, err := buildPlan(pending, fsys)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(os.Stderr, "Would push these migrations in order:")
+			if err := printPlan(plan); err != nil {
+				return err
+			}
 		}
 		if len(seeds) > 0 {
 			fmt.Fprintln(os.Stderr, "Would seed these files:")
@@ -113,6 +126,22 @@ func confirmPushAll(pending []string) (msg string) {
 	return msg
 }
 
+// Warns when migrations tested locally may behave differently on the remote database.
+func checkMajorVersion(conn *pgx.Conn) {
+	local := utils.Config.Db.MajorVersion
+	remote := utils.GetServerMajorVersion(conn)
+	if remote == 0 || local == 0 || remote == local {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%s Local database uses Postgres %d but remote database uses Postgres %d.", utils.Yellow("WARNING:"), local, remote)
+	// The local development stack only pins images up to Postgres 15
+	if remote > 15 {
+		fmt.Fprintln(os.Stderr, " This version is not yet supported by the local development stack.")
+	} else {
+		fmt.Fprintf(os.Stderr, " Update %s in %s to match.\n", utils.Aqua("db.major_version"), utils.Bold(utils.ConfigPath))
+	}
+}
+
 func confirmSeedAll(pending []migration.SeedFile) (msg string) {
 	for _, seed := range pending {
 		notice := seed.Path
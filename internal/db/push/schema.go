@@ -0,0 +1,60 @@
+package push
+
+import (
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+var (
+	ddlPattern       = regexp.MustCompile(`(?is)^\s*(create|alter|drop|truncate|comment|grant|revoke)\b`)
+	schemaPattern    = regexp.MustCompile(`(?is)^\s*(?:create|alter|drop)\s+schema\s+(?:if\s+(?:not\s+)?exists\s+)?"?([a-z_][a-z0-9_$]*)"?`)
+	inSchemaPattern  = regexp.MustCompile(`(?is)\bin\s+schema\s+"?([a-z_][a-z0-9_$]*)"?`)
+	qualifiedPattern = regexp.MustCompile(`(?i)"?([a-z_][a-z0-9_$]*)"?\s*\.\s*"?[a-z_]`)
+)
+
+// Returns the schema of the object targeted by a DDL statement, or empty if unqualified.
+func targetSchema(stat string) string {
+	if !ddlPattern.MatchString(stat) {
+		return ""
+	}
+	if m := schemaPattern.FindStringSubmatch(stat); len(m) > 1 {
+		return strings.ToLower(m[1])
+	}
+	// Only consider the object name, not references in arguments or bodies
+	prefix := stat
+	if i := strings.IndexAny(prefix, "($"); i >= 0 {
+		prefix = prefix[:i]
+	}
+	if m := inSchemaPattern.FindStringSubmatch(prefix); len(m) > 1 {
+		return strings.ToLower(m[1])
+	}
+	if m := qualifiedPattern.FindStringSubmatch(prefix); len(m) > 1 {
+		return strings.ToLower(m[1])
+	}
+	return ""
+}
+
+// Rejects pending migrations that modify schemas outside of the included list.
+func checkSchemas(pending, schema []string, fsys afero.Fs) error {
+	if len(schema) == 0 {
+		return nil
+	}
+	for _, path := range pending {
+		file, err := migration.NewMigrationFromFile(path, afero.NewIOFS(fsys))
+		if err != nil {
+			return err
+		}
+		for i, stat := range file.Statements {
+			if s := targetSchema(stat); len(s) > 0 && !slices.Contains(schema, s) {
+				return errors.Errorf("Migration %s modifies schema %s, which is not included in --schema.\nAt statement %d: %s", filepath.Base(path), s, i, stat)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package push
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestTargetSchema(t *testing.T) {
+	cases := map[string]string{
+		"create table public.todos (id bigint)":                                        "public",
+		`create policy "owner" on storage.objects using (auth.uid() = owner)`:          "storage",
+		"create trigger on_signup after insert on auth.users for each row execute f()": "auth",
+		"create or replace function app.f() returns uuid as $$ select auth.uid() $$":   "app",
+		`alter table "Private"."Todos" enable row level security`:                      "private",
+		"create schema if not exists app":                                              "app",
+		"grant select on all tables in schema realtime to anon":                        "realtime",
+		"create table todos (id bigint references auth.users)":                         "",
+		"insert into auth.users (id) values (1)":                                       "",
+		"create extension if not exists pg_net with schema extensions":                 "",
+	}
+	for stat, expected := range cases {
+		assert.Equal(t, expected, targetSchema(stat), stat)
+	}
+}
+
+func TestCheckSchemas(t *testing.T) {
+	t.Run("allows included schemas", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_schema.sql")
+		sql := "create table public.todos (id bigint);\ncreate policy p on storage.objects using (auth.uid() = owner);"
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(sql), 0644))
+		// Run test
+		err := checkSchemas([]string{path}, []string{"public", "storage"}, fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on excluded schema", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_schema.sql")
+		sql := "create table public.todos (id bigint);\nalter table auth.users add column nickname text;"
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(sql), 0644))
+		// Run test
+		err := checkSchemas([]string{path}, []string{"public"}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Migration 0_schema.sql modifies schema auth, which is not included in --schema.")
+		assert.ErrorContains(t, err, "At statement 1: alter table auth.users add column nickname text")
+	})
+}
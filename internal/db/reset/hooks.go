@@ -0,0 +1,75 @@
+package reset
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/apply"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+// Applies migrations and seed after the before hooks declared in config.
+func migrateAndSeed(ctx context.Context, version string, config pgconn.Config, conn *pgx.Conn, fsys afero.Fs) error {
+	if err := runHooks(ctx, utils.Config.Db.Reset.Before, config, conn, fsys); err != nil {
+		return err
+	}
+	return apply.MigrateAndSeed(ctx, version, conn, fsys)
+}
+
+// After hooks run once the reset has finished, including bucket seeding and service restarts.
+func runAfterHooks(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if len(utils.Config.Db.Reset.After) == 0 {
+		return nil
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	return runHooks(ctx, utils.Config.Db.Reset.After, config, conn, fsys)
+}
+
+func runHooks(ctx context.Context, hooks []string, config pgconn.Config, conn *pgx.Conn, fsys afero.Fs) error {
+	for _, path := range hooks {
+		fmt.Fprintln(os.Stderr, "Running reset hook "+utils.Bold(path)+"...")
+		if filepath.Ext(path) == ".sql" {
+			// Hooks are not tracked in migration history
+			file, err := migration.NewMigrationFromFile(path, afero.NewIOFS(fsys))
+			if err != nil {
+				return err
+			}
+			file.Version = ""
+			if err := file.ExecBatch(ctx, conn); err != nil {
+				return errors.Errorf("failed to run reset hook %s: %w", path, err)
+			}
+			continue
+		}
+		if err := runScript(ctx, path, config); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runScript(ctx context.Context, path string, config pgconn.Config) error {
+	name, args := path, []string{}
+	if filepath.Ext(path) == ".sh" {
+		name, args = "sh", []string{path}
+	}
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Env = append(os.Environ(), "DB_URL="+utils.ToPostgresURL(config))
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return errors.Errorf("failed to run reset hook %s: %w", path, err)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -22,14 +23,18 @@ import (
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/db/start"
 	"github.com/supabase/cli/internal/gen/keys"
-	"github.com/supabase/cli/internal/migration/apply"
 	"github.com/supabase/cli/internal/migration/repair"
 	"github.com/supabase/cli/internal/seed/buckets"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/migration"
 )
 
-func Run(ctx context.Context, version string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+func Run(ctx context.Context, version string, schema []string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	for _, s := range schema {
+		if slices.Contains(utils.InternalSchemas, s) {
+			return errors.Errorf("Cannot reset managed schema: %s", s)
+		}
+	}
 	if len(version) > 0 {
 		if _, err := strconv.Atoi(version); err != nil {
 			return errors.New(repair.ErrInvalidVersion)
@@ -45,14 +50,28 @@ func Run(ctx context.Context, version string, config pgconn.Config, fsys afero.F
 		} else if !shouldReset {
 			return errors.New(context.Canceled)
 		}
-		return resetRemote(ctx, version, config, fsys, options...)
+		if len(schema) > 0 {
+			if err := resetSchemas(ctx, version, schema, config, fsys, options...); err != nil {
+				return err
+			}
+		} else if err := resetRemote(ctx, version, config, fsys, options...); err != nil {
+			return err
+		}
+		return runAfterHooks(ctx, config, fsys, options...)
 	}
 	// Config file is loaded before parsing --linked or --local flags
 	if err := utils.AssertSupabaseDbIsRunning(); err != nil {
 		return err
 	}
+	// Partial reset keeps the database container running
+	if len(schema) > 0 {
+		if err := resetSchemas(ctx, version, schema, config, fsys, options...); err != nil {
+			return err
+		}
+		return runAfterHooks(ctx, config, fsys, options...)
+	}
 	// Reset postgres database because extensions (pg_cron, pg_net) require postgres
-	if err := resetDatabase(ctx, version, fsys, options...); err != nil {
+	if err := resetDatabase(ctx, version, config, fsys, options...); err != nil {
 		return err
 	}
 	// Seed objects from supabase/buckets directory
@@ -66,17 +85,20 @@ func Run(ctx context.Context, version string, config pgconn.Config, fsys afero.F
 			return err
 		}
 	}
+	if err := runAfterHooks(ctx, config, fsys, options...); err != nil {
+		return err
+	}
 	branch := keys.GetGitBranch(fsys)
 	fmt.Fprintln(os.Stderr, "Finished "+utils.Aqua("supabase db reset")+" on branch "+utils.Aqua(branch)+".")
 	return nil
 }
 
-func resetDatabase(ctx context.Context, version string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+func resetDatabase(ctx context.Context, version string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
 	fmt.Fprintln(os.Stderr, "Resetting local database"+toLogMessage(version))
 	if utils.Config.Db.MajorVersion <= 14 {
-		return resetDatabase14(ctx, version, fsys, options...)
+		return resetDatabase14(ctx, version, config, fsys, options...)
 	}
-	return resetDatabase15(ctx, version, fsys, options...)
+	return resetDatabase15(ctx, version, config, fsys, options...)
 }
 
 func toLogMessage(version string) string {
@@ -86,7 +108,7 @@ func toLogMessage(version string) string {
 	return "..."
 }
 
-func resetDatabase14(ctx context.Context, version string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+func resetDatabase14(ctx context.Context, version string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
 	if err := recreateDatabase(ctx, options...); err != nil {
 		return err
 	}
@@ -101,17 +123,17 @@ func resetDatabase14(ctx context.Context, version string, fsys afero.Fs, options
 		return err
 	}
 	defer conn.Close(context.Background())
-	return apply.MigrateAndSeed(ctx, version, conn, fsys)
+	return migrateAndSeed(ctx, version, config, conn, fsys)
 }
 
-func resetDatabase15(ctx context.Context, version string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+func resetDatabase15(ctx context.Context, version string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
 	if err := utils.Docker.ContainerRemove(ctx, utils.DbId, container.RemoveOptions{Force: true}); err != nil {
 		return errors.Errorf("failed to remove container: %w", err)
 	}
 	if err := utils.Docker.VolumeRemove(ctx, utils.DbId, true); err != nil {
 		return errors.Errorf("failed to remove volume: %w", err)
 	}
-	config := start.NewContainerConfig()
+	containerConfig := start.NewContainerConfig()
 	hostConfig := start.NewHostConfig()
 	networkingConfig := network.NetworkingConfig{
 		EndpointsConfig: map[string]*network.EndpointSettings{
@@ -121,19 +143,31 @@ func resetDatabase15(ctx context.Context, version string, fsys afero.Fs, options
 		},
 	}
 	fmt.Fprintln(os.Stderr, "Recreating database...")
-	if _, err := utils.DockerStart(ctx, config, hostConfig, networkingConfig, utils.DbId); err != nil {
+	if _, err := utils.DockerStart(ctx, containerConfig, hostConfig, networkingConfig, utils.DbId); err != nil {
 		return err
 	}
 	if err := start.WaitForHealthyService(ctx, start.HealthTimeout, utils.DbId); err != nil {
 		return err
 	}
-	if err := start.SetupLocalDatabase(ctx, version, fsys, os.Stderr, options...); err != nil {
+	if err := setupLocalDatabase(ctx, version, config, fsys, options...); err != nil {
 		return err
 	}
 	fmt.Fprintln(os.Stderr, "Restarting containers...")
 	return restartServices(ctx)
 }
 
+func setupLocalDatabase(ctx context.Context, version string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{}, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if err := start.SetupDatabase(ctx, conn, utils.DbId, os.Stderr, fsys); err != nil {
+		return err
+	}
+	return migrateAndSeed(ctx, version, config, conn, fsys)
+}
+
 func initDatabase(ctx context.Context, options ...func(*pgx.ConnConfig)) error {
 	conn, err := utils.ConnectLocalPostgres(ctx, pgconn.Config{User: "supabase_admin"}, options...)
 	if err != nil {
@@ -241,7 +275,23 @@ func resetRemote(ctx context.Context, version string, config pgconn.Config, fsys
 	if err := migration.DropUserSchemas(ctx, conn); err != nil {
 		return err
 	}
-	return apply.MigrateAndSeed(ctx, version, conn, fsys)
+	return migrateAndSeed(ctx, version, config, conn, fsys)
+}
+
+func resetSchemas(ctx context.Context, version string, schema []string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if err := assertSchemaTargets(version, schema, fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Resetting schemas "+utils.Aqua(strings.Join(schema, ", "))+toLogMessage(version))
+	conn, err := utils.ConnectByConfigStream(ctx, config, io.Discard, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	if err := migration.DropSchemas(ctx, conn, schema); err != nil {
+		return err
+	}
+	return migrateAndSeed(ctx, version, config, conn, fsys)
 }
 
 func LikeEscapeSchema(schemas []string) (result []string) {
@@ -95,17 +95,26 @@ func TestResetCommand(t *testing.T) {
 			Reply(http.StatusOK).
 			JSON([]storage.BucketResponse{})
 		// Run test
-		err := Run(context.Background(), "", dbConfig, fsys, conn.Intercept)
+		err := Run(context.Background(), "", nil, dbConfig, fsys, conn.Intercept)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 
+	t.Run("throws error on managed schema", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Run(context.Background(), "", []string{"public", "auth"}, dbConfig, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Cannot reset managed schema: auth")
+	})
+
 	t.Run("throws error on context canceled", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), "", pgconn.Config{Host: "db.supabase.co"}, fsys)
+		err := Run(context.Background(), "", nil, pgconn.Config{Host: "db.supabase.co"}, fsys)
 		// Check error
 		assert.ErrorIs(t, err, context.Canceled)
 	})
@@ -115,7 +124,7 @@ func TestResetCommand(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), "", pgconn.Config{Host: "db.supabase.co"}, fsys)
+		err := Run(context.Background(), "", nil, pgconn.Config{Host: "db.supabase.co"}, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "invalid port (outside range)")
 	})
@@ -130,7 +139,7 @@ func TestResetCommand(t *testing.T) {
 			Get("/v" + utils.Docker.ClientVersion() + "/containers").
 			Reply(http.StatusNotFound)
 		// Run test
-		err := Run(context.Background(), "", dbConfig, fsys)
+		err := Run(context.Background(), "", nil, dbConfig, fsys)
 		// Check error
 		assert.ErrorIs(t, err, utils.ErrNotRunning)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -152,7 +161,7 @@ func TestResetCommand(t *testing.T) {
 			Delete("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.DbId).
 			ReplyError(errors.New("network error"))
 		// Run test
-		err := Run(context.Background(), "", dbConfig, fsys)
+		err := Run(context.Background(), "", nil, dbConfig, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "network error")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -492,3 +501,83 @@ func TestResetRemote(t *testing.T) {
 		assert.ErrorContains(t, err, "ERROR: permission denied for relation supabase_migrations (SQLSTATE 42501)")
 	})
 }
+
+func TestFindForeignSchema(t *testing.T) {
+	schema := []string{"public", "Private"}
+	for stmt, expected := range map[string]string{
+		"create table public.todos (id uuid references auth.users)":                                         "",
+		`alter table "Private".notes add column body text`:                                                  "",
+		"insert into storage.buckets (id) values ('avatars')":                                               "storage",
+		"-- seed\nINSERT INTO auth.users (id) VALUES (gen_random_uuid())":                                   "auth",
+		"create trigger on_signup after insert on auth.users for each row execute function public.handle()": "auth",
+		"insert into storage.buckets (id) values ('avatars') on conflict do nothing":                        "",
+		"create schema if not exists app":                                                                   "",
+		"create or replace function app.hello() returns text as $$ select 'hi' $$":                          "",
+		"create table todos (id bigint)":                                                                    "",
+		"select cron.schedule('job', '* * * * *', 'select 1')":                                              "",
+	} {
+		assert.Equal(t, expected, findForeignSchema(stmt, schema), stmt)
+	}
+}
+
+func TestResetSchemas(t *testing.T) {
+	dbConfig := pgconn.Config{
+		Host:     "127.0.0.1",
+		Port:     5432,
+		User:     "admin",
+		Password: "password",
+		Database: "postgres",
+	}
+
+	t.Run("resets selected schemas", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_schema.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, nil, 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.SET_RESET_SCHEMAS, "public,private").
+			Reply("SELECT 1", []interface{}{"public,private"}).
+			Query(migration.DropSchemaObjects).
+			Reply("DO")
+		helper.MockMigrationHistory(conn).
+			Query(migration.INSERT_MIGRATION_VERSION, "0", "schema", nil).
+			Reply("INSERT 0 1")
+		utils.Config.Db.Seed.Enabled = false
+		// Run test
+		err := resetSchemas(context.Background(), "", []string{"public", "private"}, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on migration touching other schemas", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_schema.sql")
+		sql := `create table public.todos (id bigint primary key);
+create policy "read avatars" on storage.objects for select using (bucket_id = 'avatars');`
+		require.NoError(t, afero.WriteFile(fsys, path, []byte(sql), 0644))
+		utils.Config.Db.Seed.Enabled = false
+		// Run test
+		err := resetSchemas(context.Background(), "", []string{"public"}, dbConfig, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Cannot reset schemas public because "+path+" modifies schema storage")
+	})
+
+	t.Run("throws error on drop failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.SET_RESET_SCHEMAS, "public").
+			Reply("SELECT 1", []interface{}{"public"}).
+			Query(migration.DropSchemaObjects).
+			ReplyError(pgerrcode.InsufficientPrivilege, "must be owner of table todos")
+		// Run test
+		err := resetSchemas(context.Background(), "", []string{"public"}, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "ERROR: must be owner of table todos (SQLSTATE 42501)")
+	})
+}
@@ -0,0 +1,74 @@
+package reset
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+var (
+	// Matches the first schema qualified object that a statement creates or modifies.
+	targetPattern = regexp.MustCompile(`(?i)\b(?:table|into|on|view|function|procedure|type|sequence|index|trigger|domain)\s+(?:only\s+)?("(?:[^"]|"")+"|[a-z_][a-z0-9_$]*)\s*\.\s*(?:"(?:[^"]|"")+"|[a-z_][a-z0-9_$]*)`)
+	// Statements that can safely run again on objects that were not dropped.
+	replayablePattern = regexp.MustCompile(`(?i)\bif\s+not\s+exists\b|\bor\s+replace\b|\bon\s+conflict\b`)
+	commentPattern    = regexp.MustCompile(`(?m)^\s*--.*$`)
+)
+
+// Migration history is replayed in full after a partial reset, so statements that
+// modify objects outside the reset schemas would fail with duplicate errors.
+func assertSchemaTargets(version string, schema []string, fsys afero.Fs) error {
+	paths, err := list.LoadPartialMigrations(version, fsys)
+	if err != nil {
+		return err
+	}
+	if utils.Config.Db.Seed.Enabled {
+		for _, p := range utils.Config.Db.Seed.SqlPaths {
+			if filepath.Ext(p) == ".sql" {
+				paths = append(paths, p)
+			}
+		}
+	}
+	for _, p := range paths {
+		file, err := migration.NewMigrationFromFile(p, afero.NewIOFS(fsys))
+		if err != nil {
+			return err
+		}
+		for _, stmt := range file.Statements {
+			if target := findForeignSchema(stmt, schema); len(target) > 0 {
+				utils.CmdSuggestion = fmt.Sprintf("Run %s without %s to reset all schemas.", utils.Aqua("supabase db reset"), utils.Aqua("--schema"))
+				return errors.Errorf("Cannot reset schemas %s because %s modifies schema %s:\n%s", strings.Join(schema, ", "), utils.Bold(p), target, strings.TrimSpace(stmt))
+			}
+		}
+	}
+	return nil
+}
+
+func findForeignSchema(stmt string, schema []string) string {
+	stmt = strings.TrimSpace(commentPattern.ReplaceAllString(stmt, ""))
+	words := strings.Fields(strings.ToLower(stmt))
+	if len(words) == 0 || !slices.Contains([]string{"create", "alter", "insert"}, words[0]) || replayablePattern.MatchString(stmt) {
+		return ""
+	}
+	matches := targetPattern.FindStringSubmatch(stmt)
+	if len(matches) < 2 {
+		return ""
+	}
+	target := matches[1]
+	if strings.HasPrefix(target, `"`) {
+		target = strings.ReplaceAll(strings.Trim(target, `"`), `""`, `"`)
+	} else {
+		target = strings.ToLower(target)
+	}
+	if slices.Contains(schema, target) {
+		return ""
+	}
+	return target
+}
@@ -0,0 +1,157 @@
+package restore
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+	"github.com/supabase/cli/pkg/parser"
+)
+
+// Number of statements sent to the database before reporting progress.
+const batchSize = 1000
+
+var copyStdinPattern = regexp.MustCompile(`(?is)^\s*copy\s+.*\s+from\s+stdin\b`)
+
+func Run(ctx context.Context, path string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	total, err := countStatements(path, fsys)
+	if err != nil {
+		return err
+	}
+	db := "remote"
+	if utils.IsLocalDatabase(config) {
+		db = "local"
+	}
+	msg := fmt.Sprintf("Do you want to drop all application schemas in the %s database and restore from %s?", db, filepath.Base(path))
+	if shouldRestore, err := utils.NewConsole().PromptYesNo(ctx, msg, false); err != nil {
+		return err
+	} else if !shouldRestore {
+		return errors.New(context.Canceled)
+	}
+	conn, err := utils.ConnectByConfigStream(ctx, config, io.Discard, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	r, err := openDump(path, fsys)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if err := restoreDump(ctx, r, total, conn); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Finished "+utils.Aqua("supabase db restore")+".")
+	return nil
+}
+
+type dumpReader struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (d *dumpReader) Close() error {
+	var errs []error
+	for i := len(d.closers) - 1; i >= 0; i-- {
+		errs = append(errs, d.closers[i].Close())
+	}
+	return errors.Join(errs...)
+}
+
+func openDump(path string, fsys afero.Fs) (io.ReadCloser, error) {
+	f, err := fsys.Open(path)
+	if err != nil {
+		return nil, errors.Errorf("failed to open dump file: %w", err)
+	}
+	if !strings.HasSuffix(path, ".gz") {
+		return f, nil
+	}
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, errors.Errorf("failed to decompress dump file: %w", err)
+	}
+	return &dumpReader{Reader: gz, closers: []io.Closer{f, gz}}, nil
+}
+
+// Validates the dump without loading it into memory, returning the number of statements.
+func countStatements(path string, fsys afero.Fs) (int, error) {
+	r, err := openDump(path, fsys)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+	var total int
+	if err := parser.StreamAndTrim(r, func(stat string) error {
+		if copyStdinPattern.MatchString(stat) {
+			return errors.Errorf("COPY FROM stdin is not supported. Dump the data without %s and try again.", utils.Aqua("--use-copy"))
+		}
+		total++
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// Drops user schemas and restores all statements in a single transaction, so a failure leaves the database unchanged.
+func restoreDump(ctx context.Context, r io.Reader, total int, conn *pgx.Conn) error {
+	fmt.Fprintf(os.Stderr, "Restoring %d statements...\n", total)
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return errors.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(context.Background()); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			fmt.Fprintln(os.Stderr, "failed to rollback transaction:", err)
+		}
+	}()
+	if err := migration.DropUserSchemas(ctx, tx.Conn()); err != nil {
+		return err
+	}
+	var restored int
+	batch := make([]string, 0, batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		b := &pgconn.Batch{}
+		for _, s := range batch {
+			b.ExecParams(s, nil, nil, nil, nil)
+		}
+		if result, err := tx.Conn().PgConn().ExecBatch(ctx, b).ReadAll(); err != nil {
+			i := len(result)
+			return errors.Errorf("%w\nAt statement %d: %s", err, restored+i, batch[i])
+		}
+		restored += len(batch)
+		batch = batch[:0]
+		fmt.Fprintf(os.Stderr, "Restored %d of %d statements\n", restored, total)
+		return nil
+	}
+	if err := parser.StreamAndTrim(r, func(stat string) error {
+		if batch = append(batch, stat); len(batch) < batchSize {
+			return nil
+		}
+		return flush()
+	}); err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
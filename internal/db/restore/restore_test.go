@@ -0,0 +1,101 @@
+package restore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/pkg/migration"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+func TestCountStatements(t *testing.T) {
+	t.Run("counts compressed dump", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		_, err := gz.Write([]byte("create table public.todos ();\ninsert into public.todos default values;"))
+		require.NoError(t, err)
+		require.NoError(t, gz.Close())
+		require.NoError(t, afero.WriteFile(fsys, "backup.sql.gz", buf.Bytes(), 0644))
+		// Run test
+		total, err := countStatements("backup.sql.gz", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, 2, total)
+	})
+
+	t.Run("throws error on copy from stdin", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "backup.sql", []byte("COPY public.todos (id) FROM stdin;"), 0644))
+		// Run test
+		_, err := countStatements("backup.sql", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "COPY FROM stdin is not supported.")
+	})
+
+	t.Run("throws error on missing file", func(t *testing.T) {
+		// Run test
+		_, err := countStatements("backup.sql", afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to open dump file:")
+	})
+}
+
+var escapedSchemas = append(migration.ManagedSchemas, "extensions", "public")
+
+func TestRestoreDump(t *testing.T) {
+	stats := []string{"create table public.todos ()", "insert into public.todos default values"}
+	dump := strings.Join(stats, ";\n") + ";"
+
+	t.Run("restores statements in transaction", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("begin").Reply("BEGIN").
+			Query(migration.ListSchemas, escapedSchemas).
+			Reply("SELECT 0").
+			Query(migration.DropObjects).
+			Reply("INSERT 0").
+			Query(stats[0]).
+			Reply("CREATE TABLE").
+			Query(stats[1]).
+			Reply("INSERT 0 1").
+			Query("commit").Reply("COMMIT")
+		// Run test
+		err := restoreDump(context.Background(), strings.NewReader(dump), len(stats), conn.MockClient(t))
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("rolls back dropped schemas on failure", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("begin").Reply("BEGIN").
+			Query(migration.ListSchemas, escapedSchemas).
+			Reply("SELECT 1", []interface{}{"private"}).
+			Query("DROP SCHEMA IF EXISTS private CASCADE").
+			Reply("DROP SCHEMA").
+			Query(migration.DropObjects).
+			Reply("INSERT 0").
+			Query(stats[0]).
+			Reply("CREATE TABLE").
+			Query(stats[1]).
+			ReplyError(pgerrcode.UndefinedTable, `relation "public.todos" does not exist`).
+			Query("rollback").Reply("ROLLBACK")
+		// Run test
+		err := restoreDump(context.Background(), strings.NewReader(dump), len(stats), conn.MockClient(t))
+		// Check error
+		assert.ErrorContains(t, err, `ERROR: relation "public.todos" does not exist (SQLSTATE 42P01)`)
+		assert.ErrorContains(t, err, "At statement 1: insert into public.todos default values")
+	})
+}
@@ -168,26 +168,30 @@ func NewBackoffPolicy(ctx context.Context, timeout time.Duration) backoff.BackOf
 	return backoff.WithContext(policy, ctx)
 }
 
+const healthLogLines = 100
+
 func WaitForHealthyService(ctx context.Context, timeout time.Duration, started ...string) error {
+	// Healthy containers are not probed again
+	unhealthy := started
 	probe := func() error {
 		var errHealth []error
-		var unhealthy []string
-		for _, container := range started {
+		var failed []string
+		for _, container := range unhealthy {
 			if err := status.IsServiceReady(ctx, container); err != nil {
-				unhealthy = append(unhealthy, container)
+				failed = append(failed, container)
 				errHealth = append(errHealth, err)
 			}
 		}
-		started = unhealthy
+		unhealthy = failed
 		return errors.Join(errHealth...)
 	}
 	policy := NewBackoffPolicy(ctx, timeout)
 	err := backoff.Retry(probe, policy)
 	if err != nil && !errors.Is(err, context.Canceled) {
-		// Print container logs for easier debugging
-		for _, containerId := range started {
-			fmt.Fprintln(os.Stderr, containerId, "container logs:")
-			if err := utils.DockerStreamLogsOnce(context.Background(), containerId, os.Stderr, os.Stderr); err != nil {
+		// Print recent logs of only the failing containers for easier debugging
+		for _, containerId := range unhealthy {
+			fmt.Fprintf(os.Stderr, "%s container is unhealthy after %s. Last %d lines of logs:\n", containerId, timeout, healthLogLines)
+			if err := utils.DockerStreamLogsTail(context.Background(), containerId, healthLogLines, os.Stderr, os.Stderr); err != nil {
 				fmt.Fprintln(os.Stderr, err)
 			}
 		}
@@ -338,8 +342,23 @@ func SetupDatabase(ctx context.Context, conn *pgx.Conn, host string, w io.Writer
 		return err
 	}
 	err := migration.SeedGlobals(ctx, []string{utils.CustomRolesPath}, conn, afero.NewIOFS(fsys))
-	if errors.Is(err, os.ErrNotExist) {
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return createExtensions(ctx, conn, w)
+}
+
+func createExtensions(ctx context.Context, conn *pgx.Conn, w io.Writer) error {
+	if len(utils.Config.Db.Extensions) == 0 {
 		return nil
 	}
-	return err
+	fmt.Fprintln(w, "Creating extensions:", strings.Join(utils.Config.Db.Extensions, ", "))
+	// Relocatable extensions are installed to the extensions schema
+	batch := migration.MigrationFile{Statements: []string{"SET search_path = extensions"}}
+	for _, name := range utils.Config.Db.Extensions {
+		sql := fmt.Sprintf("CREATE EXTENSION IF NOT EXISTS %s CASCADE", pgx.Identifier{name}.Sanitize())
+		batch.Statements = append(batch.Statements, sql)
+	}
+	batch.Statements = append(batch.Statements, "RESET search_path")
+	return batch.ExecBatch(ctx, conn)
 }
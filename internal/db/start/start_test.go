@@ -7,10 +7,12 @@ import (
 	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/volume"
 	"github.com/h2non/gock"
+	"github.com/jackc/pgerrcode"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -309,6 +311,50 @@ func TestSetupDatabase(t *testing.T) {
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 }
+func TestCreateExtensions(t *testing.T) {
+	t.Run("creates configured extensions", func(t *testing.T) {
+		utils.Config.Db.Extensions = []string{"postgis", "pg_cron"}
+		defer func() {
+			utils.Config.Db.Extensions = nil
+		}()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("SET search_path = extensions").
+			Reply("SET").
+			Query(`CREATE EXTENSION IF NOT EXISTS "postgis" CASCADE`).
+			Reply("CREATE EXTENSION").
+			Query(`CREATE EXTENSION IF NOT EXISTS "pg_cron" CASCADE`).
+			Reply("CREATE EXTENSION").
+			Query("RESET search_path").
+			Reply("RESET")
+		// Run test
+		err := createExtensions(context.Background(), conn.MockClient(t), io.Discard)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on unavailable extension", func(t *testing.T) {
+		utils.Config.Db.Extensions = []string{"missing"}
+		defer func() {
+			utils.Config.Db.Extensions = nil
+		}()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("SET search_path = extensions").
+			Reply("SET").
+			Query(`CREATE EXTENSION IF NOT EXISTS "missing" CASCADE`).
+			ReplyError(pgerrcode.UndefinedFile, `extension "missing" is not available`).
+			Query("RESET search_path").
+			Reply("RESET")
+		// Run test
+		err := createExtensions(context.Background(), conn.MockClient(t), io.Discard)
+		// Check error
+		assert.ErrorContains(t, err, `ERROR: extension "missing" is not available (SQLSTATE 58P01)`)
+	})
+}
+
 func TestStartDatabaseWithCustomSettings(t *testing.T) {
 	t.Run("starts database with custom MaxConnections", func(t *testing.T) {
 		// Setup
@@ -361,3 +407,61 @@ func TestStartDatabaseWithCustomSettings(t *testing.T) {
 		assert.Contains(t, config.Entrypoint[2], "max_connections = 50")
 	})
 }
+
+func TestWaitForHealthyService(t *testing.T) {
+	t.Run("throws error on crash looping container", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/test-kong/json").
+			Persist().
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{
+				State: &types.ContainerState{
+					Running:    true,
+					Restarting: true,
+					ExitCode:   1,
+				},
+			}})
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v"+utils.Docker.ClientVersion()+"/containers/test-kong/logs").
+			MatchParam("tail", "100").
+			Reply(http.StatusOK)
+		// Run test
+		err := WaitForHealthyService(context.Background(), time.Second, "test-kong")
+		// Check error
+		assert.ErrorContains(t, err, "test-kong container is restarting: exit 1")
+		assert.True(t, IsUnhealthyError(err))
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("prints logs of unhealthy containers only", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/test-rest/json").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{
+				State: &types.ContainerState{Running: true},
+			}})
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/test-kong/json").
+			Persist().
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{
+				State: &types.ContainerState{Running: false, Status: "exited"},
+			}})
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v"+utils.Docker.ClientVersion()+"/containers/test-kong/logs").
+			MatchParam("tail", "100").
+			Reply(http.StatusOK)
+		// Run test
+		err := WaitForHealthyService(context.Background(), time.Second, "test-rest", "test-kong")
+		// Check error
+		assert.ErrorContains(t, err, "test-kong container is not running: exited")
+		assert.NotContains(t, err.Error(), "test-rest")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
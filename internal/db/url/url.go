@@ -0,0 +1,44 @@
+package url
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(config pgconn.Config, projectRef string, pooler bool, format string, w io.Writer) error {
+	if !utils.IsLocalDatabase(config) {
+		var err error
+		if config, err = getLinkedConfig(config, projectRef, pooler); err != nil {
+			return err
+		}
+	}
+	dbUrl := utils.ToPostgresURL(config)
+	if format == utils.OutputPretty {
+		fmt.Fprintln(w, dbUrl)
+		return nil
+	}
+	return utils.EncodeOutput(format, w, map[string]string{"DB_URL": dbUrl})
+}
+
+// Linked config prefers the pooler when configured, so both variants are resolved explicitly.
+func getLinkedConfig(config pgconn.Config, projectRef string, pooler bool) (pgconn.Config, error) {
+	result := pgconn.Config{
+		Host:     utils.GetSupabaseDbHost(projectRef),
+		Port:     5432,
+		User:     "postgres",
+		Database: "postgres",
+	}
+	if pooler {
+		poolerConfig := utils.GetPoolerConfig(projectRef)
+		if poolerConfig == nil {
+			return result, errors.Errorf("Connection pooler is not configured for project %s. Run %s to fetch it.", projectRef, utils.Aqua("supabase link"))
+		}
+		result = *poolerConfig
+	}
+	result.Password = config.Password
+	return result, nil
+}
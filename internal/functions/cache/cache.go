@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/functions/deploy"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/config"
+)
+
+func Run(ctx context.Context, slugs []string, check, analyze bool, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	} else if len(slugs) > 0 {
+		for _, s := range slugs {
+			if err := utils.ValidateFunctionSlug(s); err != nil {
+				return err
+			}
+		}
+	} else if slugs, err = deploy.GetFunctionSlugs(fsys); err != nil {
+		return err
+	}
+	if len(slugs) == 0 {
+		return errors.Errorf("No Functions specified or found in %s", utils.Bold(utils.FunctionsDir))
+	}
+	functionConfig, err := deploy.GetFunctionConfig(slugs, "", nil, fsys)
+	if err != nil {
+		return err
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return errors.Errorf("failed to get working directory: %w", err)
+	}
+	var cached int
+	var failed []string
+	for _, slug := range sortedSlugs(functionConfig) {
+		fc := functionConfig[slug]
+		if !fc.IsEnabled() {
+			fmt.Fprintln(os.Stderr, "Skipped caching Function:", slug)
+			continue
+		}
+		cached++
+		fmt.Fprintln(os.Stderr, "Caching dependencies of Function:", utils.Bold(slug))
+		if analyze {
+			// Keep the bundle on host so that its modules can be reported
+			bundler := deploy.NewSizeReporter(deploy.NewDockerBundler(fsys), true)
+			err = bundler.Bundle(ctx, fc.Entrypoint, fc.ImportMap, io.Discard)
+		} else {
+			err = cacheFunction(ctx, cwd, fc.Entrypoint, fc.ImportMap, false, os.Stderr, fsys)
+		}
+		if err != nil {
+			if check {
+				return err
+			}
+			fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), err)
+			failed = append(failed, slug)
+		}
+	}
+	if len(failed) > 0 {
+		utils.CmdSuggestion = fmt.Sprintf("Run %s to stop at the first unresolved dependency.", utils.Aqua("supabase functions cache --check"))
+		return errors.Errorf("failed to cache %d of %d Functions: %s", len(failed), cached, strings.Join(failed, ", "))
+	}
+	fmt.Println("Cached Function dependencies in docker volume: " + utils.Aqua(utils.DenoCacheVolume))
+	return nil
+}
+
+func sortedSlugs(functionConfig config.FunctionConfig) []string {
+	slugs := make([]string, 0, len(functionConfig))
+	for slug := range functionConfig {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+	return slugs
+}
+
+var remoteModulePattern = regexp.MustCompile(`https?://[^\s"'<>()\[\],]+`)
+
+// Bundles each function without network access, failing with the list of remote
+// modules that are neither vendored nor present in the shared deno cache volume.
+func AssertCached(ctx context.Context, functionConfig config.FunctionConfig, fsys afero.Fs) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return errors.Errorf("failed to get working directory: %w", err)
+	}
+	var missing []string
+	for _, slug := range sortedSlugs(functionConfig) {
+		fc := functionConfig[slug]
+		if !fc.IsEnabled() {
+			continue
+		}
+		var output bytes.Buffer
+		if err := cacheFunction(ctx, cwd, fc.Entrypoint, fc.ImportMap, true, &output, fsys); err != nil {
+			modules := remoteModulePattern.FindAllString(output.String(), -1)
+			if len(modules) == 0 {
+				fmt.Fprint(os.Stderr, output.String())
+				return errors.Errorf("failed to resolve dependencies of %s: %w", slug, err)
+			}
+			missing = append(missing, modules...)
+		}
+	}
+	if len(missing) > 0 {
+		missing = utils.RemoveDuplicates(missing)
+		sort.Strings(missing)
+		utils.CmdSuggestion = fmt.Sprintf("Run %s while online, or vendor them with %s.", utils.Aqua("supabase functions cache"), utils.Aqua("deno vendor"))
+		return errors.Errorf("Missing modules in cache:\n  %s", strings.Join(missing, "\n  "))
+	}
+	return nil
+}
+
+// Bundling an eszip downloads all remote modules into the shared deno cache volume.
+func cacheFunction(ctx context.Context, cwd, entrypoint, importMap string, offline bool, stderr io.Writer, fsys afero.Fs) error {
+	binds, err := deploy.GetBindMounts(cwd, utils.FunctionsDir, "", entrypoint, importMap, fsys)
+	if err != nil {
+		return err
+	}
+	cmd := []string{"bundle", "--entrypoint", utils.ToDockerPath(entrypoint), "--output", "/tmp/output.eszip"}
+	if len(importMap) > 0 {
+		cmd = append(cmd, "--import-map", utils.ToDockerPath(importMap))
+	}
+	stdout := io.Discard
+	if viper.GetBool("DEBUG") {
+		cmd = append(cmd, "--verbose")
+		stdout = os.Stderr
+	}
+	hostConfig := container.HostConfig{Binds: binds}
+	if offline {
+		hostConfig.NetworkMode = network.NetworkNone
+		stdout = stderr
+	}
+	return utils.DockerRunOnceWithConfig(
+		ctx,
+		container.Config{
+			Image:      utils.Config.EdgeRuntime.Image,
+			Cmd:        cmd,
+			WorkingDir: utils.ToDockerPath(cwd),
+		},
+		hostConfig,
+		network.NetworkingConfig{},
+		"",
+		stdout,
+		stderr,
+	)
+}
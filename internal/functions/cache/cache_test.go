@@ -0,0 +1,136 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/config"
+)
+
+func TestCacheCommand(t *testing.T) {
+	const slug = "test-func"
+	const containerId = "test-container"
+	imageUrl := utils.GetRegistryImageUrl(utils.Config.EdgeRuntime.Image)
+
+	t.Run("caches function dependencies", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		entrypoint := filepath.Join(utils.FunctionsDir, slug, "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte{}, 0644))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
+		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "bundled"))
+		// Run test
+		err := Run(context.Background(), nil, true, false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on resolution failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
+		require.NoError(t, apitest.MockDockerLogsExitCode(utils.Docker, containerId, 1))
+		// Run test
+		err := Run(context.Background(), []string{slug}, false, false, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to cache 1 of 1 Functions: "+slug)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on resolution failure with check", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
+		require.NoError(t, apitest.MockDockerLogsExitCode(utils.Docker, containerId, 1))
+		// Run test
+		err := Run(context.Background(), []string{slug}, true, false, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "error running container: exit 1")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing functions", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Run test
+		err := Run(context.Background(), nil, false, false, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "No Functions specified or found in")
+	})
+}
+
+func TestAssertCached(t *testing.T) {
+	const slug = "test-func"
+	const containerId = "test-container"
+	imageUrl := utils.GetRegistryImageUrl(utils.Config.EdgeRuntime.Image)
+	functionConfig := config.FunctionConfig{slug: {
+		Entrypoint: filepath.Join(utils.FunctionsDir, slug, "index.ts"),
+	}}
+
+	t.Run("passes with cached dependencies", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
+		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "bundled"))
+		// Run test
+		err := AssertCached(context.Background(), functionConfig, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("lists missing modules", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
+		var body bytes.Buffer
+		_, err := stdcopy.NewStdWriter(&body, stdcopy.Stderr).Write([]byte(
+			"error: Import 'https://deno.land/std/http/server.ts' failed: error sending request for url (https://deno.land/std/http/server.ts)\n",
+		))
+		require.NoError(t, err)
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v"+utils.Docker.ClientVersion()+"/containers/"+containerId+"/logs").
+			Reply(http.StatusOK).
+			SetHeader("Content-Type", "application/vnd.docker.raw-stream").
+			Body(&body)
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/" + containerId + "/json").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSONBase{State: &types.ContainerState{ExitCode: 1}})
+		gock.New(utils.Docker.DaemonHost()).
+			Delete("/v" + utils.Docker.ClientVersion() + "/containers/" + containerId).
+			Reply(http.StatusOK)
+		// Run test
+		err = AssertCached(context.Background(), functionConfig, afero.NewMemMapFs())
+		// Check error
+		assert.EqualError(t, err, "Missing modules in cache:\n  https://deno.land/std/http/server.ts")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
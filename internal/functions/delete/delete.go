@@ -10,6 +10,16 @@ import (
 	"github.com/supabase/cli/internal/utils"
 )
 
+func PreRun(ctx context.Context, slug string, projectRef string) error {
+	title := fmt.Sprintf("Do you want to delete Function %s from project %s?", utils.Aqua(slug), utils.Aqua(projectRef))
+	if shouldDelete, err := utils.NewConsole().PromptYesNo(ctx, title, false); err != nil {
+		return err
+	} else if !shouldDelete {
+		return errors.New(context.Canceled)
+	}
+	return nil
+}
+
 func Run(ctx context.Context, slug string, projectRef string, fsys afero.Fs) error {
 	// 1. Sanity checks.
 	{
@@ -92,3 +92,12 @@ func TestDeleteCommand(t *testing.T) {
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
 }
+
+func TestDeletePreRun(t *testing.T) {
+	t.Run("cancels without confirmation", func(t *testing.T) {
+		// Run test
+		err := PreRun(context.Background(), "test-func", apitest.RandomProjectRef())
+		// Check error
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
@@ -0,0 +1,122 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/docker/go-units"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/function"
+)
+
+// Number of modules listed in the size report without --analyze.
+const topModules = 3
+
+type bundleReport struct {
+	size    int
+	modules []function.ModuleSize
+}
+
+// Wraps a bundler to record the size of each bundle and its largest modules.
+type SizeReporter struct {
+	function.EszipBundler
+	analyze bool
+	mu      sync.Mutex
+	reports map[string]bundleReport
+}
+
+func NewSizeReporter(bundler function.EszipBundler, analyze bool) *SizeReporter {
+	return &SizeReporter{EszipBundler: bundler, analyze: analyze, reports: map[string]bundleReport{}}
+}
+
+func (b *SizeReporter) Bundle(ctx context.Context, entrypoint string, importMap string, output io.Writer) error {
+	var body bytes.Buffer
+	if err := b.EszipBundler.Bundle(ctx, entrypoint, importMap, io.MultiWriter(output, &body)); err != nil {
+		return err
+	}
+	slug := filepath.Base(filepath.Dir(entrypoint))
+	report := bundleReport{size: body.Len()}
+	modules, err := function.AnalyzeEszip(&body)
+	if err != nil {
+		fmt.Fprintln(utils.GetDebugLogger(), err)
+	}
+	report.modules = modules
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.reports[slug] = report
+	return nil
+}
+
+// Prints the recorded reports in order of slug. Call this after all bundles are
+// done so that output of parallel deploys is not interleaved.
+func (b *SizeReporter) Print() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	slugs := make([]string, 0, len(b.reports))
+	for slug := range b.reports {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+	for _, slug := range slugs {
+		r := b.reports[slug]
+		if !b.analyze {
+			fmt.Fprint(os.Stderr, formatSizeReport(slug, r.size, r.modules, topModules))
+			continue
+		}
+		fmt.Fprint(os.Stderr, formatSizeReport(slug, r.size, r.modules, 0))
+		if len(r.modules) == 0 {
+			continue
+		}
+		if err := list.RenderTable(formatModuleTable(r.modules)); err != nil {
+			return err
+		}
+	}
+	clear(b.reports)
+	return nil
+}
+
+func formatSizeReport(slug string, size int, modules []function.ModuleSize, top int) string {
+	var sourceSize int
+	for _, m := range modules {
+		sourceSize += m.Size
+	}
+	var report strings.Builder
+	fmt.Fprintf(&report, "Bundle size of %s: %s", utils.Bold(slug), units.HumanSize(float64(size)))
+	if sourceSize > 0 {
+		fmt.Fprintf(&report, " (%s sources in %d modules)", units.HumanSize(float64(sourceSize)), len(modules))
+	}
+	fmt.Fprintln(&report)
+	if percent := size * 100 / function.MaxBundleSize; percent >= 80 {
+		fmt.Fprintf(&report, "%s Bundle of %s is %d%% of the %s limit.\n", utils.Yellow("WARNING:"), slug, percent, units.HumanSize(function.MaxBundleSize))
+	}
+	if top = min(top, len(modules)); top > 0 {
+		fmt.Fprintln(&report, "Largest modules:")
+		for _, m := range modules[:top] {
+			fmt.Fprintf(&report, "  %8s  %s\n", units.HumanSize(float64(m.Size)), m.Specifier)
+		}
+	}
+	return report.String()
+}
+
+func formatModuleTable(modules []function.ModuleSize) string {
+	var total int
+	for _, m := range modules {
+		total += m.Size
+	}
+	table := `|MODULE|SIZE|SHARE|
+|-|-|-|
+`
+	for _, m := range modules {
+		share := float64(m.Size) * 100 / float64(max(total, 1))
+		table += fmt.Sprintf("|`%s`|`%s`|`%.1f%%`|\n", m.Specifier, units.HumanSize(float64(m.Size)), share)
+	}
+	return table
+}
@@ -0,0 +1,118 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/go-errors/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/pkg/function"
+)
+
+type mockBundler struct {
+	body []byte
+	err  error
+}
+
+func (b *mockBundler) Bundle(ctx context.Context, entrypoint string, importMap string, output io.Writer) error {
+	if b.err != nil {
+		return b.err
+	}
+	_, err := output.Write(b.body)
+	return err
+}
+
+func mockEszip(t *testing.T, specifier string, size uint32) []byte {
+	var header bytes.Buffer
+	require.NoError(t, binary.Write(&header, binary.BigEndian, uint32(len(specifier))))
+	header.WriteString(specifier)
+	header.WriteByte(0)
+	require.NoError(t, binary.Write(&header, binary.BigEndian, [4]uint32{0, size, size, 0}))
+	header.WriteByte(0)
+	var eszip bytes.Buffer
+	eszip.WriteString("ESZIP_V2")
+	require.NoError(t, binary.Write(&eszip, binary.BigEndian, uint32(header.Len())))
+	eszip.Write(header.Bytes())
+	eszip.Write(make([]byte, 32))
+	var body bytes.Buffer
+	require.NoError(t, function.Compress(&eszip, &body))
+	return body.Bytes()
+}
+
+func TestSizeReporter(t *testing.T) {
+	t.Run("passes through bundle output", func(t *testing.T) {
+		body := mockEszip(t, "file:///src/index.ts", 1024)
+		bundler := NewSizeReporter(&mockBundler{body: body}, true)
+		// Run test
+		var output bytes.Buffer
+		err := bundler.Bundle(context.Background(), "supabase/functions/hello/index.ts", "", &output)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, body, output.Bytes())
+	})
+
+	t.Run("defers reports until printed", func(t *testing.T) {
+		body := mockEszip(t, "file:///src/index.ts", 1024)
+		bundler := NewSizeReporter(&mockBundler{body: body}, true)
+		// Run test
+		for _, slug := range []string{"world", "hello"} {
+			err := bundler.Bundle(context.Background(), "supabase/functions/"+slug+"/index.ts", "", io.Discard)
+			assert.NoError(t, err)
+		}
+		// Check reports
+		assert.Len(t, bundler.reports, 2)
+		assert.Equal(t, bundleReport{size: len(body), modules: []function.ModuleSize{{Specifier: "file:///src/index.ts", Size: 1024}}}, bundler.reports["hello"])
+		assert.NoError(t, bundler.Print())
+		assert.Empty(t, bundler.reports)
+	})
+
+	t.Run("ignores unknown bundle format", func(t *testing.T) {
+		bundler := NewSizeReporter(&mockBundler{body: []byte("legacy")}, false)
+		// Run test
+		var output bytes.Buffer
+		err := bundler.Bundle(context.Background(), "supabase/functions/hello/index.ts", "", &output)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "legacy", output.String())
+	})
+
+	t.Run("throws error on bundle failure", func(t *testing.T) {
+		errBundle := errors.New("bundle failed")
+		bundler := NewSizeReporter(&mockBundler{err: errBundle}, false)
+		// Run test
+		err := bundler.Bundle(context.Background(), "supabase/functions/hello/index.ts", "", io.Discard)
+		// Check error
+		assert.ErrorIs(t, err, errBundle)
+	})
+}
+
+func TestFormatSizeReport(t *testing.T) {
+	modules := []function.ModuleSize{
+		{Specifier: "npm:zod", Size: 3000},
+		{Specifier: "file:///src/index.ts", Size: 1000},
+	}
+
+	t.Run("lists largest modules", func(t *testing.T) {
+		report := formatSizeReport("hello", 2000, modules, 1)
+		assert.Contains(t, report, "2kB (4kB sources in 2 modules)")
+		assert.Contains(t, report, "npm:zod")
+		assert.NotContains(t, report, "index.ts")
+		assert.NotContains(t, report, "WARNING")
+	})
+
+	t.Run("warns when approaching limit", func(t *testing.T) {
+		report := formatSizeReport("hello", function.MaxBundleSize*9/10, nil, 0)
+		assert.Contains(t, report, "Bundle of hello is 90% of the 20MB limit.")
+		assert.NotContains(t, report, "Largest modules")
+	})
+
+	t.Run("formats module shares", func(t *testing.T) {
+		table := formatModuleTable(modules)
+		assert.Contains(t, table, "|`npm:zod`|`3kB`|`75.0%`|")
+		assert.Contains(t, table, "|`file:///src/index.ts`|`1kB`|`25.0%`|")
+	})
+}
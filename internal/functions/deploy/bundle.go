@@ -34,9 +34,16 @@ func (b *dockerBundler) Bundle(ctx context.Context, entrypoint string, importMap
 		return errors.Errorf("failed to get working directory: %w", err)
 	}
 	// BitBucket pipelines require docker bind mounts to be world writable
-	hostOutputDir := filepath.Join(utils.TempDir, fmt.Sprintf(".output_%s", slug))
-	if err := b.fsys.MkdirAll(hostOutputDir, 0777); err != nil {
-		return errors.Errorf("failed to mkdir: %w", err)
+	if err := utils.MkdirIfNotExistFS(b.fsys, utils.TempDir); err != nil {
+		return err
+	}
+	// Unique per call so that parallel bundles never share an output directory
+	hostOutputDir, err := afero.TempDir(b.fsys, utils.TempDir, fmt.Sprintf(".output_%s_", slug))
+	if err != nil {
+		return errors.Errorf("failed to create output dir: %w", err)
+	}
+	if err := b.fsys.Chmod(hostOutputDir, 0777); err != nil {
+		return errors.Errorf("failed to chmod output dir: %w", err)
 	}
 	defer func() {
 		if err := b.fsys.RemoveAll(hostOutputDir); err != nil {
@@ -99,7 +106,7 @@ func GetBindMounts(cwd, hostFuncDir, hostOutputDir, hostEntrypointPath, hostImpo
 	binds := []string{
 		// Reuse deno cache directory, ie. DENO_DIR, between container restarts
 		// https://denolib.gitbook.io/guide/advanced/deno_dir-code-fetch-and-cache
-		utils.EdgeRuntimeId + ":/root/.cache/deno:rw",
+		utils.DenoCacheVolume + ":/root/.cache/deno:rw",
 		hostFuncDir + ":" + dockerFuncDir + ":ro",
 	}
 	if len(hostOutputDir) > 0 {
@@ -5,6 +5,8 @@ import (
 	"bytes"
 	"context"
 	"net/http"
+	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/h2non/gock"
@@ -48,4 +50,52 @@ func TestDockerBundle(t *testing.T) {
 		assert.ErrorContains(t, err, "error running container: exit 1")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
+
+	t.Run("bundles into separate output dirs", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := mockBundleOutput(afero.NewMemMapFs())
+		entrypoint := filepath.Join(utils.FunctionsDir, "hello", "index.ts")
+		// Setup mock docker
+		for range 2 {
+			require.NoError(t, apitest.MockDocker(utils.Docker))
+			apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
+			require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "bundled"))
+		}
+		defer gock.OffAll()
+		// Run test
+		for range 2 {
+			var output bytes.Buffer
+			assert.NoError(t, NewDockerBundler(fsys).Bundle(context.Background(), entrypoint, "", &output))
+		}
+		// Check error
+		require.Len(t, fsys.dirs, 2)
+		assert.NotEqual(t, fsys.dirs[0], fsys.dirs[1])
+		exists, err := afero.DirExists(fsys, fsys.dirs[0])
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+// Simulates the edge runtime writing eszip to its bind mounted output dir.
+type bundleOutputFs struct {
+	afero.Fs
+	mu   sync.Mutex
+	dirs []string
+}
+
+func (f *bundleOutputFs) Open(name string) (afero.File, error) {
+	if filepath.Base(name) == "output.eszip" {
+		f.mu.Lock()
+		f.dirs = append(f.dirs, filepath.Dir(name))
+		f.mu.Unlock()
+		if err := afero.WriteFile(f.Fs, name, []byte{}, 0644); err != nil {
+			return nil, err
+		}
+	}
+	return f.Fs.Open(name)
+}
+
+func mockBundleOutput(fsys afero.Fs) *bundleOutputFs {
+	return &bundleOutputFs{Fs: fsys}
 }
@@ -7,15 +7,17 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/docker/go-units"
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/cast"
 	"github.com/supabase/cli/pkg/config"
 	"github.com/supabase/cli/pkg/function"
 )
 
-func Run(ctx context.Context, slugs []string, projectRef string, noVerifyJWT *bool, importMapPath string, fsys afero.Fs) error {
+func Run(ctx context.Context, slugs []string, projectRef string, noVerifyJWT *bool, importMapPath string, checkRemote, analyze bool, fsys afero.Fs) error {
 	// Load function config and project id
 	if err := utils.LoadConfigFS(fsys); err != nil {
 		return err
@@ -25,7 +27,7 @@ func Run(ctx context.Context, slugs []string, projectRef string, noVerifyJWT *bo
 				return err
 			}
 		}
-	} else if slugs, err = GetFunctionSlugs(fsys); err != nil {
+	} else if slugs, err = getDeploySlugs(fsys); err != nil {
 		return err
 	}
 	// TODO: require all functions to be deployed from config for v2
@@ -36,8 +38,19 @@ func Run(ctx context.Context, slugs []string, projectRef string, noVerifyJWT *bo
 	if err != nil {
 		return err
 	}
-	api := function.NewEdgeRuntimeAPI(projectRef, *utils.GetSupabase(), NewDockerBundler(fsys))
-	if err := api.UpsertFunctions(ctx, functionConfig); err != nil {
+	if err := ValidateImportMaps(ctx, functionConfig, checkRemote, fsys); err != nil {
+		return err
+	}
+	reporter := NewSizeReporter(NewDockerBundler(fsys), analyze)
+	api := function.NewEdgeRuntimeAPI(projectRef, *utils.GetSupabase(), reporter)
+	err = api.UpsertFunctions(ctx, functionConfig)
+	if err := reporter.Print(); err != nil {
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if err := syncSchedules(ctx, projectRef, functionConfig); err != nil {
 		return err
 	}
 	fmt.Printf("Deployed Functions on project %s: %s\n", utils.Aqua(projectRef), strings.Join(slugs, ", "))
@@ -46,6 +59,116 @@ func Run(ctx context.Context, slugs []string, projectRef string, noVerifyJWT *bo
 	return nil
 }
 
+// Deploys every function in the project concurrently, printing a summary of results.
+func RunAll(ctx context.Context, projectRef string, noVerifyJWT *bool, importMapPath string, checkRemote, analyze bool, maxJobs uint, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	slugs, err := getDeploySlugs(fsys)
+	if err != nil {
+		return err
+	}
+	if len(slugs) == 0 {
+		return errors.Errorf("No Functions specified or found in %s", utils.Bold(utils.FunctionsDir))
+	}
+	functionConfig, err := GetFunctionConfig(slugs, importMapPath, noVerifyJWT, fsys)
+	if err != nil {
+		return err
+	}
+	if err := ValidateImportMaps(ctx, functionConfig, checkRemote, fsys); err != nil {
+		return err
+	}
+	reporter := NewSizeReporter(NewDockerBundler(fsys), analyze)
+	api := function.NewEdgeRuntimeAPI(projectRef, *utils.GetSupabase(), reporter)
+	results, err := api.UpsertFunctionsParallel(ctx, functionConfig, maxJobs)
+	if err := reporter.Print(); err != nil {
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	table := `|FUNCTION|STATUS|SIZE|ERROR|
+|-|-|-|-|
+`
+	var failed []string
+	for _, r := range results {
+		status, size, reason := "DEPLOYED", units.HumanSize(float64(r.Size)), ""
+		if r.Err != nil {
+			failed = append(failed, r.Slug)
+			status, size = "FAILED", "-"
+			reason = strings.ReplaceAll(r.Err.Error(), "\n", " ")
+		}
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|%s|\n", r.Slug, status, size, reason)
+	}
+	if err := list.RenderTable(table); err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("failed to deploy %d of %d Functions: %s", len(failed), len(results), strings.Join(failed, ", "))
+	}
+	if err := syncSchedules(ctx, projectRef, functionConfig); err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/project/%v/functions", utils.GetSupabaseDashboardURL(), projectRef)
+	fmt.Println("You can inspect your deployment in the Dashboard: " + url)
+	return nil
+}
+
+// Prints which functions would be created or updated without uploading.
+func RunDryRun(ctx context.Context, slugs []string, projectRef string, noVerifyJWT *bool, importMapPath string, checkRemote, analyze bool, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	} else if len(slugs) > 0 {
+		for _, s := range slugs {
+			if err := utils.ValidateFunctionSlug(s); err != nil {
+				return err
+			}
+		}
+	} else if slugs, err = getDeploySlugs(fsys); err != nil {
+		return err
+	}
+	if len(slugs) == 0 {
+		return errors.Errorf("No Functions specified or found in %s", utils.Bold(utils.FunctionsDir))
+	}
+	functionConfig, err := GetFunctionConfig(slugs, importMapPath, noVerifyJWT, fsys)
+	if err != nil {
+		return err
+	}
+	if err := ValidateImportMaps(ctx, functionConfig, checkRemote, fsys); err != nil {
+		return err
+	}
+	reporter := NewSizeReporter(NewDockerBundler(fsys), analyze)
+	api := function.NewEdgeRuntimeAPI(projectRef, *utils.GetSupabase(), reporter)
+	results, err := api.PlanFunctions(ctx, functionConfig)
+	if err := reporter.Print(); err != nil {
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	table := `|FUNCTION|ACTION|SIZE|CHANGES|
+|-|-|-|-|
+`
+	var failed int
+	for _, r := range results {
+		action, size, changes := string(r.Action), units.HumanSize(float64(r.Size)), strings.Join(r.Changes, ", ")
+		if r.Err != nil {
+			failed++
+			action, size = "FAILED", "-"
+			changes = strings.ReplaceAll(r.Err.Error(), "\n", " ")
+		}
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|%s|\n", r.Slug, action, size, changes)
+	}
+	if err := list.RenderTable(table); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return errors.Errorf("failed to plan %d of %d Functions", failed, len(results))
+	}
+	fmt.Fprintln(os.Stderr, "Dry run complete. No Functions were deployed.")
+	return nil
+}
+
 func GetFunctionSlugs(fsys afero.Fs) (slugs []string, err error) {
 	pattern := filepath.Join(utils.FunctionsDir, "*", "index.ts")
 	paths, err := afero.Glob(fsys, pattern)
@@ -65,6 +188,27 @@ func GetFunctionSlugs(fsys afero.Fs) (slugs []string, err error) {
 	return slugs, nil
 }
 
+// Functions named explicitly on the command line are deployed even if ignored.
+func getDeploySlugs(fsys afero.Fs) ([]string, error) {
+	slugs, err := GetFunctionSlugs(fsys)
+	if err != nil {
+		return nil, err
+	}
+	ignore, err := LoadIgnorePatterns(fsys)
+	if err != nil {
+		return nil, err
+	}
+	var result []string
+	for _, slug := range slugs {
+		if ignore.Match(slug) {
+			fmt.Fprintln(os.Stderr, "Skipping ignored Function:", utils.Bold(slug))
+			continue
+		}
+		result = append(result, slug)
+	}
+	return result, nil
+}
+
 func GetFunctionConfig(slugs []string, importMapPath string, noVerifyJWT *bool, fsys afero.Fs) (config.FunctionConfig, error) {
 	// Although some functions do not require import map, it's more convenient to setup
 	// vscode deno extension with a single import map for all functions.
@@ -82,10 +226,10 @@ func GetFunctionConfig(slugs []string, importMapPath string, noVerifyJWT *bool,
 	for _, name := range slugs {
 		function := utils.Config.Functions[name]
 		// Precedence order: flag > config > fallback
-		functionDir := filepath.Join(utils.FunctionsDir, name)
 		if len(function.Entrypoint) == 0 {
-			function.Entrypoint = filepath.Join(functionDir, "index.ts")
+			function.Entrypoint = filepath.Join(utils.FunctionsDir, name, "index.ts")
 		}
+		functionDir := filepath.Dir(function.Entrypoint)
 		if len(importMapPath) > 0 {
 			function.ImportMap = importMapPath
 		} else if len(function.ImportMap) == 0 {
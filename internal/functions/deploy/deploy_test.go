@@ -55,13 +55,11 @@ func TestDeployCommand(t *testing.T) {
 			require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "bundled"))
 		}
 		// Setup output file
-		for _, v := range functions {
-			outputDir := filepath.Join(utils.TempDir, fmt.Sprintf(".output_%s", v))
-			require.NoError(t, afero.WriteFile(fsys, filepath.Join(outputDir, "output.eszip"), []byte(""), 0644))
-		}
+		fsys = mockBundleOutput(fsys)
+		mockListSchedules(project)
 		// Run test
 		noVerifyJWT := true
-		err = Run(context.Background(), functions, project, &noVerifyJWT, "", fsys)
+		err = Run(context.Background(), functions, project, &noVerifyJWT, "", false, false, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -113,10 +111,10 @@ import_map = "./import_map.json"
 		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
 		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "bundled"))
 		// Setup output file
-		outputDir := filepath.Join(utils.TempDir, fmt.Sprintf(".output_%s", slug))
-		require.NoError(t, afero.WriteFile(fsys, filepath.Join(outputDir, "output.eszip"), []byte(""), 0644))
+		fsys = mockBundleOutput(fsys)
+		mockListSchedules(project)
 		// Run test
-		err = Run(context.Background(), nil, project, nil, "", fsys)
+		err = Run(context.Background(), nil, project, nil, "", false, false, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -165,10 +163,10 @@ import_map = "./import_map.json"
 		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
 		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "bundled"))
 		// Setup output file
-		outputDir := filepath.Join(utils.TempDir, ".output_enabled-func")
-		require.NoError(t, afero.WriteFile(fsys, filepath.Join(outputDir, "output.eszip"), []byte(""), 0644))
+		fsys = mockBundleOutput(fsys)
+		mockListSchedules(project)
 		// Run test
-		err = Run(context.Background(), nil, project, nil, "", fsys)
+		err = Run(context.Background(), nil, project, nil, "", false, false, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -179,7 +177,7 @@ import_map = "./import_map.json"
 		fsys := afero.NewMemMapFs()
 		require.NoError(t, utils.WriteConfig(fsys, false))
 		// Run test
-		err := Run(context.Background(), []string{"_invalid"}, "", nil, "", fsys)
+		err := Run(context.Background(), []string{"_invalid"}, "", nil, "", false, false, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "Invalid Function name.")
 	})
@@ -189,7 +187,7 @@ import_map = "./import_map.json"
 		fsys := afero.NewMemMapFs()
 		require.NoError(t, utils.WriteConfig(fsys, false))
 		// Run test
-		err := Run(context.Background(), nil, "", nil, "", fsys)
+		err := Run(context.Background(), nil, "", nil, "", false, false, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "No Functions specified or found in supabase/functions")
 	})
@@ -231,10 +229,10 @@ verify_jwt = false
 		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
 		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "bundled"))
 		// Setup output file
-		outputDir := filepath.Join(utils.TempDir, fmt.Sprintf(".output_%s", slug))
-		require.NoError(t, afero.WriteFile(fsys, filepath.Join(outputDir, "output.eszip"), []byte(""), 0644))
+		fsys = mockBundleOutput(fsys)
+		mockListSchedules(project)
 		// Run test
-		assert.NoError(t, Run(context.Background(), []string{slug}, project, nil, "", fsys))
+		assert.NoError(t, Run(context.Background(), []string{slug}, project, nil, "", false, false, fsys))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
@@ -276,11 +274,11 @@ verify_jwt = false
 		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
 		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "bundled"))
 		// Setup output file
-		outputDir := filepath.Join(utils.TempDir, fmt.Sprintf(".output_%s", slug))
-		require.NoError(t, afero.WriteFile(fsys, filepath.Join(outputDir, "output.eszip"), []byte(""), 0644))
+		fsys = mockBundleOutput(fsys)
+		mockListSchedules(project)
 		// Run test
 		noVerifyJwt := false
-		assert.NoError(t, Run(context.Background(), []string{slug}, project, &noVerifyJwt, "", fsys))
+		assert.NoError(t, Run(context.Background(), []string{slug}, project, &noVerifyJwt, "", false, false, fsys))
 		// Validate api
 		assert.Empty(t, apitest.ListUnmatchedRequests())
 	})
@@ -344,6 +342,25 @@ func TestImportMapPath(t *testing.T) {
 		assert.Empty(t, fc["test"].ImportMap)
 	})
 
+	t.Run("loads deno.json from function path", func(t *testing.T) {
+		t.Cleanup(func() { clear(utils.Config.Functions) })
+		functionDir := filepath.Join("packages", "edge", "test")
+		utils.Config.Functions = config.FunctionConfig{"test": {
+			Path:       functionDir,
+			Entrypoint: filepath.Join(functionDir, "index.ts"),
+		}}
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		denoJsonPath := filepath.Join(functionDir, "deno.json")
+		require.NoError(t, afero.WriteFile(fsys, denoJsonPath, []byte("{}"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, utils.FallbackImportMapPath, []byte("{}"), 0644))
+		// Run test
+		fc, err := GetFunctionConfig([]string{"test"}, "", nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, denoJsonPath, fc["test"].ImportMap)
+	})
+
 	t.Run("preserves absolute path", func(t *testing.T) {
 		path := "/tmp/import_map.json"
 		// Setup in-memory fs
@@ -356,3 +373,149 @@ func TestImportMapPath(t *testing.T) {
 		assert.Equal(t, path, fc["test"].ImportMap)
 	})
 }
+
+func TestDeployAll(t *testing.T) {
+	const slug = "test-func"
+	const containerId = "test-container"
+	imageUrl := utils.GetRegistryImageUrl(utils.Config.EdgeRuntime.Image)
+
+	t.Run("deploys all functions", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		entrypoint := filepath.Join(utils.FunctionsDir, slug, "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte{}, 0644))
+		fsys = mockBundleOutput(fsys)
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{{Slug: slug}})
+		gock.New(utils.DefaultApiHost).
+			Patch("/v1/projects/" + project + "/functions/" + slug).
+			Reply(http.StatusOK).
+			JSON(api.FunctionResponse{Id: "1"})
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
+		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "bundled"))
+		mockListSchedules(project)
+		// Run test
+		err := RunAll(context.Background(), project, nil, "", false, false, 1, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("reports failed functions", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		entrypoint := filepath.Join(utils.FunctionsDir, slug, "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte{}, 0644))
+		fsys = mockBundleOutput(fsys)
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{{Slug: slug}})
+		gock.New(utils.DefaultApiHost).
+			Patch("/v1/projects/" + project + "/functions/" + slug).
+			Reply(http.StatusBadRequest).
+			JSON(map[string]string{"message": "invalid bundle"})
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
+		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "bundled"))
+		// Run test
+		err := RunAll(context.Background(), project, nil, "", false, false, 1, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to deploy 1 of 1 Functions: "+slug)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing functions", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		err := RunAll(context.Background(), apitest.RandomProjectRef(), nil, "", false, false, 1, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "No Functions specified or found in")
+	})
+}
+
+func TestDeployDryRun(t *testing.T) {
+	const slug = "test-func"
+	const containerId = "test-container"
+	imageUrl := utils.GetRegistryImageUrl(utils.Config.EdgeRuntime.Image)
+
+	t.Run("plans without uploading", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		entrypoint := filepath.Join(utils.FunctionsDir, slug, "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte{}, 0644))
+		fsys = mockBundleOutput(fsys)
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{})
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
+		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "bundled"))
+		// Run test
+		err := RunDryRun(context.Background(), nil, project, nil, "", false, false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on bundle failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		entrypoint := filepath.Join(utils.FunctionsDir, slug, "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte{}, 0644))
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{{Slug: slug}})
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
+		require.NoError(t, apitest.MockDockerLogsExitCode(utils.Docker, containerId, 1))
+		// Run test
+		err := RunDryRun(context.Background(), nil, project, nil, "", false, false, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to plan 1 of 1 Functions")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
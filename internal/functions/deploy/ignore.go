@@ -0,0 +1,66 @@
+package deploy
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Gitignore style patterns, relative to the functions directory, of local
+// helpers and fixtures that should not be deployed.
+var IgnoreFilePath = filepath.Join(utils.FunctionsDir, ".supabaseignore")
+
+type IgnorePatterns []string
+
+func LoadIgnorePatterns(fsys afero.Fs) (IgnorePatterns, error) {
+	data, err := afero.ReadFile(fsys, IgnoreFilePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Errorf("failed to read ignore file: %w", err)
+	}
+	var patterns IgnorePatterns
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		pattern := strings.Trim(line, "/")
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errors.Errorf("invalid pattern %s in %s: %w", line, IgnoreFilePath, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// Matches a slash separated path relative to the functions directory. Patterns
+// without a slash match any path component, like .gitignore.
+func (p IgnorePatterns) Match(relPath string) bool {
+	relPath = filepath.ToSlash(relPath)
+	parts := strings.Split(relPath, "/")
+	for _, pattern := range p {
+		if strings.Contains(pattern, "/") {
+			for i := len(parts); i > 0; i-- {
+				if ok, _ := path.Match(pattern, strings.Join(parts[:i], "/")); ok {
+					return true
+				}
+			}
+			continue
+		}
+		for _, part := range parts {
+			if ok, _ := path.Match(pattern, part); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
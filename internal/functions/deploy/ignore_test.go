@@ -0,0 +1,66 @@
+package deploy
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestIgnorePatterns(t *testing.T) {
+	t.Run("matches path components", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, IgnoreFilePath, []byte(`
+# local only helpers
+scratch-*
+tests/
+hello/fixtures
+`), 0644))
+		// Run test
+		patterns, err := LoadIgnorePatterns(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.True(t, patterns.Match("scratch-pad"))
+		assert.True(t, patterns.Match("hello/tests/index.test.ts"))
+		assert.True(t, patterns.Match("hello/fixtures/data.json"))
+		assert.False(t, patterns.Match("hello"))
+		assert.False(t, patterns.Match("world/fixtures"))
+	})
+
+	t.Run("ignores missing file", func(t *testing.T) {
+		// Run test
+		patterns, err := LoadIgnorePatterns(afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.False(t, patterns.Match("hello"))
+	})
+
+	t.Run("throws error on malformed pattern", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, IgnoreFilePath, []byte("[a-"), 0644))
+		// Run test
+		_, err := LoadIgnorePatterns(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "invalid pattern [a-")
+	})
+
+	t.Run("skips ignored functions on deploy", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		entrypoint := filepath.Join(utils.FunctionsDir, "scratch-pad", "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte{}, 0644))
+		require.NoError(t, afero.WriteFile(fsys, IgnoreFilePath, []byte("scratch-*"), 0644))
+		// Run test
+		err := RunAll(context.Background(), apitest.RandomProjectRef(), nil, "", false, false, 1, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "No Functions specified or found in")
+	})
+}
@@ -0,0 +1,142 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/config"
+)
+
+var (
+	jsrSpecifierPattern = regexp.MustCompile(`^jsr:/?(@[a-z0-9-_]+/[a-z0-9-_]+)(@[^/]+)?(/.*)?$`)
+	npmSpecifierPattern = regexp.MustCompile(`^npm:/?((?:@[a-z0-9-~][a-z0-9-._~]*/)?[a-z0-9-~][a-z0-9-._~]*)(@[^/]+)?(/.*)?$`)
+
+	remoteClient = &http.Client{Timeout: 10 * time.Second}
+)
+
+// Checks every specifier in the import maps of functions being deployed, so
+// that a broken mapping fails the deploy instead of the function cold start.
+func ValidateImportMaps(ctx context.Context, functionConfig config.FunctionConfig, checkRemote bool, fsys afero.Fs) error {
+	if checkRemote {
+		fmt.Fprintln(os.Stderr, "Checking remote modules in import maps...")
+	}
+	checked := map[string]struct{}{}
+	var failures []string
+	for _, function := range functionConfig {
+		if len(function.ImportMap) == 0 || !function.IsEnabled() {
+			continue
+		}
+		if _, ok := checked[function.ImportMap]; ok {
+			continue
+		}
+		checked[function.ImportMap] = struct{}{}
+		// Local modules are resolved relative to the absolute import map path
+		importMapPath, err := filepath.Abs(function.ImportMap)
+		if err != nil {
+			return errors.Errorf("failed to resolve import map path: %w", err)
+		}
+		importMap, err := utils.NewImportMap(importMapPath, fsys)
+		if err != nil {
+			return err
+		}
+		check := func(label, key, value string) {
+			if err := validateSpecifier(ctx, key, value, checkRemote, fsys); err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %s: %s", function.ImportMap, label, err.Error()))
+			}
+		}
+		for key, value := range importMap.Imports {
+			check(key, key, value)
+		}
+		for scope, mapping := range importMap.Scopes {
+			for key, value := range mapping {
+				check(scope+" "+key, key, value)
+			}
+		}
+	}
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		return errors.Errorf("Invalid import map entries:\n%s", strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+func validateSpecifier(ctx context.Context, key, value string, checkRemote bool, fsys afero.Fs) error {
+	if strings.HasSuffix(key, "/") && !strings.HasSuffix(value, "/") {
+		return errors.Errorf("address %s must end with / to match key %s", value, key)
+	}
+	if filepath.IsAbs(value) {
+		if _, err := fsys.Stat(value); err != nil {
+			return errors.Errorf("failed to read local module: %w", err)
+		}
+		return nil
+	}
+	// Unresolved relative paths do not exist on disk
+	if strings.HasPrefix(value, "./") || strings.HasPrefix(value, "../") {
+		return errors.Errorf("local module not found: %s", value)
+	}
+	var remoteUrl string
+	switch {
+	case strings.HasPrefix(value, "jsr:"):
+		matches := jsrSpecifierPattern.FindStringSubmatch(value)
+		if len(matches) == 0 {
+			return errors.Errorf("malformed jsr specifier: %s", value)
+		}
+		remoteUrl = "https://jsr.io/" + matches[1] + "/meta.json"
+	case strings.HasPrefix(value, "npm:"):
+		matches := npmSpecifierPattern.FindStringSubmatch(value)
+		if len(matches) == 0 {
+			return errors.Errorf("malformed npm specifier: %s", value)
+		}
+		remoteUrl = "https://registry.npmjs.org/" + strings.Replace(matches[1], "/", "%2F", 1)
+	case strings.HasPrefix(value, "node:"):
+		return nil
+	default:
+		parsed, err := url.Parse(value)
+		if err != nil {
+			return errors.Errorf("malformed url: %w", err)
+		}
+		switch parsed.Scheme {
+		case "http", "https":
+			remoteUrl = value
+		case "data", "file":
+			return nil
+		default:
+			return errors.Errorf("unsupported specifier: %s", value)
+		}
+	}
+	if checkRemote {
+		return checkRemoteModule(ctx, remoteUrl)
+	}
+	return nil
+}
+
+func checkRemoteModule(ctx context.Context, remoteUrl string) error {
+	// Directory mappings cannot be fetched directly
+	if strings.HasSuffix(remoteUrl, "/") {
+		return nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, remoteUrl, nil)
+	if err != nil {
+		return errors.Errorf("failed to create request: %w", err)
+	}
+	resp, err := remoteClient.Do(req)
+	if err != nil {
+		return errors.Errorf("failed to fetch remote module: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("remote module returned status %d: %s", resp.StatusCode, remoteUrl)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package deploy
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/config"
+)
+
+func TestValidateImportMaps(t *testing.T) {
+	importMapPath, err := filepath.Abs(filepath.Join(utils.FunctionsDir, "hello", "deno.json"))
+	require.NoError(t, err)
+	functionConfig := config.FunctionConfig{"hello": {ImportMap: importMapPath}}
+
+	t.Run("accepts valid specifiers", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		sharedPath := filepath.Join(filepath.Dir(importMapPath), "..", "_shared", "cors.ts")
+		require.NoError(t, afero.WriteFile(fsys, sharedPath, []byte{}, 0644))
+		require.NoError(t, afero.WriteFile(fsys, importMapPath, []byte(`{
+  "imports": {
+    "@std/assert": "jsr:@std/assert@1",
+    "stripe": "npm:stripe@^17",
+    "@supabase/supabase-js": "npm:@supabase/supabase-js@2/dist",
+    "std/": "https://deno.land/std@0.224.0/",
+    "cors": "../_shared/cors.ts",
+    "fs": "node:fs"
+  }
+}`), 0644))
+		// Run test
+		err := ValidateImportMaps(context.Background(), functionConfig, false, fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on invalid specifiers", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, importMapPath, []byte(`{
+  "imports": {
+    "missing": "./missing.ts",
+    "std/": "https://deno.land/std@0.224.0",
+    "bad-jsr": "jsr:assert",
+    "ftp": "ftp://example.com/mod.ts"
+  },
+  "scopes": {
+    "https://deno.land/": {
+      "bad-npm": "npm:"
+    }
+  }
+}`), 0644))
+		// Run test
+		err := ValidateImportMaps(context.Background(), functionConfig, false, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "missing: local module not found: ./missing.ts")
+		assert.ErrorContains(t, err, "std/: address https://deno.land/std@0.224.0 must end with /")
+		assert.ErrorContains(t, err, "bad-jsr: malformed jsr specifier: jsr:assert")
+		assert.ErrorContains(t, err, "ftp: unsupported specifier")
+		assert.ErrorContains(t, err, "https://deno.land/ bad-npm: malformed npm specifier")
+	})
+
+	t.Run("checks remote modules", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, importMapPath, []byte(`{
+  "imports": {
+    "@std/assert": "jsr:@std/assert@1",
+    "@supabase/supabase-js": "npm:@supabase/supabase-js@2",
+    "missing": "https://deno.land/x/missing/mod.ts"
+  }
+}`), 0644))
+		// Setup mock registries
+		defer gock.OffAll()
+		gock.New("https://jsr.io").
+			Get("/@std/assert/meta.json").
+			Reply(http.StatusOK)
+		gock.New("https://registry.npmjs.org").
+			Get("/@supabase/supabase-js").
+			Reply(http.StatusOK)
+		gock.New("https://deno.land").
+			Get("/x/missing/mod.ts").
+			Reply(http.StatusNotFound)
+		// Run test
+		err := ValidateImportMaps(context.Background(), functionConfig, true, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "remote module returned status 404")
+		assert.NotContains(t, err.Error(), "@std/assert")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
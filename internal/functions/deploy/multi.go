@@ -0,0 +1,174 @@
+package deploy
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/function"
+)
+
+// Reads project refs from a file with one ref per line. Blank lines and lines
+// starting with # are skipped.
+func LoadRefFile(refFilePath string, fsys afero.Fs) ([]string, error) {
+	f, err := fsys.Open(refFilePath)
+	if err != nil {
+		return nil, errors.Errorf("failed to open ref file: %w", err)
+	}
+	defer f.Close()
+	var refs []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := utils.AssertProjectRefIsValid(line); err != nil {
+			return nil, err
+		}
+		refs = append(refs, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Errorf("failed to read ref file: %w", err)
+	}
+	return refs, nil
+}
+
+type bundleKey struct {
+	entrypoint string
+	importMap  string
+}
+
+type bundleResult struct {
+	once sync.Once
+	body []byte
+	err  error
+}
+
+// Memoizes bundles so that each function is only bundled once across projects.
+type sharedBundler struct {
+	function.EszipBundler
+	mu      sync.Mutex
+	bundles map[bundleKey]*bundleResult
+}
+
+func newSharedBundler(bundler function.EszipBundler) *sharedBundler {
+	return &sharedBundler{EszipBundler: bundler, bundles: map[bundleKey]*bundleResult{}}
+}
+
+func (b *sharedBundler) Bundle(ctx context.Context, entrypoint string, importMap string, output io.Writer) error {
+	key := bundleKey{entrypoint: entrypoint, importMap: importMap}
+	b.mu.Lock()
+	result, ok := b.bundles[key]
+	if !ok {
+		result = &bundleResult{}
+		b.bundles[key] = result
+	}
+	b.mu.Unlock()
+	result.once.Do(func() {
+		var body bytes.Buffer
+		result.err = b.EszipBundler.Bundle(ctx, entrypoint, importMap, &body)
+		result.body = body.Bytes()
+	})
+	if result.err != nil {
+		return result.err
+	}
+	if _, err := output.Write(result.body); err != nil {
+		return errors.Errorf("failed to write bundle: %w", err)
+	}
+	return nil
+}
+
+// Deploys the same bundles to every project, printing a status matrix of functions by project.
+func RunMulti(ctx context.Context, slugs []string, projectRefs []string, noVerifyJWT *bool, importMapPath string, checkRemote, analyze bool, maxJobs uint, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	} else if len(slugs) > 0 {
+		for _, s := range slugs {
+			if err := utils.ValidateFunctionSlug(s); err != nil {
+				return err
+			}
+		}
+	} else if slugs, err = getDeploySlugs(fsys); err != nil {
+		return err
+	}
+	if len(slugs) == 0 {
+		return errors.Errorf("No Functions specified or found in %s", utils.Bold(utils.FunctionsDir))
+	}
+	functionConfig, err := GetFunctionConfig(slugs, importMapPath, noVerifyJWT, fsys)
+	if err != nil {
+		return err
+	}
+	if err := ValidateImportMaps(ctx, functionConfig, checkRemote, fsys); err != nil {
+		return err
+	}
+	projectRefs = utils.RemoveDuplicates(projectRefs)
+	reporter := NewSizeReporter(NewDockerBundler(fsys), analyze)
+	bundler := newSharedBundler(reporter)
+	// Results are keyed by slug, then project ref
+	status := map[string]map[string]string{}
+	var deployed, failures []string
+	failedRefs := map[string]bool{}
+	for _, ref := range projectRefs {
+		fmt.Fprintln(os.Stderr, "Deploying Functions to project:", utils.Aqua(ref))
+		api := function.NewEdgeRuntimeAPI(ref, *utils.GetSupabase(), bundler)
+		results, err := api.UpsertFunctionsParallel(ctx, functionConfig, maxJobs)
+		// Bundles are shared, so sizes are only reported for the first project
+		if err := reporter.Print(); err != nil {
+			return err
+		}
+		if err != nil {
+			failedRefs[ref] = true
+			failures = append(failures, fmt.Sprintf("%s: %v", ref, err))
+			continue
+		}
+		for _, r := range results {
+			if _, ok := status[r.Slug]; !ok {
+				status[r.Slug] = map[string]string{}
+				deployed = append(deployed, r.Slug)
+			}
+			status[r.Slug][ref] = "DEPLOYED"
+			if r.Err != nil {
+				failedRefs[ref] = true
+				status[r.Slug][ref] = "FAILED"
+				failures = append(failures, fmt.Sprintf("%s/%s: %s", ref, r.Slug, strings.ReplaceAll(r.Err.Error(), "\n", " ")))
+			}
+		}
+		if failedRefs[ref] {
+			continue
+		}
+		if err := syncSchedules(ctx, ref, functionConfig); err != nil {
+			failedRefs[ref] = true
+			failures = append(failures, fmt.Sprintf("%s: %v", ref, err))
+		}
+	}
+	table := "|FUNCTION|" + strings.Join(projectRefs, "|") + "|\n|-|" + strings.Repeat("-|", len(projectRefs)) + "\n"
+	for _, slug := range deployed {
+		table += fmt.Sprintf("|`%s`|", slug)
+		for _, ref := range projectRefs {
+			result, ok := status[slug][ref]
+			if !ok {
+				result = "SKIPPED"
+			}
+			table += fmt.Sprintf("`%s`|", result)
+		}
+		table += "\n"
+	}
+	if err := list.RenderTable(table); err != nil {
+		return err
+	}
+	if len(failures) > 0 {
+		return errors.Errorf("failed to deploy to %d of %d projects:\n%s", len(failedRefs), len(projectRefs), strings.Join(failures, "\n"))
+	}
+	fmt.Printf("Deployed Functions to %d projects: %s\n", len(projectRefs), strings.Join(projectRefs, ", "))
+	return nil
+}
@@ -0,0 +1,169 @@
+package deploy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestLoadRefFile(t *testing.T) {
+	t.Run("loads refs skipping comments", func(t *testing.T) {
+		staging := apitest.RandomProjectRef()
+		prod := apitest.RandomProjectRef()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "refs.txt", []byte("# staging\n"+staging+"\n\n  "+prod+"  \n"), 0644))
+		// Run test
+		refs, err := LoadRefFile("refs.txt", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{staging, prod}, refs)
+	})
+
+	t.Run("throws error on invalid ref", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "refs.txt", []byte("invalid"), 0644))
+		// Run test
+		refs, err := LoadRefFile("refs.txt", fsys)
+		// Check error
+		assert.ErrorIs(t, err, utils.ErrInvalidRef)
+		assert.Empty(t, refs)
+	})
+
+	t.Run("throws error on missing file", func(t *testing.T) {
+		// Run test
+		refs, err := LoadRefFile("refs.txt", afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to open ref file:")
+		assert.Empty(t, refs)
+	})
+}
+
+type countingBundler struct {
+	calls int
+}
+
+func (b *countingBundler) Bundle(ctx context.Context, entrypoint string, importMap string, output io.Writer) error {
+	b.calls++
+	if entrypoint == "broken.ts" {
+		return errors.New("bundle failed")
+	}
+	_, err := output.Write([]byte(entrypoint))
+	return err
+}
+
+func TestSharedBundler(t *testing.T) {
+	counter := &countingBundler{}
+	bundler := newSharedBundler(counter)
+	// Run test
+	for i := 0; i < 2; i++ {
+		var body bytes.Buffer
+		assert.NoError(t, bundler.Bundle(context.Background(), "index.ts", "", &body))
+		assert.Equal(t, "index.ts", body.String())
+		assert.ErrorContains(t, bundler.Bundle(context.Background(), "broken.ts", "", io.Discard), "bundle failed")
+	}
+	// Check each entrypoint is bundled once
+	assert.Equal(t, 2, counter.calls)
+}
+
+func TestDeployMulti(t *testing.T) {
+	const slug = "test-func"
+	const containerId = "test-container"
+	imageUrl := utils.GetRegistryImageUrl(utils.Config.EdgeRuntime.Image)
+
+	t.Run("deploys to multiple projects", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		entrypoint := filepath.Join(utils.FunctionsDir, slug, "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte{}, 0644))
+		fsys = mockBundleOutput(fsys)
+		// Setup valid project refs
+		staging := apitest.RandomProjectRef()
+		prod := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + staging + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{})
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + staging + "/functions").
+			Reply(http.StatusCreated).
+			JSON(api.FunctionResponse{Id: "1"})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + prod + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{{Slug: slug}})
+		gock.New(utils.DefaultApiHost).
+			Patch("/v1/projects/" + prod + "/functions/" + slug).
+			Reply(http.StatusOK).
+			JSON(api.FunctionResponse{Id: "1"})
+		// Setup mock docker to bundle only once
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
+		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "bundled"))
+		mockListSchedules(staging)
+		mockListSchedules(prod)
+		// Run test
+		err := RunMulti(context.Background(), nil, []string{staging, prod}, nil, "", false, false, 1, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("reports failed projects", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		entrypoint := filepath.Join(utils.FunctionsDir, slug, "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte{}, 0644))
+		fsys = mockBundleOutput(fsys)
+		// Setup valid project refs
+		staging := apitest.RandomProjectRef()
+		prod := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + staging + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{})
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + staging + "/functions").
+			Reply(http.StatusCreated).
+			JSON(api.FunctionResponse{Id: "1"})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + prod + "/functions").
+			Reply(http.StatusForbidden).
+			JSON(map[string]string{"message": "forbidden"})
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
+		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "bundled"))
+		mockListSchedules(staging)
+		// Run test
+		err := RunMulti(context.Background(), nil, []string{staging, prod}, nil, "", false, false, 1, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to deploy to 1 of 2 projects:\n"+prod+": unexpected status 403:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
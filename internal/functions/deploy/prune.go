@@ -0,0 +1,68 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/functions/delete"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Deletes remote functions that no longer exist locally, after confirming with the user.
+func RunPrune(ctx context.Context, projectRef string, fsys afero.Fs) error {
+	stale, err := listStaleFunctions(ctx, projectRef, fsys)
+	if err != nil {
+		return err
+	}
+	if len(stale) == 0 {
+		fmt.Fprintln(os.Stderr, "No Functions to prune from project:", utils.Aqua(projectRef))
+		return nil
+	}
+	fmt.Fprintln(os.Stderr, "Functions deployed to project", utils.Aqua(projectRef), "but not found locally:")
+	for _, slug := range stale {
+		fmt.Fprintln(os.Stderr, " •", utils.Bold(slug))
+	}
+	title := fmt.Sprintf("Do you want to delete %d Functions from project %s?", len(stale), utils.Aqua(projectRef))
+	if shouldDelete, err := utils.NewConsole().PromptYesNo(ctx, title, false); err != nil {
+		return err
+	} else if !shouldDelete {
+		return errors.New(context.Canceled)
+	}
+	for _, slug := range stale {
+		if err := delete.Run(ctx, slug, projectRef, fsys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func listStaleFunctions(ctx context.Context, projectRef string, fsys afero.Fs) ([]string, error) {
+	// Ignored functions are still considered local so they are never pruned
+	slugs, err := GetFunctionSlugs(fsys)
+	if err != nil {
+		return nil, err
+	}
+	local := make(map[string]struct{}, len(slugs))
+	for _, s := range slugs {
+		local[s] = struct{}{}
+	}
+	resp, err := utils.GetSupabase().V1ListAllFunctionsWithResponse(ctx, projectRef)
+	if err != nil {
+		return nil, errors.Errorf("failed to list functions: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return nil, errors.New("Unexpected error retrieving functions: " + string(resp.Body))
+	}
+	var stale []string
+	for _, f := range *resp.JSON200 {
+		if _, ok := local[f.Slug]; !ok {
+			stale = append(stale, f.Slug)
+		}
+	}
+	sort.Strings(stale)
+	return stale, nil
+}
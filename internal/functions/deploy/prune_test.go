@@ -0,0 +1,120 @@
+package deploy
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestPruneFunctions(t *testing.T) {
+	// Setup valid project ref
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("deletes functions missing locally", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "hello", "index.ts"), []byte{}, 0644))
+		// Setup stdin
+		r, w, err := os.Pipe()
+		require.NoError(t, err)
+		_, err = w.WriteString("y\n")
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		oldStdin := os.Stdin
+		defer func() {
+			os.Stdin = oldStdin
+		}()
+		os.Stdin = r
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{{Slug: "hello"}, {Slug: "stale"}})
+		gock.New(utils.DefaultApiHost).
+			Delete("/v1/projects/" + project + "/functions/stale").
+			Reply(http.StatusOK)
+		// Run test
+		err = RunPrune(context.Background(), project, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("deletes without prompt when confirmed by flag", func(t *testing.T) {
+		viper.Set("YES", true)
+		t.Cleanup(func() { viper.Set("YES", false) })
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{{Slug: "stale"}})
+		gock.New(utils.DefaultApiHost).
+			Delete("/v1/projects/" + project + "/functions/stale").
+			Reply(http.StatusOK)
+		// Run test
+		err := RunPrune(context.Background(), project, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips prompt when in sync", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "hello", "index.ts"), []byte{}, 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{{Slug: "hello"}})
+		// Run test
+		err := RunPrune(context.Background(), project, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on cancel", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{{Slug: "stale"}})
+		// Run test
+		err := RunPrune(context.Background(), project, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on service unavailable", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := RunPrune(context.Background(), project, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Unexpected error retrieving functions:")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
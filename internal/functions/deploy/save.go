@@ -0,0 +1,111 @@
+package deploy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var tableHeaderPattern = regexp.MustCompile(`^\s*\[`)
+
+// Writes deploy flags into the [functions.<slug>] block of config.toml. The file
+// is edited line by line instead of re-encoded so that user comments are kept.
+func SaveFunctionConfig(slugs []string, noVerifyJWT *bool, importMapPath string, fsys afero.Fs) error {
+	if len(slugs) == 0 {
+		var err error
+		if slugs, err = getDeploySlugs(fsys); err != nil {
+			return err
+		}
+	}
+	var values [][2]string
+	if noVerifyJWT != nil {
+		values = append(values, [2]string{"verify_jwt", strconv.FormatBool(!*noVerifyJWT)})
+	}
+	if len(importMapPath) > 0 {
+		values = append(values, [2]string{"import_map", strconv.Quote(relativeToSupabaseDir(importMapPath))})
+	}
+	original, err := afero.ReadFile(fsys, utils.ConfigPath)
+	if err != nil {
+		return errors.Errorf("failed to read config: %w", err)
+	}
+	updated := string(original)
+	for _, slug := range slugs {
+		updated = setFunctionValues(updated, slug, values)
+	}
+	if err := utils.WriteFile(utils.ConfigPath, []byte(updated), fsys); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Saved Function config to %s: %s\n", utils.Bold(utils.ConfigPath), strings.Join(slugs, ", "))
+	return nil
+}
+
+// Paths in config.toml are resolved relative to the supabase directory.
+func relativeToSupabaseDir(importMapPath string) string {
+	if !filepath.IsAbs(importMapPath) {
+		importMapPath = filepath.Join(utils.CurrentDirAbs, importMapPath)
+	}
+	supabaseDir, err := filepath.Abs(utils.SupabaseDirPath)
+	if err != nil {
+		return importMapPath
+	}
+	rel, err := filepath.Rel(supabaseDir, importMapPath)
+	if err != nil {
+		return importMapPath
+	}
+	rel = filepath.ToSlash(rel)
+	if !strings.HasPrefix(rel, "../") {
+		rel = "./" + rel
+	}
+	return rel
+}
+
+func setFunctionValues(config, slug string, values [][2]string) string {
+	headerPattern := regexp.MustCompile(fmt.Sprintf(`^\s*\[\s*functions\s*\.\s*"?%s"?\s*\]\s*(#.*)?$`, regexp.QuoteMeta(slug)))
+	lines := strings.Split(config, "\n")
+	start := -1
+	for i, line := range lines {
+		if headerPattern.MatchString(line) {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		block := []string{"", fmt.Sprintf("[functions.%s]", slug)}
+		for _, kv := range values {
+			block = append(block, kv[0]+" = "+kv[1])
+		}
+		return strings.TrimRight(config, "\n") + "\n" + strings.Join(block, "\n") + "\n"
+	}
+	end := len(lines)
+	for i := start + 1; i < len(lines); i++ {
+		if tableHeaderPattern.MatchString(lines[i]) {
+			end = i
+			break
+		}
+	}
+	var missing []string
+	for _, kv := range values {
+		keyPattern := regexp.MustCompile(fmt.Sprintf(`^\s*%s\s*=`, kv[0]))
+		found := false
+		for i := start + 1; i < end; i++ {
+			if keyPattern.MatchString(lines[i]) {
+				lines[i] = kv[0] + " = " + kv[1]
+				found = true
+			}
+		}
+		if !found {
+			missing = append(missing, kv[0]+" = "+kv[1])
+		}
+	}
+	result := append([]string{}, lines[:start+1]...)
+	result = append(result, missing...)
+	result = append(result, lines[start+1:]...)
+	return strings.Join(result, "\n")
+}
@@ -0,0 +1,103 @@
+package deploy
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/cast"
+)
+
+func TestSaveFunctionConfig(t *testing.T) {
+	t.Run("updates existing function block", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "test"
+
+[functions.hello]
+# Entrypoint comment is kept
+entrypoint = "./functions/hello/index.ts"
+verify_jwt = true
+
+[analytics]
+enabled = false
+`), 0644))
+		// Run test
+		err := SaveFunctionConfig([]string{"hello"}, cast.Ptr(true), "", fsys)
+		// Check error
+		assert.NoError(t, err)
+		data, err := afero.ReadFile(fsys, utils.ConfigPath)
+		assert.NoError(t, err)
+		assert.Equal(t, `project_id = "test"
+
+[functions.hello]
+# Entrypoint comment is kept
+entrypoint = "./functions/hello/index.ts"
+verify_jwt = false
+
+[analytics]
+enabled = false
+`, string(data))
+	})
+
+	t.Run("appends missing function block", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`project_id = "test"
+
+[functions.other]
+verify_jwt = false
+`), 0644))
+		importMapPath, err := filepath.Abs(filepath.Join(utils.FunctionsDir, "import_map.json"))
+		require.NoError(t, err)
+		// Run test
+		err = SaveFunctionConfig([]string{"hello"}, nil, importMapPath, fsys)
+		// Check error
+		assert.NoError(t, err)
+		data, err := afero.ReadFile(fsys, utils.ConfigPath)
+		assert.NoError(t, err)
+		assert.Equal(t, `project_id = "test"
+
+[functions.other]
+verify_jwt = false
+
+[functions.hello]
+import_map = "./functions/import_map.json"
+`, string(data))
+	})
+
+	t.Run("inserts missing keys under header", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, []byte(`[functions."hello"]
+entrypoint = "./functions/hello/index.ts"
+`), 0644))
+		// Run test
+		err := SaveFunctionConfig([]string{"hello"}, cast.Ptr(false), "/tmp/import_map.json", fsys)
+		// Check error
+		assert.NoError(t, err)
+		data, err := afero.ReadFile(fsys, utils.ConfigPath)
+		assert.NoError(t, err)
+		rel, err := filepath.Abs(utils.SupabaseDirPath)
+		require.NoError(t, err)
+		rel, err = filepath.Rel(rel, "/tmp/import_map.json")
+		require.NoError(t, err)
+		assert.Equal(t, `[functions."hello"]
+verify_jwt = true
+import_map = "`+filepath.ToSlash(rel)+`"
+entrypoint = "./functions/hello/index.ts"
+`, string(data))
+	})
+
+	t.Run("throws error on missing config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := SaveFunctionConfig([]string{"hello"}, cast.Ptr(true), "", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to read config:")
+	})
+}
@@ -0,0 +1,147 @@
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/tenant"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/config"
+)
+
+// Cron jobs created by deploy are named by slug so they can be updated in place.
+const scheduleJobPrefix = "supabase_functions_"
+
+// Schedules deployed functions with pg_cron and pg_net. Existing jobs of functions
+// that no longer declare a schedule are removed in the same query.
+func syncSchedules(ctx context.Context, projectRef string, functionConfig config.FunctionConfig) error {
+	var scheduled, candidates []string
+	for slug, function := range functionConfig {
+		if !function.IsEnabled() {
+			continue
+		}
+		if len(function.Schedule) > 0 {
+			scheduled = append(scheduled, slug)
+		} else {
+			candidates = append(candidates, slug)
+		}
+	}
+	var unscheduled []string
+	if len(candidates) > 0 {
+		// Cleaning up stale jobs is best effort so that it never fails a deploy
+		existing, err := ListSchedules(ctx, projectRef)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "failed to list scheduled Functions:", err)
+		}
+		for _, slug := range candidates {
+			if _, ok := existing[slug]; ok {
+				unscheduled = append(unscheduled, slug)
+			}
+		}
+	}
+	if len(scheduled) == 0 && len(unscheduled) == 0 {
+		return nil
+	}
+	sort.Strings(scheduled)
+	sort.Strings(unscheduled)
+	var anonKey string
+	if len(scheduled) > 0 {
+		// The anon key is public so it is safe to store in cron.job
+		keys, err := tenant.GetApiKeys(ctx, projectRef)
+		if err != nil {
+			return err
+		}
+		anonKey = keys.Anon
+	}
+	sql := buildScheduleQuery(projectRef, anonKey, scheduled, unscheduled, functionConfig)
+	if _, err := runQuery(ctx, projectRef, sql); err != nil {
+		return errors.Errorf("failed to schedule functions: %w", err)
+	}
+	if len(scheduled) > 0 {
+		fmt.Fprintln(os.Stderr, "Scheduled Functions:", strings.Join(scheduled, ", "))
+	}
+	if len(unscheduled) > 0 {
+		fmt.Fprintln(os.Stderr, "Unscheduled Functions:", strings.Join(unscheduled, ", "))
+	}
+	return nil
+}
+
+func buildScheduleQuery(projectRef, anonKey string, scheduled, unscheduled []string, functionConfig config.FunctionConfig) string {
+	headers := fmt.Sprintf(`{"Content-Type": "application/json", "Authorization": "Bearer %s"}`, anonKey)
+	var lines []string
+	if len(scheduled) > 0 {
+		lines = append(lines,
+			"create extension if not exists pg_cron with schema pg_catalog;",
+			"create extension if not exists pg_net with schema extensions;",
+		)
+	}
+	for _, slug := range scheduled {
+		url := fmt.Sprintf("https://%s/functions/v1/%s", utils.GetSupabaseHost(projectRef), slug)
+		command := fmt.Sprintf("select net.http_post(url := '%s', headers := '%s'::jsonb, body := '{}'::jsonb)", url, headers)
+		lines = append(lines, fmt.Sprintf("select cron.schedule('%s%s', '%s', $cron$%s$cron$);", scheduleJobPrefix, slug, functionConfig[slug].Schedule, command))
+	}
+	if len(unscheduled) > 0 {
+		jobs := make([]string, len(unscheduled))
+		for i, slug := range unscheduled {
+			jobs[i] = "'" + scheduleJobPrefix + slug + "'"
+		}
+		lines = append(lines, fmt.Sprintf("select cron.unschedule(jobname) from cron.job where jobname in (%s);", strings.Join(jobs, ", ")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Returns the cron schedule of each function slug scheduled by deploy.
+func ListSchedules(ctx context.Context, projectRef string) (map[string]string, error) {
+	// Projects that never scheduled a function may not have pg_cron installed
+	body, err := runQuery(ctx, projectRef, "select extname from pg_extension where extname = 'pg_cron'")
+	if err != nil {
+		return nil, err
+	}
+	var extensions []json.RawMessage
+	if err := json.Unmarshal(body, &extensions); err != nil {
+		return nil, errors.Errorf("failed to parse extensions: %w", err)
+	} else if len(extensions) == 0 {
+		return map[string]string{}, nil
+	}
+	body, err = runQuery(ctx, projectRef, fmt.Sprintf("select jobname, schedule from cron.job where starts_with(jobname, '%s')", scheduleJobPrefix))
+	if err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		Jobname  string `json:"jobname"`
+		Schedule string `json:"schedule"`
+	}
+	if err := json.Unmarshal(body, &rows); err != nil {
+		return nil, errors.Errorf("failed to parse schedules: %w", err)
+	}
+	result := make(map[string]string, len(rows))
+	for _, r := range rows {
+		result[strings.TrimPrefix(r.Jobname, scheduleJobPrefix)] = r.Schedule
+	}
+	return result, nil
+}
+
+// The generated client expects an object but the query endpoint responds with rows.
+func runQuery(ctx context.Context, projectRef, sql string) ([]byte, error) {
+	resp, err := utils.GetSupabase().V1RunAQuery(ctx, projectRef, api.V1RunQueryBody{Query: sql})
+	if err != nil {
+		return nil, errors.Errorf("failed to run query: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return nil, errors.Errorf("unexpected status %d: %s", resp.StatusCode, string(body))
+	}
+	return body, nil
+}
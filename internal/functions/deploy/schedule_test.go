@@ -0,0 +1,181 @@
+package deploy
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/config"
+)
+
+func TestSyncSchedules(t *testing.T) {
+	t.Run("schedules functions with pg_cron", func(t *testing.T) {
+		functionConfig := config.FunctionConfig{
+			"cron-func": {Schedule: "*/5 * * * *"},
+			"http-func": {},
+		}
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		mockListSchedules(project, "http-func")
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "anon", ApiKey: "anon-key"}})
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/database/query").
+			BodyString(`cron.schedule\('supabase_functions_cron-func', '\*/5 \* \* \* \*'`).
+			BodyString(`jobname in \('supabase_functions_http-func'\)`).
+			Reply(http.StatusCreated).
+			JSON([]any{})
+		// Run test
+		err := syncSchedules(context.Background(), project, functionConfig)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("unschedules the last scheduled function", func(t *testing.T) {
+		functionConfig := config.FunctionConfig{"http-func": {}}
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		mockListSchedules(project, "http-func")
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/database/query").
+			BodyString(`cron.unschedule\(jobname\) from cron.job where jobname in \('supabase_functions_http-func'\)`).
+			Reply(http.StatusCreated).
+			JSON([]any{})
+		// Run test
+		err := syncSchedules(context.Background(), project, functionConfig)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips pg_cron without scheduled jobs", func(t *testing.T) {
+		functionConfig := config.FunctionConfig{"http-func": {}}
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		mockListSchedules(project)
+		// Run test
+		err := syncSchedules(context.Background(), project, functionConfig)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("ignores failure to list schedules", func(t *testing.T) {
+		functionConfig := config.FunctionConfig{"http-func": {}}
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/database/query").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := syncSchedules(context.Background(), project, functionConfig)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("skips disabled functions", func(t *testing.T) {
+		enabled := false
+		functionConfig := config.FunctionConfig{"http-func": {Enabled: &enabled}}
+		// Run test
+		err := syncSchedules(context.Background(), "", functionConfig)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		functionConfig := config.FunctionConfig{"cron-func": {Schedule: "30 seconds"}}
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{{Name: "anon", ApiKey: "anon-key"}})
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/database/query").
+			Reply(http.StatusBadRequest).
+			JSON(map[string]string{"message": `extension "pg_cron" is not available`})
+		// Run test
+		err := syncSchedules(context.Background(), project, functionConfig)
+		// Check error
+		assert.ErrorContains(t, err, "failed to schedule functions: unexpected status 400")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestListSchedules(t *testing.T) {
+	t.Run("lists schedules by slug", func(t *testing.T) {
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		mockListSchedules(project, "cron-func")
+		// Run test
+		schedules, err := ListSchedules(context.Background(), project)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"cron-func": "0 * * * *"}, schedules)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func mockListSchedules(project string, slugs ...string) {
+	if len(slugs) == 0 {
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/database/query").
+			BodyString(`from pg_extension`).
+			Reply(http.StatusCreated).
+			JSON([]any{})
+		return
+	}
+	gock.New(utils.DefaultApiHost).
+		Post("/v1/projects/" + project + "/database/query").
+		BodyString(`from pg_extension`).
+		Reply(http.StatusCreated).
+		JSON([]map[string]string{{"extname": "pg_cron"}})
+	jobs := make([]map[string]string, len(slugs))
+	for i, slug := range slugs {
+		jobs[i] = map[string]string{"jobname": scheduleJobPrefix + slug, "schedule": "0 * * * *"}
+	}
+	gock.New(utils.DefaultApiHost).
+		Post("/v1/projects/" + project + "/database/query").
+		BodyString(`from cron.job`).
+		Reply(http.StatusCreated).
+		JSON(jobs)
+}
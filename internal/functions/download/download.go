@@ -107,6 +107,26 @@ func downloadFunction(ctx context.Context, projectRef, slug, extractScriptPath s
 	return nil
 }
 
+// Recovering a function overwrites any local source under its directory.
+func PreRun(ctx context.Context, slug string, fsys afero.Fs) error {
+	funcDir := filepath.Join(utils.FunctionsDir, slug)
+	if _, err := fsys.Stat(funcDir); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if empty, err := afero.IsEmpty(fsys, funcDir); err != nil {
+		return errors.Errorf("failed to read function directory: %w", err)
+	} else if empty {
+		return nil
+	}
+	title := fmt.Sprintf("Do you want to overwrite existing files in %s?", utils.Bold(funcDir))
+	if shouldOverwrite, err := utils.NewConsole().PromptYesNo(ctx, title, false); err != nil {
+		return err
+	} else if !shouldOverwrite {
+		return errors.New(context.Canceled)
+	}
+	return nil
+}
+
 func Run(ctx context.Context, slug string, projectRef string, useLegacyBundle bool, fsys afero.Fs) error {
 	if useLegacyBundle {
 		return RunLegacy(ctx, slug, projectRef, fsys)
@@ -126,11 +146,12 @@ func Run(ctx context.Context, slug string, projectRef string, useLegacyBundle bo
 		}
 	}()
 	// Extract eszip to functions directory
-	err = extractOne(ctx, slug, eszipPath)
-	if err != nil {
+	if err := extractOne(ctx, slug, eszipPath); err != nil {
 		utils.CmdSuggestion += suggestLegacyBundle(slug)
+		return err
 	}
-	return err
+	fmt.Println("Downloaded Function " + utils.Aqua(slug) + " from project " + utils.Aqua(projectRef) + ".")
+	return nil
 }
 
 func downloadOne(ctx context.Context, slug, projectRef string, fsys afero.Fs) (string, error) {
@@ -173,7 +194,7 @@ func extractOne(ctx context.Context, slug, eszipPath string) error {
 	binds := []string{
 		// Reuse deno cache directory, ie. DENO_DIR, between container restarts
 		// https://denolib.gitbook.io/guide/advanced/deno_dir-code-fetch-and-cache
-		utils.EdgeRuntimeId + ":/root/.cache/deno:rw",
+		utils.DenoCacheVolume + ":/root/.cache/deno:rw",
 		hostEszipPath + ":" + dockerEszipPath + ":ro",
 		hostFuncDirPath + ":" + utils.DockerDenoDir + ":rw",
 	}
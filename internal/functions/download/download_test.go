@@ -7,6 +7,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/h2non/gock"
@@ -235,3 +236,27 @@ func TestGetMetadata(t *testing.T) {
 		assert.Nil(t, meta)
 	})
 }
+
+func TestDownloadPreRun(t *testing.T) {
+	const slug = "test-func"
+
+	t.Run("skips prompt on new function", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := PreRun(context.Background(), slug, fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("cancels overwrite without confirmation", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		entrypoint := filepath.Join(utils.FunctionsDir, slug, "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte{}, 0644))
+		// Run test
+		err := PreRun(context.Background(), slug, fsys)
+		// Check error
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
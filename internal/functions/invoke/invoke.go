@@ -0,0 +1,122 @@
+package invoke
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/tenant"
+)
+
+type InvokeOptions struct {
+	Method      string
+	Body        string
+	Headers     []string
+	ServiceRole bool
+}
+
+// Invokes the local stack when projectRef is empty, otherwise the hosted project.
+func Run(ctx context.Context, slug, projectRef string, opts InvokeOptions, fsys afero.Fs) error {
+	if err := utils.ValidateFunctionSlug(slug); err != nil {
+		return err
+	}
+	url, key, err := resolveEndpoint(ctx, slug, projectRef, opts.ServiceRole, fsys)
+	if err != nil {
+		return err
+	}
+	req, err := newRequest(ctx, url, key, opts)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Errorf("failed to invoke function: %w", err)
+	}
+	defer resp.Body.Close()
+	printHeaders(resp, os.Stderr)
+	if _, err := io.Copy(os.Stdout, resp.Body); err != nil {
+		return errors.Errorf("failed to read response: %w", err)
+	}
+	fmt.Println()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("Function %s returned status %d", utils.Aqua(slug), resp.StatusCode)
+	}
+	return nil
+}
+
+func resolveEndpoint(ctx context.Context, slug, projectRef string, serviceRole bool, fsys afero.Fs) (string, string, error) {
+	path := "/functions/v1/" + slug
+	if len(projectRef) == 0 {
+		if err := utils.LoadConfigFS(fsys); err != nil {
+			return "", "", err
+		}
+		if err := utils.AssertServiceIsRunning(ctx, utils.EdgeRuntimeId); err != nil {
+			return "", "", err
+		}
+		key := utils.Config.Auth.AnonKey
+		if serviceRole {
+			key = utils.Config.Auth.ServiceRoleKey
+		}
+		return utils.GetApiUrl(path), key, nil
+	}
+	keys, err := tenant.GetApiKeys(ctx, projectRef)
+	if err != nil {
+		return "", "", err
+	}
+	key := keys.Anon
+	if serviceRole {
+		key = keys.ServiceRole
+	}
+	return "https://" + utils.GetSupabaseHost(projectRef) + path, key, nil
+}
+
+func newRequest(ctx context.Context, url, key string, opts InvokeOptions) (*http.Request, error) {
+	var body io.Reader
+	if len(opts.Body) > 0 {
+		body = strings.NewReader(opts.Body)
+	}
+	method := opts.Method
+	if len(method) == 0 {
+		method = http.MethodPost
+	}
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(method), url, body)
+	if err != nil {
+		return nil, errors.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+key)
+	req.Header.Set("apikey", key)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	// User supplied headers take precedence over defaults
+	for _, pair := range opts.Headers {
+		name, value, found := strings.Cut(pair, "=")
+		if !found || len(strings.TrimSpace(name)) == 0 {
+			return nil, errors.Errorf("Invalid header %s: must be formatted as key=value", utils.Aqua(pair))
+		}
+		req.Header.Set(strings.TrimSpace(name), value)
+	}
+	return req, nil
+}
+
+func printHeaders(resp *http.Response, w io.Writer) {
+	fmt.Fprintln(w, resp.Proto, resp.Status)
+	names := make([]string, 0, len(resp.Header))
+	for name := range resp.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range resp.Header[name] {
+			fmt.Fprintf(w, "%s: %s\n", name, value)
+		}
+	}
+	fmt.Fprintln(w)
+}
@@ -0,0 +1,116 @@
+package invoke
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestInvokeCommand(t *testing.T) {
+	const slug = "hello"
+
+	t.Run("invokes local function with anon key", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_edge_runtime_test/json").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{})
+		gock.New("http://127.0.0.1:54321").
+			Post("/functions/v1/"+slug).
+			MatchHeader("Authorization", "^Bearer ey").
+			MatchHeader("Content-Type", "application/json").
+			MatchHeader("X-Custom", "value").
+			BodyString(`{"name":"world"}`).
+			Reply(http.StatusOK).
+			JSON(map[string]string{"message": "Hello world!"})
+		// Run test
+		err := Run(context.Background(), slug, "", InvokeOptions{
+			Body:    `{"name":"world"}`,
+			Headers: []string{"X-Custom=value"},
+		}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("invokes remote function with service role key", func(t *testing.T) {
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/api-keys").
+			Reply(http.StatusOK).
+			JSON([]api.ApiKeyResponse{
+				{Name: "anon", ApiKey: "anon-key"},
+				{Name: "service_role", ApiKey: "service-key"},
+			})
+		gock.New("https://"+utils.GetSupabaseHost(project)).
+			Get("/functions/v1/"+slug).
+			MatchHeader("Authorization", "Bearer service-key").
+			Reply(http.StatusOK)
+		// Run test
+		err := Run(context.Background(), slug, project, InvokeOptions{
+			Method:      http.MethodGet,
+			ServiceRole: true,
+		}, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on malformed header", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_edge_runtime_test/json").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{})
+		// Run test
+		err := Run(context.Background(), slug, "", InvokeOptions{Headers: []string{"invalid"}}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "must be formatted as key=value")
+	})
+
+	t.Run("throws error on function failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_edge_runtime_test/json").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{})
+		gock.New("http://127.0.0.1:54321").
+			Post("/functions/v1/" + slug).
+			Reply(http.StatusInternalServerError)
+		// Run test
+		err := Run(context.Background(), slug, "", InvokeOptions{}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "returned status 500")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
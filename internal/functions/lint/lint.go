@@ -0,0 +1,172 @@
+package lint
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/functions/deploy"
+	"github.com/supabase/cli/internal/migration/list"
+	secrets "github.com/supabase/cli/internal/secrets/list"
+	"github.com/supabase/cli/internal/secrets/set"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var (
+	envGetPattern = regexp.MustCompile(`Deno\.env\.get\(\s*["'` + "`" + `]([A-Za-z_][A-Za-z0-9_]*)["'` + "`" + `]\s*\)`)
+	sourceExts    = map[string]bool{".ts": true, ".tsx": true, ".mts": true, ".js": true, ".jsx": true, ".mjs": true}
+)
+
+type EnvReference struct {
+	Slug string
+	Name string
+	Path string
+	Line int
+}
+
+func Run(ctx context.Context, slugs []string, projectRef string, envFilePaths []string, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	} else if len(slugs) > 0 {
+		for _, s := range slugs {
+			if err := utils.ValidateFunctionSlug(s); err != nil {
+				return err
+			}
+		}
+	} else if slugs, err = deploy.GetFunctionSlugs(fsys); err != nil {
+		return err
+	}
+	if len(slugs) == 0 {
+		return errors.Errorf("No Functions specified or found in %s", utils.Bold(utils.FunctionsDir))
+	}
+	functionConfig, err := deploy.GetFunctionConfig(slugs, "", nil, fsys)
+	if err != nil {
+		return err
+	}
+	var refs []EnvReference
+	for _, slug := range utils.RemoveDuplicates(slugs) {
+		found, err := FindEnvReferences(slug, filepath.Dir(functionConfig[slug].Entrypoint), fsys)
+		if err != nil {
+			return err
+		}
+		refs = append(refs, found...)
+	}
+	digests, err := secrets.GetSecretDigests(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	remote := make(map[string]bool, len(digests))
+	for _, s := range digests {
+		remote[s.Name] = true
+	}
+	local, err := loadLocalEnv(envFilePaths, fsys)
+	if err != nil {
+		return err
+	}
+	table := `|FUNCTION|ENV|LOCATION|PROBLEM|
+|-|-|-|-|
+`
+	var problems int
+	for _, r := range refs {
+		if remote[r.Name] || isReserved(r.Name) {
+			continue
+		}
+		problems++
+		problem := "undefined"
+		if local[r.Name] {
+			problem = "missing from project secrets"
+		}
+		table += fmt.Sprintf("|`%s`|`%s`|`%s:%d`|%s|\n", r.Slug, r.Name, r.Path, r.Line, problem)
+	}
+	if problems == 0 {
+		fmt.Fprintf(os.Stderr, "Found %d env references. All are set on project %s.\n", len(refs), utils.Aqua(projectRef))
+		return nil
+	}
+	if err := list.RenderTable(table); err != nil {
+		return err
+	}
+	utils.CmdSuggestion = fmt.Sprintf("Run %s to set missing secrets.", utils.Aqua("supabase secrets set"))
+	return errors.Errorf("Found %d env references that are undefined on project %s", problems, projectRef)
+}
+
+// Scans function sources for Deno.env.get calls with a string literal name.
+func FindEnvReferences(slug, functionDir string, fsys afero.Fs) ([]EnvReference, error) {
+	var refs []EnvReference
+	if _, err := fsys.Stat(functionDir); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	err := afero.Walk(fsys, functionDir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == "node_modules" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !sourceExts[filepath.Ext(path)] {
+			return nil
+		}
+		f, err := fsys.Open(path)
+		if err != nil {
+			return errors.Errorf("failed to open source: %w", err)
+		}
+		defer f.Close()
+		scanner := bufio.NewScanner(f)
+		for line := 1; scanner.Scan(); line++ {
+			for _, m := range envGetPattern.FindAllStringSubmatch(scanner.Text(), -1) {
+				refs = append(refs, EnvReference{Slug: slug, Name: m[1], Path: path, Line: line})
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return errors.Errorf("failed to read source: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to scan function %s: %w", slug, err)
+	}
+	sort.SliceStable(refs, func(i, j int) bool {
+		return refs[i].Path < refs[j].Path
+	})
+	return refs, nil
+}
+
+func loadLocalEnv(envFilePaths []string, fsys afero.Fs) (map[string]bool, error) {
+	// Flag paths are relative to current directory, same as functions serve
+	for i, envFilePath := range envFilePaths {
+		if !filepath.IsAbs(envFilePath) {
+			envFilePaths[i] = filepath.Join(utils.CurrentDirAbs, envFilePath)
+		}
+	}
+	if len(envFilePaths) == 0 {
+		if f, err := fsys.Stat(utils.FallbackEnvFilePath); err == nil && !f.IsDir() {
+			envFilePaths = []string{utils.FallbackEnvFilePath}
+		}
+	}
+	result := map[string]bool{}
+	for _, envFilePath := range envFilePaths {
+		parsed, err := set.ParseEnvFile(envFilePath, fsys)
+		if err != nil {
+			return nil, err
+		}
+		for name := range parsed {
+			result[name] = true
+		}
+	}
+	return result, nil
+}
+
+// Secrets prefixed with SUPABASE_ are populated by the platform.
+func isReserved(name string) bool {
+	return strings.HasPrefix(name, "SUPABASE_")
+}
@@ -0,0 +1,117 @@
+package lint
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+const indexTs = `const url = Deno.env.get("SUPABASE_URL")
+const key = Deno.env.get('STRIPE_KEY') ?? Deno.env.get("STRIPE_FALLBACK")
+Deno.serve(() => new Response(Deno.env.get(` + "`OPENAI_KEY`" + `)))
+`
+
+func TestFindEnvReferences(t *testing.T) {
+	t.Run("finds literal env names", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		functionDir := filepath.Join(utils.FunctionsDir, "hello")
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(functionDir, "index.ts"), []byte(indexTs), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(functionDir, "README.md"), []byte(`Deno.env.get("DOCS")`), 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(functionDir, "node_modules", "a.js"), []byte(`Deno.env.get("VENDOR")`), 0644))
+		// Run test
+		refs, err := FindEnvReferences("hello", functionDir, fsys)
+		// Check error
+		assert.NoError(t, err)
+		var names []string
+		for _, r := range refs {
+			names = append(names, r.Name)
+		}
+		assert.Equal(t, []string{"SUPABASE_URL", "STRIPE_KEY", "STRIPE_FALLBACK", "OPENAI_KEY"}, names)
+		assert.Equal(t, 3, refs[3].Line)
+	})
+
+	t.Run("ignores missing directory", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		refs, err := FindEnvReferences("hello", filepath.Join(utils.FunctionsDir, "hello"), fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, refs)
+	})
+}
+
+func TestLintCommand(t *testing.T) {
+	setup := func(t *testing.T) afero.Fs {
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		entrypoint := filepath.Join(utils.FunctionsDir, "hello", "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte(indexTs), 0644))
+		require.NoError(t, afero.WriteFile(fsys, utils.FallbackEnvFilePath, []byte("STRIPE_FALLBACK=test"), 0644))
+		return fsys
+	}
+
+	t.Run("passes when all secrets are set", func(t *testing.T) {
+		fsys := setup(t)
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{
+				{Name: "OPENAI_KEY", Value: "digest"},
+				{Name: "STRIPE_FALLBACK", Value: "digest"},
+				{Name: "STRIPE_KEY", Value: "digest"},
+			})
+		// Run test
+		err := Run(context.Background(), nil, project, nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing secrets", func(t *testing.T) {
+		fsys := setup(t)
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{{Name: "STRIPE_KEY", Value: "digest"}})
+		// Run test
+		err := Run(context.Background(), []string{"hello"}, project, nil, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Found 2 env references that are undefined on project "+project)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing functions", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		err := Run(context.Background(), nil, "", nil, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "No Functions specified or found in")
+	})
+}
@@ -3,14 +3,22 @@ package list
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/functions/deploy"
 	"github.com/supabase/cli/internal/migration/list"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
 )
 
+type scheduledFunction struct {
+	api.FunctionResponse `yaml:",inline"`
+	Schedule             string `json:"schedule,omitempty"`
+}
+
 func Run(ctx context.Context, projectRef string, fsys afero.Fs) error {
 	resp, err := utils.GetSupabase().V1ListAllFunctionsWithResponse(ctx, projectRef)
 	if err != nil {
@@ -21,21 +29,67 @@ func Run(ctx context.Context, projectRef string, fsys afero.Fs) error {
 		return errors.New("Unexpected error retrieving functions: " + string(resp.Body))
 	}
 
-	table := `|ID|NAME|SLUG|STATUS|VERSION|UPDATED_AT (UTC)|
-|-|-|-|-|-|-|
+	// Schedules are best effort so that listing works without database access
+	schedules, err := deploy.ListSchedules(ctx, projectRef)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "failed to list schedules:", err)
+	}
+	functions := make([]scheduledFunction, len(*resp.JSON200))
+	for i, function := range *resp.JSON200 {
+		functions[i] = scheduledFunction{
+			FunctionResponse: function,
+			Schedule:         schedules[function.Slug],
+		}
+	}
+
+	if utils.OutputFormat.Value == utils.OutputPretty {
+		table := `|ID|NAME|SLUG|STATUS|VERSION|VERIFY_JWT|IMPORT_MAP|SCHEDULE|CREATED_AT (UTC)|UPDATED_AT (UTC)|
+|-|-|-|-|-|-|-|-|-|-|
 `
-	for _, function := range *resp.JSON200 {
-		t := time.UnixMilli(function.UpdatedAt)
-		table += fmt.Sprintf(
-			"|`%s`|`%s`|`%s`|`%s`|`%d`|`%s`|\n",
-			function.Id,
-			function.Name,
-			function.Slug,
-			function.Status,
-			function.Version,
-			t.UTC().Format("2006-01-02 15:04:05"),
-		)
-	}
-
-	return list.RenderTable(table)
+		for _, function := range functions {
+			table += fmt.Sprintf(
+				"|`%s`|`%s`|`%s`|`%s`|`%d`|`%t`|`%s`|`%s`|`%s`|`%s`|\n",
+				function.Id,
+				function.Name,
+				function.Slug,
+				function.Status,
+				function.Version,
+				function.VerifyJwt == nil || *function.VerifyJwt,
+				formatImportMap(function.FunctionResponse),
+				formatSchedule(function.Schedule),
+				formatMillis(function.CreatedAt),
+				formatMillis(function.UpdatedAt),
+			)
+		}
+		return list.RenderTable(table)
+	} else if utils.OutputFormat.Value == utils.OutputToml {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, struct {
+			Functions []scheduledFunction `toml:"functions"`
+		}{
+			Functions: functions,
+		})
+	}
+
+	return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, functions)
+}
+
+func formatImportMap(function api.FunctionResponse) string {
+	if function.ImportMap == nil || !*function.ImportMap {
+		return "-"
+	}
+	if function.ImportMapPath != nil && len(*function.ImportMapPath) > 0 {
+		return *function.ImportMapPath
+	}
+	return "true"
+}
+
+func formatSchedule(schedule string) string {
+	if len(schedule) == 0 {
+		return "-"
+	}
+	return schedule
+}
+
+func formatMillis(timestamp int64) string {
+	return time.UnixMilli(timestamp).UTC().Format("2006-01-02 15:04:05")
 }
@@ -3,6 +3,7 @@ package list
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/h2non/gock"
@@ -46,6 +47,52 @@ func TestFunctionsListCommand(t *testing.T) {
 				ImportMap:      &testImportMap,
 				ImportMapPath:  &testImportMapPath,
 			}})
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/database/query").
+			BodyString(`from pg_extension`).
+			Reply(http.StatusCreated).
+			JSON([]map[string]string{{"extname": "pg_cron"}})
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/database/query").
+			BodyString(`from cron.job`).
+			Reply(http.StatusCreated).
+			JSON([]map[string]string{{
+				"jobname":  "supabase_functions_test-function",
+				"schedule": "*/5 * * * *",
+			}})
+		// Run test
+		err := Run(context.Background(), project, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("encodes functions as json", func(t *testing.T) {
+		utils.OutputFormat.Value = utils.OutputJson
+		t.Cleanup(func() { utils.OutputFormat.Value = utils.OutputPretty })
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Flush pending mocks after test execution
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(200).
+			JSON([]api.FunctionResponse{{
+				Id:      "test-id",
+				Name:    "Test Function",
+				Slug:    "test-function",
+				Status:  api.FunctionResponseStatusACTIVE,
+				Version: 2,
+			}})
+		gock.New(utils.DefaultApiHost).
+			Post("/v1/projects/" + project + "/database/query").
+			Reply(http.StatusBadRequest).
+			JSON(map[string]string{"message": "permission denied"})
 		// Run test
 		err := Run(context.Background(), project, fsys)
 		// Check error
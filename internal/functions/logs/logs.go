@@ -0,0 +1,43 @@
+package logs
+
+import (
+	"context"
+	"os"
+	"strconv"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Tail of 0 shows all lines since the runtime started.
+func RunLocal(ctx context.Context, follow bool, tail uint, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	if err := utils.AssertServiceIsRunning(ctx, utils.EdgeRuntimeId); err != nil {
+		return err
+	}
+	if tail == 0 {
+		if follow {
+			return utils.DockerStreamLogs(ctx, utils.EdgeRuntimeId, os.Stdout, os.Stderr)
+		}
+		return utils.DockerStreamLogsOnce(ctx, utils.EdgeRuntimeId, os.Stdout, os.Stderr)
+	}
+	logs, err := utils.Docker.ContainerLogs(ctx, utils.EdgeRuntimeId, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     follow,
+		Tail:       strconv.FormatUint(uint64(tail), 10),
+	})
+	if err != nil {
+		return errors.Errorf("failed to read docker logs: %w", err)
+	}
+	defer logs.Close()
+	if _, err := stdcopy.StdCopy(os.Stdout, os.Stderr, logs); err != nil {
+		return errors.Errorf("failed to copy docker logs: %w", err)
+	}
+	return nil
+}
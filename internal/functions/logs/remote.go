@@ -0,0 +1,171 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+// Column names of function_logs metadata that may be filtered on.
+var filterColumns = []string{"level", "event_type"}
+
+var (
+	filterValuePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+	// Analytics endpoint rate limits requests per project.
+	PollInterval = 5 * time.Second
+)
+
+type logEntry struct {
+	Id        string
+	Timestamp time.Time
+	Level     string
+	Message   string
+}
+
+// Streams logs of a deployed function from the platform analytics endpoint.
+func RunRemote(ctx context.Context, slug, projectRef string, follow bool, since time.Duration, filters []string, fsys afero.Fs) error {
+	if err := utils.ValidateFunctionSlug(slug); err != nil {
+		return err
+	}
+	where, err := parseFilters(filters)
+	if err != nil {
+		return err
+	}
+	functionId, err := getFunctionId(ctx, projectRef, slug)
+	if err != nil {
+		return err
+	}
+	sql := buildQuery(functionId, where)
+	end := time.Now().UTC()
+	start := end.Add(-since)
+	// Timestamps of printed entries, keyed by id
+	seen := map[string]time.Time{}
+	for {
+		entries, err := queryLogs(ctx, projectRef, sql, start, end)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if _, ok := seen[e.Id]; ok {
+				continue
+			}
+			seen[e.Id] = e.Timestamp
+			printEntry(e, os.Stdout)
+			if e.Timestamp.After(start) {
+				start = e.Timestamp
+			}
+		}
+		pruneSeen(seen, start)
+		if !follow {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(PollInterval):
+		}
+		end = time.Now().UTC()
+	}
+}
+
+// Only entries at or after the start of the next poll window can be returned again.
+func pruneSeen(seen map[string]time.Time, start time.Time) {
+	for id, ts := range seen {
+		if ts.Before(start) {
+			delete(seen, id)
+		}
+	}
+}
+
+func parseFilters(filters []string) ([]string, error) {
+	var where []string
+	for _, f := range filters {
+		key, value, found := strings.Cut(f, "=")
+		if !found || !utils.SliceContains(filterColumns, key) {
+			return nil, errors.Errorf("Invalid filter %s: must be one of %s formatted as key=value", utils.Aqua(f), strings.Join(filterColumns, ", "))
+		}
+		if !filterValuePattern.MatchString(value) {
+			return nil, errors.Errorf("Invalid filter value: %s", utils.Aqua(value))
+		}
+		where = append(where, fmt.Sprintf("metadata.%s = '%s'", key, value))
+	}
+	return where, nil
+}
+
+func getFunctionId(ctx context.Context, projectRef, slug string) (string, error) {
+	resp, err := utils.GetSupabase().V1GetAFunctionWithResponse(ctx, projectRef, slug)
+	if err != nil {
+		return "", errors.Errorf("failed to get function: %w", err)
+	}
+	switch resp.StatusCode() {
+	case http.StatusNotFound:
+		return "", errors.Errorf("Function %s does not exist on the Supabase project.", utils.Aqua(slug))
+	case http.StatusOK:
+		return resp.JSON200.Id, nil
+	default:
+		return "", errors.Errorf("Unexpected error retrieving Function: %s", string(resp.Body))
+	}
+}
+
+func buildQuery(functionId string, where []string) string {
+	conditions := append([]string{fmt.Sprintf("metadata.function_id = '%s'", functionId)}, where...)
+	return fmt.Sprintf(`select id, function_logs.timestamp, event_message, metadata.level
+from function_logs
+cross join unnest(metadata) as metadata
+where %s
+order by timestamp asc
+limit 1000`, strings.Join(conditions, " and "))
+}
+
+func queryLogs(ctx context.Context, projectRef, sql string, start, end time.Time) ([]logEntry, error) {
+	resp, err := utils.GetSupabase().V1GetProjectLogsWithResponse(ctx, projectRef, &api.V1GetProjectLogsParams{
+		Sql:               &sql,
+		IsoTimestampStart: &start,
+		IsoTimestampEnd:   &end,
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to query logs: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return nil, errors.New("Unexpected error retrieving logs: " + string(resp.Body))
+	}
+	if resp.JSON200.Error != nil && len(*resp.JSON200.Error) > 0 {
+		return nil, errors.Errorf("failed to query logs: %s", *resp.JSON200.Error)
+	}
+	if resp.JSON200.Result == nil {
+		return nil, nil
+	}
+	entries := make([]logEntry, len(*resp.JSON200.Result))
+	for i, row := range *resp.JSON200.Result {
+		entries[i] = logEntry{
+			Id:      fmt.Sprint(row["id"]),
+			Level:   fmt.Sprint(row["level"]),
+			Message: fmt.Sprint(row["event_message"]),
+		}
+		// Analytics timestamps are in microseconds since epoch
+		if ts, ok := row["timestamp"].(float64); ok {
+			entries[i].Timestamp = time.UnixMicro(int64(ts)).UTC()
+		}
+	}
+	return entries, nil
+}
+
+func printEntry(e logEntry, w io.Writer) {
+	level := strings.ToUpper(e.Level)
+	if level == "ERROR" {
+		level = utils.Red(level)
+	} else if level == "WARNING" {
+		level = utils.Yellow(level)
+	}
+	fmt.Fprintf(w, "%s %s %s\n", e.Timestamp.Format(time.RFC3339), level, strings.TrimRight(e.Message, "\n"))
+}
@@ -0,0 +1,140 @@
+package logs
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestRemoteLogs(t *testing.T) {
+	const slug = "test-func"
+
+	t.Run("queries deployed function logs", func(t *testing.T) {
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions/" + slug).
+			Reply(http.StatusOK).
+			JSON(api.FunctionSlugResponse{Id: "test-id", Slug: slug})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/"+project+"/analytics/endpoints/logs.all").
+			MatchParam("sql", "metadata.function_id = 'test-id' and metadata.level = 'error'").
+			MatchParam("iso_timestamp_start", ".+").
+			Reply(http.StatusOK).
+			JSON(api.V1AnalyticsResponse{Result: &[]map[string]interface{}{{
+				"id":            "log-1",
+				"timestamp":     1700000000000000,
+				"level":         "error",
+				"event_message": "boom\n",
+			}}})
+		// Run test
+		err := RunRemote(context.Background(), slug, project, false, time.Hour, []string{"level=error"}, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("follows until cancelled", func(t *testing.T) {
+		PollInterval = 10 * time.Millisecond
+		t.Cleanup(func() { PollInterval = 5 * time.Second })
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions/" + slug).
+			Reply(http.StatusOK).
+			JSON(api.FunctionSlugResponse{Id: "test-id", Slug: slug})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/analytics/endpoints/logs.all").
+			Persist().
+			Reply(http.StatusOK).
+			JSON(api.V1AnalyticsResponse{})
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		// Run test
+		err := RunRemote(ctx, slug, project, true, time.Hour, nil, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on invalid filter", func(t *testing.T) {
+		// Run test
+		err := RunRemote(context.Background(), slug, apitest.RandomProjectRef(), false, time.Hour, []string{"level=error' or 1=1"}, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Invalid filter value")
+	})
+
+	t.Run("throws error on missing function", func(t *testing.T) {
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions/" + slug).
+			Reply(http.StatusNotFound)
+		// Run test
+		err := RunRemote(context.Background(), slug, project, false, time.Hour, nil, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "does not exist on the Supabase project")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions/" + slug).
+			Reply(http.StatusOK).
+			JSON(api.FunctionSlugResponse{Id: "test-id", Slug: slug})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/analytics/endpoints/logs.all").
+			Reply(http.StatusOK).
+			JSON(map[string]string{"error": "syntax error"})
+		// Run test
+		err := RunRemote(context.Background(), slug, project, false, time.Hour, nil, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "syntax error")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestPruneSeen(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	seen := map[string]time.Time{
+		"old":    start.Add(-time.Second),
+		"same":   start,
+		"recent": start.Add(time.Second),
+	}
+	// Run test
+	pruneSeen(seen, start)
+	// Check result
+	assert.Equal(t, map[string]time.Time{
+		"same":   start,
+		"recent": start.Add(time.Second),
+	}, seen)
+}
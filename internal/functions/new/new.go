@@ -2,29 +2,58 @@ package new
 
 import (
 	"context"
-	_ "embed"
+	"embed"
 	"fmt"
-	"html/template"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
+	"text/template"
 
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/functions/deploy"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/cast"
+)
+
+const (
+	TemplateDefault         = "default"
+	TemplateWebSocket       = "websocket"
+	TemplateCors            = "cors"
+	TemplateStripeWebhook   = "stripe-webhook"
+	TemplateOpenAI          = "openai"
+	TemplateDatabaseWebhook = "database-webhook"
+	TemplateScheduled       = "scheduled"
 )
 
 var (
-	//go:embed templates/index.ts
-	indexEmbed    string
-	indexTemplate = template.Must(template.New("indexl").Parse(indexEmbed))
+	// Each template is a directory of files copied into the new function.
+	//go:embed templates
+	templatesEmbed embed.FS
+
+	Templates = []string{
+		TemplateDefault,
+		TemplateWebSocket,
+		TemplateCors,
+		TemplateStripeWebhook,
+		TemplateOpenAI,
+		TemplateDatabaseWebhook,
+		TemplateScheduled,
+	}
 )
 
 type indexConfig struct {
-	URL   string
-	Token string
+	Slug         string
+	URL          string
+	WebSocketURL string
+	// Matches SUPABASE_URL inside the edge runtime, which other containers on the network can reach
+	InternalURL string
+	Token       string
 }
 
-func Run(ctx context.Context, slug string, fsys afero.Fs) error {
+func Run(ctx context.Context, slug, templateName string, fsys afero.Fs) error {
 	// 1. Sanity checks.
 	funcDir := filepath.Join(utils.FunctionsDir, slug)
 	{
@@ -32,31 +61,61 @@ func Run(ctx context.Context, slug string, fsys afero.Fs) error {
 			return err
 		}
 	}
+	templateDir := path.Join("templates", templateName)
+	entries, err := fs.ReadDir(templatesEmbed, templateDir)
+	if err != nil {
+		return errors.Errorf("unknown function template %s: %w", templateName, err)
+	}
 
 	// 2. Create new function.
 	{
 		if err := utils.MkdirIfNotExistFS(fsys, funcDir); err != nil {
 			return err
 		}
-		path := filepath.Join(funcDir, "index.ts")
-		f, err := fsys.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
-		if err != nil {
-			return errors.Errorf("failed to create function entrypoint: %w", err)
-		}
-		defer f.Close()
 		// Templatize index.ts by config.toml if available
 		if err := utils.LoadConfigFS(fsys); err != nil {
 			utils.CmdSuggestion = ""
 		}
+		url := utils.GetApiUrl("/functions/v1/" + slug)
 		config := indexConfig{
-			URL:   utils.GetApiUrl("/functions/v1/" + slug),
-			Token: utils.Config.Auth.AnonKey,
+			Slug:         slug,
+			URL:          url,
+			WebSocketURL: "ws" + strings.TrimPrefix(url, "http"),
+			InternalURL:  fmt.Sprintf("http://%s:8000/functions/v1/%s", utils.KongAliases[0], slug),
+			Token:        utils.Config.Auth.AnonKey,
 		}
-		if err := indexTemplate.Option("missingkey=error").Execute(f, config); err != nil {
-			return errors.Errorf("failed to initialise function entrypoint: %w", err)
+		for _, e := range entries {
+			if err := copyTemplate(path.Join(templateDir, e.Name()), filepath.Join(funcDir, e.Name()), config, fsys); err != nil {
+				return err
+			}
+		}
+	}
+
+	// 3. Stripe webhooks do not send a Supabase JWT.
+	if templateName == TemplateStripeWebhook {
+		if exists, _ := afero.Exists(fsys, utils.ConfigPath); exists {
+			if err := deploy.SaveFunctionConfig([]string{slug}, cast.Ptr(true), "", fsys); err != nil {
+				return err
+			}
 		}
 	}
 
 	fmt.Println("Created new Function at " + utils.Bold(funcDir))
 	return nil
 }
+
+func copyTemplate(srcPath, dstPath string, config indexConfig, fsys afero.Fs) error {
+	tmpl, err := template.ParseFS(templatesEmbed, srcPath)
+	if err != nil {
+		return errors.Errorf("failed to parse function template: %w", err)
+	}
+	f, err := fsys.OpenFile(dstPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return errors.Errorf("failed to create function file: %w", err)
+	}
+	defer f.Close()
+	if err := tmpl.Option("missingkey=error").Execute(f, config); err != nil {
+		return errors.Errorf("failed to initialise function file: %w", err)
+	}
+	return nil
+}
@@ -16,7 +16,7 @@ func TestNewCommand(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		assert.NoError(t, Run(context.Background(), "test-func", fsys))
+		assert.NoError(t, Run(context.Background(), "test-func", TemplateDefault, fsys))
 		// Validate output
 		funcPath := filepath.Join(utils.FunctionsDir, "test-func", "index.ts")
 		content, err := afero.ReadFile(fsys, funcPath)
@@ -26,8 +26,71 @@ func TestNewCommand(t *testing.T) {
 		)
 	})
 
+	t.Run("creates new websocket function", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		assert.NoError(t, Run(context.Background(), "test-ws", TemplateWebSocket, fsys))
+		// Validate output
+		funcPath := filepath.Join(utils.FunctionsDir, "test-ws", "index.ts")
+		content, err := afero.ReadFile(fsys, funcPath)
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "Deno.upgradeWebSocket(req)")
+		assert.Contains(t, string(content), "websocat 'ws://127.0.0.1:54321/functions/v1/test-ws'")
+	})
+
+	t.Run("creates function from every template", func(t *testing.T) {
+		for _, name := range Templates {
+			// Setup in-memory fs
+			fsys := afero.NewMemMapFs()
+			// Run test
+			assert.NoError(t, Run(context.Background(), "test-"+name, name, fsys), name)
+			// Validate output
+			funcDir := filepath.Join(utils.FunctionsDir, "test-"+name)
+			content, err := afero.ReadFile(fsys, filepath.Join(funcDir, "index.ts"))
+			assert.NoError(t, err)
+			assert.Contains(t, string(content), "Deno.serve(")
+			if name == TemplateDefault {
+				continue
+			}
+			exists, err := afero.Exists(fsys, filepath.Join(funcDir, "deno.json"))
+			assert.NoError(t, err)
+			assert.True(t, exists, name)
+			test, err := afero.ReadFile(fsys, filepath.Join(funcDir, "index.test.ts"))
+			assert.NoError(t, err)
+			assert.Contains(t, string(test), "http://127.0.0.1:54321/functions/v1/test-"+name)
+		}
+	})
+
+	t.Run("disables jwt verification for stripe webhook", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.WriteConfig(fsys, false))
+		// Run test
+		assert.NoError(t, Run(context.Background(), "test-stripe", TemplateStripeWebhook, fsys))
+		// Validate output
+		config, err := afero.ReadFile(fsys, utils.ConfigPath)
+		assert.NoError(t, err)
+		assert.Contains(t, string(config), "[functions.test-stripe]\nverify_jwt = false\n")
+	})
+
+	t.Run("schedules function through internal url", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		assert.NoError(t, Run(context.Background(), "test-cron", TemplateScheduled, fsys))
+		// Validate output
+		content, err := afero.ReadFile(fsys, filepath.Join(utils.FunctionsDir, "test-cron", "index.ts"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(content), "url := 'http://kong:8000/functions/v1/test-cron'")
+	})
+
+	t.Run("throws error on unknown template", func(t *testing.T) {
+		assert.ErrorContains(t, Run(context.Background(), "test-func", "unknown", afero.NewMemMapFs()), "unknown function template")
+	})
+
 	t.Run("throws error on malformed slug", func(t *testing.T) {
-		assert.Error(t, Run(context.Background(), "@", afero.NewMemMapFs()))
+		assert.Error(t, Run(context.Background(), "@", TemplateDefault, afero.NewMemMapFs()))
 	})
 
 	t.Run("throws error on duplicate slug", func(t *testing.T) {
@@ -36,13 +99,13 @@ func TestNewCommand(t *testing.T) {
 		funcPath := filepath.Join(utils.FunctionsDir, "test-func", "index.ts")
 		require.NoError(t, afero.WriteFile(fsys, funcPath, []byte{}, 0644))
 		// Run test
-		assert.Error(t, Run(context.Background(), "test-func", fsys))
+		assert.Error(t, Run(context.Background(), "test-func", TemplateDefault, fsys))
 	})
 
 	t.Run("throws error on permission denied", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewReadOnlyFs(afero.NewMemMapFs())
 		// Run test
-		assert.Error(t, Run(context.Background(), "test-func", fsys))
+		assert.Error(t, Run(context.Background(), "test-func", TemplateDefault, fsys))
 	})
 }
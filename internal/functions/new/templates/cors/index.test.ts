@@ -0,0 +1,21 @@
+// Run with `deno test --allow-net --allow-env` while `supabase functions serve` is running.
+import { assertEquals } from "@std/assert"
+
+const url = Deno.env.get("FUNCTION_URL") ?? "{{ .URL }}"
+const token = Deno.env.get("SUPABASE_ANON_KEY") ?? "{{ .Token }}"
+
+Deno.test("responds to preflight requests", async () => {
+  const res = await fetch(url, { method: "OPTIONS" })
+  await res.body?.cancel()
+  assertEquals(res.status, 200)
+  assertEquals(res.headers.get("Access-Control-Allow-Origin"), "*")
+})
+
+Deno.test("greets by name", async () => {
+  const res = await fetch(url, {
+    method: "POST",
+    headers: { "Authorization": `Bearer ${token}`, "Content-Type": "application/json" },
+    body: JSON.stringify({ name: "Functions" }),
+  })
+  assertEquals(await res.json(), { message: "Hello Functions!" })
+})
@@ -0,0 +1,20 @@
+// Run with `deno test --allow-net --allow-env` while `supabase functions serve` is running.
+import { assertEquals } from "@std/assert"
+
+const url = Deno.env.get("FUNCTION_URL") ?? "{{ .URL }}"
+const token = Deno.env.get("SUPABASE_ANON_KEY") ?? "{{ .Token }}"
+
+Deno.test("acknowledges update events", async () => {
+  const res = await fetch(url, {
+    method: "POST",
+    headers: { "Authorization": `Bearer ${token}`, "Content-Type": "application/json" },
+    body: JSON.stringify({
+      type: "UPDATE",
+      table: "profiles",
+      schema: "public",
+      record: { id: 1 },
+      old_record: { id: 1 },
+    }),
+  })
+  assertEquals(await res.json(), { ok: true })
+})
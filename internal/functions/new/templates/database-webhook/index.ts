@@ -0,0 +1,60 @@
+// Follow this setup guide to integrate the Deno language server with your editor:
+// https://deno.land/manual/getting_started/setup_your_environment
+// This enables autocomplete, go to definition, etc.
+
+// Setup type definitions for built-in Supabase Runtime APIs
+import "jsr:@supabase/functions-js/edge-runtime.d.ts"
+
+import { createClient } from "@supabase/supabase-js"
+
+// Mirrors the payload sent by Database Webhooks
+// https://supabase.com/docs/guides/database/webhooks#payload
+interface WebhookPayload {
+  type: "INSERT" | "UPDATE" | "DELETE"
+  table: string
+  schema: string
+  record: Record<string, unknown> | null
+  old_record: Record<string, unknown> | null
+}
+
+const supabase = createClient(
+  Deno.env.get("SUPABASE_URL") as string,
+  Deno.env.get("SUPABASE_SERVICE_ROLE_KEY") as string,
+)
+
+Deno.serve(async (req) => {
+  const payload: WebhookPayload = await req.json()
+  console.log(`Received ${payload.type} on ${payload.schema}.${payload.table}`)
+
+  if (payload.type === "INSERT" && payload.record) {
+    // Replace with your own side effect, ie. sending an email or syncing a search index
+    const { error } = await supabase.from(payload.table).select().limit(1)
+    if (error) {
+      return new Response(JSON.stringify({ error: error.message }), {
+        status: 500,
+        headers: { "Content-Type": "application/json" },
+      })
+    }
+  }
+
+  return new Response(JSON.stringify({ ok: true }), {
+    headers: { "Content-Type": "application/json" },
+  })
+})
+
+/* To trigger locally:
+
+  1. Run `supabase start` (see: https://supabase.com/docs/reference/cli/supabase-start)
+  2. Create a webhook on your table, ie. in a migration:
+
+  create trigger on_insert
+    after insert on public.your_table
+    for each row execute function supabase_functions.http_request(
+      'http://host.docker.internal:54321/functions/v1/{{ .Slug }}',
+      'POST',
+      '{"Content-Type":"application/json","Authorization":"Bearer {{ .Token }}"}',
+      '{}',
+      '1000'
+    );
+
+*/
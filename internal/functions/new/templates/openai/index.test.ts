@@ -0,0 +1,15 @@
+// Run with `deno test --allow-net --allow-env` while `supabase functions serve` is running.
+import { assertEquals } from "@std/assert"
+
+const url = Deno.env.get("FUNCTION_URL") ?? "{{ .URL }}"
+const token = Deno.env.get("SUPABASE_ANON_KEY") ?? "{{ .Token }}"
+
+Deno.test("requires a prompt", async () => {
+  const res = await fetch(url, {
+    method: "POST",
+    headers: { "Authorization": `Bearer ${token}`, "Content-Type": "application/json" },
+    body: JSON.stringify({}),
+  })
+  assertEquals(res.status, 400)
+  assertEquals(await res.json(), { error: "prompt is required" })
+})
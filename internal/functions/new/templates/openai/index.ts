@@ -0,0 +1,43 @@
+// Follow this setup guide to integrate the Deno language server with your editor:
+// https://deno.land/manual/getting_started/setup_your_environment
+// This enables autocomplete, go to definition, etc.
+
+// Setup type definitions for built-in Supabase Runtime APIs
+import "jsr:@supabase/functions-js/edge-runtime.d.ts"
+
+import OpenAI from "openai"
+
+const openai = new OpenAI({ apiKey: Deno.env.get("OPENAI_API_KEY") })
+
+Deno.serve(async (req) => {
+  const { prompt } = await req.json()
+  if (typeof prompt !== "string" || prompt.length === 0) {
+    return new Response(JSON.stringify({ error: "prompt is required" }), {
+      status: 400,
+      headers: { "Content-Type": "application/json" },
+    })
+  }
+
+  const completion = await openai.chat.completions.create({
+    model: "gpt-4o-mini",
+    messages: [{ role: "user", content: prompt }],
+  })
+
+  return new Response(
+    JSON.stringify({ reply: completion.choices[0].message.content }),
+    { headers: { "Content-Type": "application/json" } },
+  )
+})
+
+/* To invoke locally:
+
+  1. Set OPENAI_API_KEY in supabase/functions/.env
+  2. Run `supabase functions serve --env-file supabase/functions/.env`
+  3. Make an HTTP request:
+
+  curl -i --location --request POST '{{ .URL }}' \
+    --header 'Authorization: Bearer {{ .Token }}' \
+    --header 'Content-Type: application/json' \
+    --data '{"prompt":"Say hello to Functions"}'
+
+*/
@@ -0,0 +1,15 @@
+// Run with `deno test --allow-net --allow-env` while `supabase functions serve` is running.
+import { assertEquals } from "@std/assert"
+
+const url = Deno.env.get("FUNCTION_URL") ?? "{{ .URL }}"
+const token = Deno.env.get("SUPABASE_ANON_KEY") ?? "{{ .Token }}"
+
+Deno.test("runs the scheduled job", async () => {
+  const res = await fetch(url, {
+    method: "POST",
+    headers: { "Authorization": `Bearer ${token}`, "Content-Type": "application/json" },
+    body: JSON.stringify({ name: "test" }),
+  })
+  const { name } = await res.json()
+  assertEquals(name, "test")
+})
@@ -0,0 +1,40 @@
+// Follow this setup guide to integrate the Deno language server with your editor:
+// https://deno.land/manual/getting_started/setup_your_environment
+// This enables autocomplete, go to definition, etc.
+
+// Setup type definitions for built-in Supabase Runtime APIs
+import "jsr:@supabase/functions-js/edge-runtime.d.ts"
+
+Deno.serve(async (req) => {
+  const { name } = await req.json().catch(() => ({ name: "cron" }))
+  const startedAt = new Date().toISOString()
+  console.log(`Running scheduled job ${name} at ${startedAt}`)
+
+  // Replace with your recurring work, ie. cleaning up expired rows
+
+  return new Response(
+    JSON.stringify({ name, startedAt }),
+    { headers: { "Content-Type": "application/json" } },
+  )
+})
+
+/* To schedule locally:
+
+  1. Run `supabase start` (see: https://supabase.com/docs/reference/cli/supabase-start)
+  2. Enable the pg_cron and pg_net extensions, then schedule the function in a migration.
+     The url uses the same host as SUPABASE_URL in your functions, so it does not depend
+     on the api port in supabase/config.toml:
+
+  select cron.schedule(
+    '{{ .Slug }}-every-minute',
+    '* * * * *',
+    $$
+    select net.http_post(
+      url := '{{ .InternalURL }}',
+      headers := '{"Content-Type":"application/json","Authorization":"Bearer {{ .Token }}"}'::jsonb,
+      body := '{"name":"{{ .Slug }}"}'::jsonb
+    );
+    $$
+  );
+
+*/
@@ -0,0 +1,20 @@
+// Run with `deno test --allow-net --allow-env` while `supabase functions serve` is running.
+import { assertEquals } from "@std/assert"
+
+const url = Deno.env.get("FUNCTION_URL") ?? "{{ .URL }}"
+
+Deno.test("rejects unsigned requests", async () => {
+  const res = await fetch(url, { method: "POST", body: "{}" })
+  await res.body?.cancel()
+  assertEquals(res.status, 400)
+})
+
+Deno.test("rejects invalid signatures", async () => {
+  const res = await fetch(url, {
+    method: "POST",
+    headers: { "Stripe-Signature": "t=0,v1=invalid" },
+    body: "{}",
+  })
+  await res.body?.cancel()
+  assertEquals(res.status, 400)
+})
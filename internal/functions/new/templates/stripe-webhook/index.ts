@@ -0,0 +1,58 @@
+// Follow this setup guide to integrate the Deno language server with your editor:
+// https://deno.land/manual/getting_started/setup_your_environment
+// This enables autocomplete, go to definition, etc.
+
+// Setup type definitions for built-in Supabase Runtime APIs
+import "jsr:@supabase/functions-js/edge-runtime.d.ts"
+
+import Stripe from "stripe"
+
+const stripe = new Stripe(Deno.env.get("STRIPE_API_KEY") as string)
+// Stripe signatures are verified with the Web Crypto API in Deno
+const cryptoProvider = Stripe.createSubtleCryptoProvider()
+
+Deno.serve(async (req) => {
+  const signature = req.headers.get("Stripe-Signature")
+  if (!signature) {
+    return new Response("Missing Stripe-Signature header", { status: 400 })
+  }
+
+  // The raw body is required to verify the signature
+  const body = await req.text()
+  let event: Stripe.Event
+  try {
+    event = await stripe.webhooks.constructEventAsync(
+      body,
+      signature,
+      Deno.env.get("STRIPE_WEBHOOK_SIGNING_SECRET") as string,
+      undefined,
+      cryptoProvider,
+    )
+  } catch (error) {
+    return new Response(String(error), { status: 400 })
+  }
+
+  switch (event.type) {
+    case "checkout.session.completed":
+      console.log("Checkout completed:", event.data.object.id)
+      break
+    default:
+      console.log("Unhandled event type:", event.type)
+  }
+
+  return new Response(JSON.stringify({ received: true }), {
+    headers: { "Content-Type": "application/json" },
+  })
+})
+
+/* To invoke locally:
+
+  1. Set STRIPE_API_KEY and STRIPE_WEBHOOK_SIGNING_SECRET in supabase/functions/.env
+  2. Stripe webhooks do not send a Supabase JWT, so check that `verify_jwt = false`
+     is set for this function in supabase/config.toml
+  3. Run `supabase functions serve --env-file supabase/functions/.env`
+  4. Forward events with the Stripe CLI (see: https://stripe.com/docs/stripe-cli):
+
+  stripe listen --forward-to '{{ .URL }}'
+
+*/
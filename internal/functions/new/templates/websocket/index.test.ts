@@ -0,0 +1,37 @@
+// Run with `deno test --allow-net --allow-env` while `supabase functions serve` is running.
+import { assertEquals, assertStringIncludes } from "@std/assert"
+
+const url = Deno.env.get("FUNCTION_URL") ?? "{{ .URL }}"
+const token = Deno.env.get("SUPABASE_ANON_KEY") ?? "{{ .Token }}"
+
+Deno.test("rejects plain requests", async () => {
+  const res = await fetch(url, { headers: { "Authorization": `Bearer ${token}` } })
+  await res.body?.cancel()
+  assertEquals(res.status, 426)
+})
+
+// The WebSocket client API cannot set an Authorization header, so the handshake is sent by hand
+Deno.test("upgrades to a websocket", async () => {
+  const { hostname, port, pathname } = new URL(url)
+  const conn = await Deno.connect({ hostname, port: Number(port || 80) })
+  try {
+    const handshake = [
+      `GET ${pathname} HTTP/1.1`,
+      `Host: ${hostname}:${port}`,
+      "Upgrade: websocket",
+      "Connection: Upgrade",
+      "Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==",
+      "Sec-WebSocket-Version: 13",
+      `Authorization: Bearer ${token}`,
+      "",
+      "",
+    ].join("\r\n")
+    await conn.write(new TextEncoder().encode(handshake))
+    const buf = new Uint8Array(1024)
+    const n = await conn.read(buf) ?? 0
+    const [status] = new TextDecoder().decode(buf.subarray(0, n)).split("\r\n")
+    assertStringIncludes(status, " 101 ")
+  } finally {
+    conn.close()
+  }
+})
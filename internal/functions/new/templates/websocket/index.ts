@@ -0,0 +1,36 @@
+// Follow this setup guide to integrate the Deno language server with your editor:
+// https://deno.land/manual/getting_started/setup_your_environment
+// This enables autocomplete, go to definition, etc.
+
+// Setup type definitions for built-in Supabase Runtime APIs
+import "jsr:@supabase/functions-js/edge-runtime.d.ts"
+
+console.log("Hello from WebSocket Functions!")
+
+Deno.serve((req) => {
+  if (req.headers.get("upgrade")?.toLowerCase() !== "websocket") {
+    return new Response("Expected a WebSocket upgrade request", { status: 426 })
+  }
+
+  const { socket, response } = Deno.upgradeWebSocket(req)
+  socket.onopen = () => console.log("socket opened")
+  socket.onmessage = (e) => socket.send(`echo: ${e.data}`)
+  socket.onclose = () => console.log("socket closed")
+  socket.onerror = (e) => console.error("socket error:", e)
+
+  return response
+})
+
+/* To invoke locally:
+
+  1. Run `supabase start` (see: https://supabase.com/docs/reference/cli/supabase-start)
+  2. Open a WebSocket connection, ie. with https://github.com/vi/websocat:
+
+  websocat '{{ .WebSocketURL }}' \
+    --header 'Authorization: Bearer {{ .Token }}'
+
+  Browsers cannot set the Authorization header on WebSocket connections, so
+  you may want to set `verify_jwt = false` for this function and authenticate
+  inside the handler instead.
+
+*/
@@ -0,0 +1,96 @@
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Mirrors the NDJSON format written by main.ts when serving with --record, with base64 encoded bodies.
+type RecordedRequest struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Headers map[string]string `json:"headers"`
+	Body    []byte            `json:"body,omitempty"`
+}
+
+// Headers that are set by the http client or kong on replay.
+var skipHeaders = []string{"host", "content-length", "connection", "transfer-encoding"}
+
+func Run(ctx context.Context, recordPath string, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	f, err := fsys.Open(recordPath)
+	if err != nil {
+		return errors.Errorf("failed to open record file: %w", err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	// Request bodies may exceed the default token size
+	scanner.Buffer(nil, 10*1024*1024)
+	var count, failed int
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 {
+			continue
+		}
+		var recorded RecordedRequest
+		if err := json.Unmarshal([]byte(line), &recorded); err != nil {
+			return errors.Errorf("failed to parse record on line %d: %w", lineNum, err)
+		}
+		status, err := replay(ctx, recorded)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s %s %d\n", recorded.Method, recorded.Path, status)
+		count++
+		if status >= http.StatusInternalServerError {
+			failed++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return errors.Errorf("failed to read record file: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Replayed %d requests with %d server errors.\n", count, failed)
+	if failed > 0 {
+		return errors.Errorf("%d replayed requests failed", failed)
+	}
+	return nil
+}
+
+func replay(ctx context.Context, recorded RecordedRequest) (int, error) {
+	var body io.Reader
+	if len(recorded.Body) > 0 {
+		body = bytes.NewReader(recorded.Body)
+	}
+	url := utils.GetApiUrl("/functions/v1" + recorded.Path)
+	req, err := http.NewRequestWithContext(ctx, recorded.Method, url, body)
+	if err != nil {
+		return 0, errors.Errorf("failed to create request: %w", err)
+	}
+	for name, value := range recorded.Headers {
+		if !utils.SliceContains(skipHeaders, strings.ToLower(name)) {
+			req.Header.Set(name, value)
+		}
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, errors.Errorf("failed to replay request: %w", err)
+	}
+	defer resp.Body.Close()
+	if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+		return 0, errors.Errorf("failed to read response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,103 @@
+package replay
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestReplayCommand(t *testing.T) {
+	const records = `{"method":"POST","path":"/hello?name=world","headers":{"authorization":"Bearer token","content-length":"2","host":"kong"},"body":"e30="}
+
+{"method":"GET","path":"/goodbye","headers":{}}
+`
+
+	t.Run("replays recorded requests", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		require.NoError(t, afero.WriteFile(fsys, "requests.ndjson", []byte(records), 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1:54321").
+			Post("/functions/v1/hello").
+			MatchParam("name", "world").
+			MatchHeader("Authorization", "Bearer token").
+			BodyString("{}").
+			Reply(http.StatusOK)
+		gock.New("http://127.0.0.1:54321").
+			Get("/functions/v1/goodbye").
+			Reply(http.StatusNotFound)
+		// Run test
+		err := Run(context.Background(), "requests.ndjson", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("replays binary body", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		require.NoError(t, afero.WriteFile(fsys, "requests.ndjson", []byte(`{"method":"POST","path":"/upload","headers":{},"body":"AP+A"}`), 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1:54321").
+			Post("/functions/v1/upload").
+			BodyString("\x00\xff\x80").
+			Reply(http.StatusOK)
+		// Run test
+		err := Run(context.Background(), "requests.ndjson", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on server failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		require.NoError(t, afero.WriteFile(fsys, "requests.ndjson", []byte(records), 0644))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New("http://127.0.0.1:54321").
+			Post("/functions/v1/hello").
+			Reply(http.StatusInternalServerError)
+		gock.New("http://127.0.0.1:54321").
+			Get("/functions/v1/goodbye").
+			Reply(http.StatusOK)
+		// Run test
+		err := Run(context.Background(), "requests.ndjson", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "1 replayed requests failed")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on malformed record", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		require.NoError(t, afero.WriteFile(fsys, "requests.ndjson", []byte("{"), 0644))
+		// Run test
+		err := Run(context.Background(), "requests.ndjson", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to parse record on line 1")
+	})
+
+	t.Run("throws error on missing file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Run test
+		err := Run(context.Background(), "requests.ndjson", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to open record file")
+	})
+}
@@ -0,0 +1,95 @@
+package serve
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const maxLogBackups = 3
+
+// Appends to a log file, rotating it to numbered backups once it grows past
+// maxSize bytes or has been open for longer than maxAge.
+type rotatingFile struct {
+	mu      sync.Mutex
+	fsys    afero.Fs
+	path    string
+	maxSize int64
+	maxAge  time.Duration
+	file    afero.File
+	size    int64
+	opened  time.Time
+}
+
+func openRotatingFile(path string, maxSize int64, maxAge time.Duration, fsys afero.Fs) (*rotatingFile, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(utils.CurrentDirAbs, path)
+	}
+	if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	r := &rotatingFile{fsys: fsys, path: path, maxSize: maxSize, maxAge: maxAge}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	f, err := r.fsys.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return errors.Errorf("failed to open log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return errors.Errorf("failed to stat log file: %w", err)
+	}
+	r.file, r.size, r.opened = f, info.Size(), time.Now()
+	return nil
+}
+
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return errors.Errorf("failed to close log file: %w", err)
+	}
+	// Shift older backups up, dropping the oldest
+	for i := maxLogBackups - 1; i > 0; i-- {
+		src := fmt.Sprintf("%s.%d", r.path, i)
+		if _, err := r.fsys.Stat(src); err == nil {
+			if err := r.fsys.Rename(src, fmt.Sprintf("%s.%d", r.path, i+1)); err != nil {
+				return errors.Errorf("failed to rotate log file: %w", err)
+			}
+		}
+	}
+	if err := r.fsys.Rename(r.path, r.path+".1"); err != nil {
+		return errors.Errorf("failed to rotate log file: %w", err)
+	}
+	return r.open()
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	expired := r.maxAge > 0 && time.Since(r.opened) > r.maxAge
+	oversized := r.maxSize > 0 && r.size+int64(len(p)) > r.maxSize
+	if r.size > 0 && (expired || oversized) {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
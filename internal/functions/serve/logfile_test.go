@@ -0,0 +1,72 @@
+package serve
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotatingFile(t *testing.T) {
+	t.Run("appends to existing log file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/logs/serve.log", []byte("hello\n"), 0644))
+		// Run test
+		f, err := openRotatingFile("/logs/serve.log", 0, 0, fsys)
+		require.NoError(t, err)
+		_, err = f.Write([]byte("world\n"))
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+		// Check output
+		data, err := afero.ReadFile(fsys, "/logs/serve.log")
+		assert.NoError(t, err)
+		assert.Equal(t, "hello\nworld\n", string(data))
+	})
+
+	t.Run("rotates on max size", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		f, err := openRotatingFile("/logs/serve.log", 8, 0, fsys)
+		require.NoError(t, err)
+		for _, line := range []string{"first\n", "second\n", "third\n"} {
+			_, err = f.Write([]byte(line))
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, f.Close())
+		// Check output
+		data, err := afero.ReadFile(fsys, "/logs/serve.log")
+		assert.NoError(t, err)
+		assert.Equal(t, "third\n", string(data))
+		data, err = afero.ReadFile(fsys, "/logs/serve.log.1")
+		assert.NoError(t, err)
+		assert.Equal(t, "second\n", string(data))
+		data, err = afero.ReadFile(fsys, "/logs/serve.log.2")
+		assert.NoError(t, err)
+		assert.Equal(t, "first\n", string(data))
+	})
+
+	t.Run("rotates on max age", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		f, err := openRotatingFile("/logs/serve.log", 0, time.Hour, fsys)
+		require.NoError(t, err)
+		_, err = f.Write([]byte("old\n"))
+		assert.NoError(t, err)
+		f.opened = time.Now().Add(-2 * time.Hour)
+		_, err = f.Write([]byte("new\n"))
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+		// Check output
+		data, err := afero.ReadFile(fsys, "/logs/serve.log")
+		assert.NoError(t, err)
+		assert.Equal(t, "new\n", string(data))
+		exists, err := afero.Exists(fsys, "/logs/serve.log.1")
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+}
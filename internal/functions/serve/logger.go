@@ -0,0 +1,126 @@
+package serve
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	// Matches the lines logged by main.ts around forwarding a request to user worker
+	requestPattern = regexp.MustCompile(`^(serving|finished) the request with \S+ function=(\S+) request=(\S+)`)
+	prefixColors   = []lipgloss.Color{"14", "13", "11", "10", "12", "9"}
+	// Matches module downloads and runtime chatter hidden in quiet mode
+	quietPattern = regexp.MustCompile(`^(Download |main function started|serving the request with |finished the request with )`)
+)
+
+// Tags each log line with the slug of the function handling requests. User worker
+// output is not tagged by the runtime, so lines are only attributed to a function
+// while it is the only one with requests in flight.
+type functionLogger struct {
+	quiet  bool
+	mu     sync.Mutex
+	last   string
+	active map[string]string
+	styles map[string]lipgloss.Style
+}
+
+func newFunctionLogger(quiet bool) *functionLogger {
+	return &functionLogger{
+		quiet:  quiet,
+		active: map[string]string{},
+		styles: map[string]lipgloss.Style{},
+	}
+}
+
+func (l *functionLogger) Writer(w io.Writer) io.WriteCloser {
+	return &prefixWriter{logger: l, w: w}
+}
+
+func (l *functionLogger) prefix(line []byte) string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var slug string
+	if matches := requestPattern.FindSubmatch(line); len(matches) > 3 {
+		slug = string(matches[2])
+		if id := string(matches[3]); string(matches[1]) == "serving" {
+			l.active[id] = slug
+		} else {
+			delete(l.active, id)
+		}
+		l.last = slug
+	} else {
+		slug = l.current()
+	}
+	if len(slug) == 0 {
+		return ""
+	}
+	style, ok := l.styles[slug]
+	if !ok {
+		color := prefixColors[len(l.styles)%len(prefixColors)]
+		style = lipgloss.NewStyle().Foreground(color)
+		l.styles[slug] = style
+	}
+	return style.Render("["+slug+"]") + " "
+}
+
+func (l *functionLogger) current() string {
+	var slug string
+	for _, s := range l.active {
+		if len(slug) > 0 && s != slug {
+			return ""
+		}
+		slug = s
+	}
+	if len(slug) == 0 {
+		return l.last
+	}
+	return slug
+}
+
+type prefixWriter struct {
+	logger *functionLogger
+	w      io.Writer
+	buf    []byte
+}
+
+func (p *prefixWriter) Write(b []byte) (int, error) {
+	p.buf = append(p.buf, b...)
+	for {
+		i := bytes.IndexByte(p.buf, '\n')
+		if i < 0 {
+			break
+		}
+		line := p.buf[:i+1]
+		p.buf = p.buf[i+1:]
+		if err := p.writeLine(line); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+func (p *prefixWriter) writeLine(line []byte) error {
+	prefix := p.logger.prefix(line)
+	if p.logger.quiet && quietPattern.Match(line) {
+		return nil
+	}
+	if _, err := io.WriteString(p.w, prefix); err != nil {
+		return err
+	}
+	_, err := p.w.Write(line)
+	return err
+}
+
+// Flushes any trailing line that was not terminated by a newline.
+func (p *prefixWriter) Close() error {
+	if len(p.buf) == 0 {
+		return nil
+	}
+	line := p.buf
+	p.buf = nil
+	return p.writeLine(line)
+}
@@ -0,0 +1,93 @@
+package serve
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFunctionLogger(t *testing.T) {
+	t.Run("prefixes lines with function slug", func(t *testing.T) {
+		logger := newFunctionLogger(false)
+		var stdout, stderr bytes.Buffer
+		outW, errW := logger.Writer(&stdout), logger.Writer(&stderr)
+		// Run test
+		fmt.Fprintln(outW, "Serving functions on http://127.0.0.1:54321")
+		fmt.Fprintln(errW, "serving the request with supabase/functions/hello function=hello request=a1")
+		fmt.Fprint(outW, "hello ")
+		fmt.Fprintln(outW, "world")
+		fmt.Fprintln(errW, "finished the request with supabase/functions/hello function=hello request=a1")
+		fmt.Fprintln(errW, "serving the request with supabase/functions/goodbye function=goodbye request=b2")
+		fmt.Fprintln(outW, "bye")
+		// Check output
+		assert.NotContains(t, stdout.String(), "[hello] Serving functions")
+		assert.Contains(t, stdout.String(), "[hello] hello world\n")
+		assert.Contains(t, stdout.String(), "[goodbye] bye\n")
+		assert.Contains(t, stderr.String(), "[hello] serving the request with supabase/functions/hello")
+	})
+
+	t.Run("maps service path to function name", func(t *testing.T) {
+		logger := newFunctionLogger(false)
+		var stdout bytes.Buffer
+		w := logger.Writer(&stdout)
+		// Run test
+		fmt.Fprintln(w, "serving the request with supabase/functions/shared/api function=hello-world request=a1")
+		fmt.Fprintln(w, "hello")
+		// Check output
+		assert.Contains(t, stdout.String(), "[hello-world] hello\n")
+		assert.NotContains(t, stdout.String(), "[api]")
+	})
+
+	t.Run("skips prefix on concurrent requests", func(t *testing.T) {
+		logger := newFunctionLogger(false)
+		var stdout bytes.Buffer
+		w := logger.Writer(&stdout)
+		// Run test
+		fmt.Fprintln(w, "serving the request with supabase/functions/hello function=hello request=a1")
+		fmt.Fprintln(w, "serving the request with supabase/functions/goodbye function=goodbye request=b2")
+		fmt.Fprintln(w, "ambiguous")
+		fmt.Fprintln(w, "finished the request with supabase/functions/goodbye function=goodbye request=b2")
+		fmt.Fprintln(w, "still hello")
+		// Check output
+		assert.Contains(t, stdout.String(), "\nambiguous\n")
+		assert.Contains(t, stdout.String(), "[hello] still hello\n")
+	})
+
+	t.Run("flushes partial line on close", func(t *testing.T) {
+		logger := newFunctionLogger(false)
+		var stdout bytes.Buffer
+		w := logger.Writer(&stdout)
+		fmt.Fprintln(w, "serving the request with supabase/functions/hello function=hello request=a1")
+		fmt.Fprint(w, "no newline")
+		// Run test
+		assert.NoError(t, w.Close())
+		// Check output
+		assert.Contains(t, stdout.String(), "[hello] no newline")
+	})
+
+	t.Run("hides runtime noise in quiet mode", func(t *testing.T) {
+		logger := newFunctionLogger(true)
+		var stdout, stderr bytes.Buffer
+		outW, errW := logger.Writer(&stdout), logger.Writer(&stderr)
+		// Run test
+		fmt.Fprintln(errW, "Download https://deno.land/std/http/server.ts")
+		fmt.Fprintln(errW, "serving the request with supabase/functions/hello function=hello request=a1")
+		fmt.Fprintln(outW, "hello world")
+		fmt.Fprintln(errW, "finished the request with supabase/functions/hello function=hello request=a1")
+		// Check output
+		assert.Equal(t, "[hello] hello world\n", stdout.String())
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("assigns distinct colors", func(t *testing.T) {
+		logger := newFunctionLogger(false)
+		// Run test
+		logger.prefix([]byte("serving the request with /hello function=hello request=a1"))
+		logger.prefix([]byte("serving the request with /goodbye function=goodbye request=b2"))
+		// Check styles
+		assert.Len(t, logger.styles, 2)
+		assert.NotEqual(t, logger.styles["hello"].GetForeground(), logger.styles["goodbye"].GetForeground())
+	})
+}
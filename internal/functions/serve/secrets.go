@@ -0,0 +1,70 @@
+package serve
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/supabase/cli/internal/secrets/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/credentials"
+	"github.com/supabase/cli/pkg/api"
+)
+
+// The management API only returns digests of secret values, so linked secrets
+// missing from the local env are prompted for and verified against the digest.
+func loadLinkedSecrets(ctx context.Context, projectRef string, env []string, console *utils.Console) ([]string, error) {
+	secrets, err := list.GetSecretDigests(ctx, projectRef)
+	if err != nil {
+		return nil, err
+	}
+	localEnv := make(map[string]string, len(env))
+	for _, pair := range env {
+		if name, value, found := strings.Cut(pair, "="); found {
+			localEnv[name] = value
+		}
+	}
+	var missing []api.SecretResponse
+	for _, secret := range secrets {
+		// Reserved secrets are populated by the local stack
+		if strings.HasPrefix(secret.Name, "SUPABASE_") {
+			continue
+		}
+		if value, ok := localEnv[secret.Name]; !ok {
+			missing = append(missing, secret)
+		} else if getDigest(value) != secret.Value {
+			fmt.Fprintf(os.Stderr, "%s local value of %s differs from linked project.\n", utils.Yellow("WARNING:"), utils.Aqua(secret.Name))
+		}
+	}
+	if len(missing) == 0 {
+		return nil, nil
+	}
+	label := fmt.Sprintf("Linked project has %d secrets missing from your env file. Do you want to enter their values?", len(missing))
+	if shouldPrompt, err := console.PromptYesNo(ctx, label, false); err != nil {
+		return nil, err
+	} else if !shouldPrompt {
+		for _, secret := range missing {
+			fmt.Fprintln(os.Stderr, "Skipped linked secret:", secret.Name)
+		}
+		return nil, nil
+	}
+	var result []string
+	for _, secret := range missing {
+		fmt.Fprintf(os.Stderr, "Enter value of %s: ", utils.Aqua(secret.Name))
+		value := strings.TrimSpace(credentials.PromptMasked(os.Stdin))
+		if getDigest(value) != secret.Value {
+			fmt.Fprintf(os.Stderr, "%s value of %s does not match linked project, skipping.\n", utils.Yellow("WARNING:"), secret.Name)
+			continue
+		}
+		result = append(result, secret.Name+"="+value)
+	}
+	return result, nil
+}
+
+func getDigest(value string) string {
+	digest := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(digest[:])
+}
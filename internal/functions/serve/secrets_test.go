@@ -0,0 +1,59 @@
+package serve
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestLinkedSecrets(t *testing.T) {
+	// Setup valid project ref
+	project := apitest.RandomProjectRef()
+	// Setup valid access token
+	token := apitest.RandomAccessToken(t)
+	t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+
+	t.Run("skips missing secrets without confirmation", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusOK).
+			JSON([]api.SecretResponse{
+				{Name: "SUPABASE_URL", Value: getDigest("http://localhost")},
+				{Name: "API_KEY", Value: getDigest("secret")},
+				{Name: "WEBHOOK_SECRET", Value: getDigest("webhook")},
+			})
+		console := utils.NewConsole()
+		console.IsTTY = false
+		// Run test
+		secrets, err := loadLinkedSecrets(context.Background(), project, []string{"API_KEY=secret"}, console)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, secrets)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on network failure", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/secrets").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		_, err := loadLinkedSecrets(context.Background(), project, nil, utils.NewConsole())
+		// Check error
+		assert.ErrorContains(t, err, "Unexpected error retrieving project secrets")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestSecretDigest(t *testing.T) {
+	assert.Equal(t, "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b", getDigest("secret"))
+}
@@ -5,20 +5,28 @@ import (
 	_ "embed"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/errdefs"
 	"github.com/docker/go-connections/nat"
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
 	"github.com/spf13/viper"
+	"github.com/supabase/cli/internal/functions/cache"
 	"github.com/supabase/cli/internal/functions/deploy"
 	"github.com/supabase/cli/internal/secrets/set"
 	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/internal/utils/flags"
+	"github.com/supabase/cli/pkg/cast"
 )
 
 type InspectMode string
@@ -45,6 +53,21 @@ func (mode InspectMode) toFlag() string {
 type RuntimeOption struct {
 	InspectMode *InspectMode
 	InspectMain bool
+	Detach      bool
+	Metrics     bool
+	DenoArgs    []string
+	RecordPath  string
+	Tls         bool
+	TlsCert     string
+	TlsKey      string
+	LogFile     string
+	LogMaxSize  int64
+	LogMaxAge   time.Duration
+	CachedOnly  bool
+	Quiet       bool
+
+	UseLinkedSecrets bool
+	linkedSecrets    []string
 }
 
 func (i *RuntimeOption) toArgs() []string {
@@ -55,12 +78,13 @@ func (i *RuntimeOption) toArgs() []string {
 			flags = append(flags, "--inspect-main")
 		}
 	}
-	return flags
+	return append(flags, i.DenoArgs...)
 }
 
 const (
 	dockerRuntimeServerPort    = 8081
 	dockerRuntimeInspectorPort = 8083
+	dockerRuntimeTlsPort       = 8443
 )
 
 var (
@@ -68,7 +92,7 @@ var (
 	mainFuncEmbed string
 )
 
-func Run(ctx context.Context, envFilePath string, noVerifyJWT *bool, importMapPath string, runtimeOption RuntimeOption, fsys afero.Fs) error {
+func Run(ctx context.Context, envFilePaths []string, noVerifyJWT *bool, importMapPath string, runtimeOption RuntimeOption, fsys afero.Fs) error {
 	// 1. Sanity checks.
 	if err := utils.LoadConfigFS(fsys); err != nil {
 		return err
@@ -81,34 +105,158 @@ func Run(ctx context.Context, envFilePath string, noVerifyJWT *bool, importMapPa
 		RemoveVolumes: true,
 		Force:         true,
 	})
+	if err := runtimeOption.resolveTls(fsys); err != nil {
+		return err
+	}
+	if runtimeOption.UseLinkedSecrets {
+		projectRef, err := flags.LoadProjectRef(fsys)
+		if err != nil {
+			return err
+		}
+		env, err := parseEnvFiles(resolveEnvFilePaths(envFilePaths, fsys), fsys)
+		if err != nil {
+			return err
+		}
+		if runtimeOption.linkedSecrets, err = loadLinkedSecrets(ctx, projectRef, env, utils.NewConsole()); err != nil {
+			return err
+		}
+	}
+	if runtimeOption.CachedOnly {
+		if err := assertCachedOnly(ctx, importMapPath, noVerifyJWT, fsys); err != nil {
+			return err
+		}
+	}
 	// Use network alias because Deno cannot resolve `_` in hostname
 	dbUrl := fmt.Sprintf("postgresql://postgres:postgres@%s:5432/postgres", utils.DbAliases[0])
 	// 3. Serve and log to console
 	fmt.Fprintln(os.Stderr, "Setting up Edge Functions runtime...")
-	if err := ServeFunctions(ctx, envFilePath, noVerifyJWT, importMapPath, dbUrl, runtimeOption, fsys); err != nil {
+	if utils.IsRemoteDocker() {
+		fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "Functions are copied to the remote docker host. Restart serving to apply code changes.")
+	}
+	start := func() error {
+		return ServeFunctions(ctx, envFilePaths, noVerifyJWT, importMapPath, dbUrl, runtimeOption, fsys)
+	}
+	if err := start(); err != nil {
 		return err
 	}
-	if err := utils.DockerStreamLogs(ctx, utils.EdgeRuntimeId, os.Stdout, os.Stderr); err != nil {
+	if runtimeOption.Tls {
+		fmt.Fprintf(os.Stderr, "Serving functions over HTTPS on %s\n", utils.Aqua(fmt.Sprintf("https://%s:%d/", utils.Config.Hostname, utils.Config.EdgeRuntime.TlsPort)))
+	}
+	if runtimeOption.Detach {
+		fmt.Fprintln(os.Stderr, "Started Edge Functions runtime in the background.")
+		fmt.Fprintln(os.Stderr, "View logs with "+utils.Aqua("supabase functions logs --local")+" and stop serving with "+utils.Aqua("supabase functions stop"))
+		return nil
+	}
+	logger := newFunctionLogger(runtimeOption.Quiet)
+	outW, errW := logger.Writer(os.Stdout), logger.Writer(os.Stderr)
+	defer outW.Close()
+	defer errW.Close()
+	var stdout, stderr io.Writer = outW, errW
+	if len(runtimeOption.LogFile) > 0 {
+		logFile, err := openRotatingFile(runtimeOption.LogFile, runtimeOption.LogMaxSize, runtimeOption.LogMaxAge, fsys)
+		if err != nil {
+			return err
+		}
+		defer logFile.Close()
+		stdout, stderr = io.MultiWriter(stdout, logFile), io.MultiWriter(stderr, logFile)
+	}
+	watcher, err := newConfigWatcher(fsys)
+	if err != nil {
+		return err
+	}
+	reload := make(chan func())
+	go watcher.Run(ctx, configPollInterval, reload)
+	if err := superviseRuntime(ctx, newRestartPolicy(ctx), start, reload, stdout, stderr); err != nil {
 		return err
 	}
 	fmt.Println("Stopped serving " + utils.Bold(utils.FunctionsDir))
 	return nil
 }
 
-func ServeFunctions(ctx context.Context, envFilePath string, noVerifyJWT *bool, importMapPath string, dbUrl string, runtimeOption RuntimeOption, fsys afero.Fs) error {
-	// 1. Load default values
-	if envFilePath == "" {
-		if f, err := fsys.Stat(utils.FallbackEnvFilePath); err == nil && !f.IsDir() {
-			envFilePath = utils.FallbackEnvFilePath
-		}
-	} else if !filepath.IsAbs(envFilePath) {
-		envFilePath = filepath.Join(utils.CurrentDirAbs, envFilePath)
+func assertCachedOnly(ctx context.Context, importMapPath string, noVerifyJWT *bool, fsys afero.Fs) error {
+	fmt.Fprintln(os.Stderr, "Checking cached dependencies of Functions...")
+	slugs, err := deploy.GetFunctionSlugs(fsys)
+	if err != nil {
+		return err
+	}
+	functionsConfig, err := deploy.GetFunctionConfig(slugs, importMapPath, noVerifyJWT, fsys)
+	if err != nil {
+		return err
 	}
+	return cache.AssertCached(ctx, functionsConfig, fsys)
+}
+
+const maxRestartInterval = 30 * time.Second
+
+func newRestartPolicy(ctx context.Context) backoff.BackOff {
+	b := backoff.NewExponentialBackOff()
+	b.MaxInterval = maxRestartInterval
+	// Keep restarting until the user stops serving
+	b.MaxElapsedTime = 0
+	return backoff.WithContext(b, ctx)
+}
+
+var errConfigReload = errors.New("config reloaded")
+
+// Streams runtime logs, restarting the container with backoff whenever it crashes
+// or immediately when a config reload is received.
+func superviseRuntime(ctx context.Context, policy backoff.BackOff, start func() error, reload <-chan func(), stdout, stderr io.Writer) error {
+	restart := false
+	return backoff.RetryNotify(func() error {
+		if restart {
+			fmt.Fprintln(os.Stderr, "Restarting Edge Functions runtime...")
+			utils.DockerRemove(utils.EdgeRuntimeId)
+			if err := start(); err != nil {
+				return backoff.Permanent(err)
+			}
+		}
+		started := time.Now()
+		streamCtx, cancel := context.WithCancel(ctx)
+		reloaded := make(chan bool, 1)
+		go func() {
+			select {
+			case apply := <-reload:
+				cancel()
+				apply()
+				reloaded <- true
+			case <-streamCtx.Done():
+				reloaded <- false
+			}
+		}()
+		err := utils.DockerStreamLogs(streamCtx, utils.EdgeRuntimeId, stdout, stderr)
+		cancel()
+		restart = true
+		if <-reloaded {
+			policy.Reset()
+			return errConfigReload
+		}
+		// Container removed by functions stop
+		if errdefs.IsNotFound(err) {
+			return nil
+		} else if err == nil || ctx.Err() != nil {
+			return backoff.Permanent(err)
+		}
+		// Restart quickly if the runtime was healthy for a while
+		if time.Since(started) > maxRestartInterval {
+			policy.Reset()
+		}
+		return err
+	}, policy, func(err error, d time.Duration) {
+		if !errors.Is(err, errConfigReload) {
+			fmt.Fprintf(os.Stderr, "%s %v\nRestarting in %s...\n", utils.Red("Edge Functions runtime crashed:"), err, d.Round(time.Millisecond))
+		}
+	})
+}
+
+func ServeFunctions(ctx context.Context, envFilePaths []string, noVerifyJWT *bool, importMapPath string, dbUrl string, runtimeOption RuntimeOption, fsys afero.Fs) error {
+	// 1. Load default values
+	envFilePaths = resolveEnvFilePaths(envFilePaths, fsys)
 	// 2. Parse user defined env
-	env, err := parseEnvFile(envFilePath, fsys)
+	env, err := parseEnvFiles(envFilePaths, fsys)
 	if err != nil {
 		return err
 	}
+	env = append(env, runtimeOption.linkedSecrets...)
 	env = append(env,
 		fmt.Sprintf("SUPABASE_URL=http://%s:8000", utils.KongAliases[0]),
 		"SUPABASE_ANON_KEY="+utils.Config.Auth.AnonKey,
@@ -122,6 +270,22 @@ func ServeFunctions(ctx context.Context, envFilePath string, noVerifyJWT *bool,
 	}
 	if runtimeOption.InspectMode != nil {
 		env = append(env, "SUPABASE_INTERNAL_WALLCLOCK_LIMIT_SEC=0")
+	} else if timeout := utils.Config.EdgeRuntime.RequestTimeout; timeout > 0 {
+		// Skipped when debugging as breakpoints would trip the timeout
+		env = append(env, fmt.Sprintf("SUPABASE_INTERNAL_REQUEST_TIMEOUT_MS=%d", timeout.Milliseconds()))
+	}
+	if runtimeOption.Metrics {
+		if err := assertMetricsPath(fsys); err != nil {
+			return err
+		}
+		env = append(env, "SUPABASE_INTERNAL_METRICS=true")
+	}
+	if utils.Config.EdgeRuntime.Cors.Enabled {
+		corsConfigBytes, err := json.Marshal(utils.Config.EdgeRuntime.Cors)
+		if err != nil {
+			return errors.Errorf("failed to marshal cors config: %w", err)
+		}
+		env = append(env, "SUPABASE_INTERNAL_CORS_CONFIG="+string(corsConfigBytes))
 	}
 	// 3. Parse custom import map
 	cwd, err := os.Getwd()
@@ -133,6 +297,14 @@ func ServeFunctions(ctx context.Context, envFilePath string, noVerifyJWT *bool,
 		return err
 	}
 	env = append(env, "SUPABASE_INTERNAL_FUNCTIONS_CONFIG="+functionsConfigString)
+	if len(runtimeOption.RecordPath) > 0 {
+		recordBind, dockerRecordPath, err := getRecordMount(runtimeOption.RecordPath, fsys)
+		if err != nil {
+			return err
+		}
+		binds = append(binds, recordBind)
+		env = append(env, "SUPABASE_INTERNAL_RECORD_PATH="+dockerRecordPath)
+	}
 	// 4. Parse entrypoint script
 	cmd := append([]string{
 		"edge-runtime",
@@ -140,7 +312,16 @@ func ServeFunctions(ctx context.Context, envFilePath string, noVerifyJWT *bool,
 		"--main-service=/root",
 		fmt.Sprintf("--port=%d", dockerRuntimeServerPort),
 		fmt.Sprintf("--policy=%s", utils.Config.EdgeRuntime.Policy),
-	}, runtimeOption.toArgs()...)
+	}, utils.Config.EdgeRuntime.DenoArgs...)
+	cmd = append(cmd, runtimeOption.toArgs()...)
+	if runtimeOption.Tls {
+		binds = append(binds, runtimeOption.tlsBinds()...)
+		cmd = append(cmd,
+			fmt.Sprintf("--tls=%d", dockerRuntimeTlsPort),
+			"--cert="+dockerTlsCertPath,
+			"--key="+dockerTlsKeyPath,
+		)
+	}
 	if viper.GetBool("DEBUG") {
 		cmd = append(cmd, "--verbose")
 	}
@@ -160,6 +341,13 @@ EOF
 			HostPort: strconv.FormatUint(uint64(utils.Config.EdgeRuntime.InspectorPort), 10),
 		}}
 	}
+	if runtimeOption.Tls {
+		dockerTlsPort := nat.Port(fmt.Sprintf("%d/tcp", dockerRuntimeTlsPort))
+		exposedPorts[dockerTlsPort] = struct{}{}
+		portBindings[dockerTlsPort] = []nat.PortBinding{{
+			HostPort: strconv.FormatUint(uint64(utils.Config.EdgeRuntime.TlsPort), 10),
+		}}
+	}
 	// 6. Start container
 	_, err = utils.DockerStart(
 		ctx,
@@ -187,20 +375,44 @@ EOF
 	return err
 }
 
-func parseEnvFile(envFilePath string, fsys afero.Fs) ([]string, error) {
-	env := []string{}
-	if len(envFilePath) == 0 {
-		return env, nil
+func resolveEnvFilePaths(envFilePaths []string, fsys afero.Fs) []string {
+	if len(envFilePaths) == 0 {
+		if f, err := fsys.Stat(utils.FallbackEnvFilePath); err == nil && !f.IsDir() {
+			return []string{utils.FallbackEnvFilePath}
+		}
+		return nil
 	}
-	envMap, err := set.ParseEnvFile(envFilePath, fsys)
-	if err != nil {
-		return env, err
+	resolved := make([]string, len(envFilePaths))
+	for i, envFilePath := range envFilePaths {
+		if !filepath.IsAbs(envFilePath) {
+			envFilePath = filepath.Join(utils.CurrentDirAbs, envFilePath)
+		}
+		resolved[i] = envFilePath
 	}
-	for name, value := range envMap {
-		if strings.HasPrefix(name, "SUPABASE_") {
-			fmt.Fprintln(os.Stderr, "Env name cannot start with SUPABASE_, skipping: "+name)
-			continue
+	return resolved
+}
+
+// Env files are merged in order, with later files taking precedence.
+func parseEnvFiles(envFilePaths []string, fsys afero.Fs) ([]string, error) {
+	env := []string{}
+	envMap := map[string]string{}
+	for _, envFilePath := range envFilePaths {
+		parsed, err := set.ParseEnvFile(envFilePath, fsys)
+		if err != nil {
+			return env, err
+		}
+		for name, value := range parsed {
+			if strings.HasPrefix(name, "SUPABASE_") {
+				fmt.Fprintln(os.Stderr, "Env name cannot start with SUPABASE_, skipping: "+name)
+				continue
+			}
+			if _, ok := envMap[name]; ok {
+				fmt.Fprintln(utils.GetDebugLogger(), "Overriding env from "+envFilePath+": "+name)
+			}
+			envMap[name] = value
 		}
+	}
+	for name, value := range envMap {
 		env = append(env, name+"="+value)
 	}
 	return env, nil
@@ -215,7 +427,8 @@ func populatePerFunctionConfigs(cwd, importMapPath string, noVerifyJWT *bool, fs
 	if err != nil {
 		return nil, "", err
 	}
-	binds := []string{}
+	// Mount deno cache even when no functions are enabled yet
+	binds := []string{utils.DenoCacheVolume + ":/root/.cache/deno:rw"}
 	for slug, fc := range functionsConfig {
 		if !fc.IsEnabled() {
 			fmt.Fprintln(os.Stderr, "Skipped serving Function:", slug)
@@ -226,6 +439,15 @@ func populatePerFunctionConfigs(cwd, importMapPath string, noVerifyJWT *bool, fs
 			return nil, "", err
 		}
 		binds = append(binds, modules...)
+		assets, err := getStaticFileMounts(cwd, fc.StaticFiles, fsys)
+		if err != nil {
+			return nil, "", err
+		}
+		binds = append(binds, assets...)
+		// Platform verifies jwt unless explicitly disabled per function
+		if fc.VerifyJWT == nil {
+			fc.VerifyJWT = cast.Ptr(true)
+		}
 		fc.ImportMap = utils.ToDockerPath(fc.ImportMap)
 		fc.Entrypoint = utils.ToDockerPath(fc.Entrypoint)
 		functionsConfig[slug] = fc
@@ -236,3 +458,52 @@ func populatePerFunctionConfigs(cwd, importMapPath string, noVerifyJWT *bool, fs
 	}
 	return utils.RemoveDuplicates(binds), string(functionsConfigBytes), nil
 }
+
+// The relay serves metrics on /metrics, which shadows any Function with the same slug.
+func assertMetricsPath(fsys afero.Fs) error {
+	slugs, err := deploy.GetFunctionSlugs(fsys)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(slugs, "metrics") {
+		return errors.Errorf("Cannot serve a Function named metrics with %s flag.", utils.Aqua("--metrics"))
+	}
+	return nil
+}
+
+func getStaticFileMounts(cwd string, staticFiles []string, fsys afero.Fs) ([]string, error) {
+	hostFuncDir := filepath.Join(cwd, utils.FunctionsDir) + string(filepath.Separator)
+	var binds []string
+	for _, hostPath := range staticFiles {
+		if _, err := fsys.Stat(hostPath); err != nil {
+			return nil, errors.Errorf("failed to read static files: %w", err)
+		}
+		if !filepath.IsAbs(hostPath) {
+			hostPath = filepath.Join(cwd, hostPath)
+		}
+		// Files under functions directory are already mounted
+		if strings.HasPrefix(hostPath, hostFuncDir) {
+			continue
+		}
+		binds = append(binds, hostPath+":"+utils.ToDockerPath(hostPath)+":ro")
+	}
+	return binds, nil
+}
+
+func getRecordMount(recordPath string, fsys afero.Fs) (string, string, error) {
+	if !filepath.IsAbs(recordPath) {
+		recordPath = filepath.Join(utils.CurrentDirAbs, recordPath)
+	}
+	// Requests are appended to an existing file so we only create it when missing
+	f, err := fsys.OpenFile(recordPath, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return "", "", errors.Errorf("failed to create record file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", "", errors.Errorf("failed to close record file: %w", err)
+	}
+	// Only the record file is writable so that functions cannot modify the rest of the project
+	dockerRecordPath := utils.ToDockerPath(recordPath)
+	bind := recordPath + ":" + dockerRecordPath + ":rw"
+	return bind, dockerRecordPath, nil
+}
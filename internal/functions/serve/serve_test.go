@@ -2,11 +2,14 @@ package serve
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/docker/docker/api/types"
 	"github.com/h2non/gock"
 	"github.com/spf13/afero"
@@ -15,6 +18,7 @@ import (
 	"github.com/supabase/cli/internal/testing/apitest"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/cast"
+	"github.com/supabase/cli/pkg/config"
 )
 
 func TestServeCommand(t *testing.T) {
@@ -38,7 +42,30 @@ func TestServeCommand(t *testing.T) {
 		apitest.MockDockerStart(utils.Docker, utils.GetRegistryImageUrl(utils.Config.EdgeRuntime.Image), containerId)
 		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "success"))
 		// Run test
-		err := Run(context.Background(), "", nil, "", RuntimeOption{}, fsys)
+		err := Run(context.Background(), nil, nil, "", RuntimeOption{}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("serves functions in background", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_test/json").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{})
+		containerId := "supabase_edge_runtime_test"
+		gock.New(utils.Docker.DaemonHost()).
+			Delete("/v" + utils.Docker.ClientVersion() + "/containers/" + containerId).
+			Reply(http.StatusOK)
+		apitest.MockDockerStart(utils.Docker, utils.GetRegistryImageUrl(utils.Config.EdgeRuntime.Image), containerId)
+		// Run test
+		err := Run(context.Background(), nil, nil, "", RuntimeOption{Detach: true}, fsys)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -48,7 +75,7 @@ func TestServeCommand(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), "", nil, "", RuntimeOption{}, fsys)
+		err := Run(context.Background(), nil, nil, "", RuntimeOption{}, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "open supabase/config.toml: file does not exist")
 	})
@@ -64,7 +91,7 @@ func TestServeCommand(t *testing.T) {
 			Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_test/json").
 			Reply(http.StatusNotFound)
 		// Run test
-		err := Run(context.Background(), "", nil, "", RuntimeOption{}, fsys)
+		err := Run(context.Background(), nil, nil, "", RuntimeOption{}, fsys)
 		// Check error
 		assert.ErrorIs(t, err, utils.ErrNotRunning)
 	})
@@ -81,7 +108,7 @@ func TestServeCommand(t *testing.T) {
 			Reply(http.StatusOK).
 			JSON(types.ContainerJSON{})
 		// Run test
-		err := Run(context.Background(), ".env", nil, "", RuntimeOption{}, fsys)
+		err := Run(context.Background(), []string{".env"}, nil, "", RuntimeOption{}, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "open .env: file does not exist")
 	})
@@ -101,7 +128,230 @@ func TestServeCommand(t *testing.T) {
 			Reply(http.StatusOK).
 			JSON(types.ContainerJSON{})
 		// Run test
-		err := Run(context.Background(), ".env", cast.Ptr(true), "import_map.json", RuntimeOption{}, fsys)
+		err := Run(context.Background(), []string{".env"}, cast.Ptr(true), "import_map.json", RuntimeOption{}, fsys)
+		// Check error
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestStaticFileMounts(t *testing.T) {
+	t.Run("mounts static files read-only", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, fsys.MkdirAll("/project/supabase/assets", 0755))
+		require.NoError(t, fsys.MkdirAll("/project/supabase/functions/hello/templates", 0755))
+		// Run test
+		binds, err := getStaticFileMounts("/project", []string{
+			"/project/supabase/assets",
+			"/project/supabase/functions/hello/templates",
+		}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/project/supabase/assets:/project/supabase/assets:ro"}, binds)
+	})
+
+	t.Run("throws error on missing static files", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		_, err := getStaticFileMounts("/project", []string{"supabase/assets"}, fsys)
+		// Check error
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestPopulatePerFunctionConfigs(t *testing.T) {
+	t.Run("wires import map of each function", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		for _, slug := range []string{"hello", "world"} {
+			functionDir := filepath.Join(utils.FunctionsDir, slug)
+			require.NoError(t, afero.WriteFile(fsys, filepath.Join(functionDir, "index.ts"), []byte{}, 0644))
+			require.NoError(t, afero.WriteFile(fsys, filepath.Join(functionDir, "deno.json"), []byte("{}"), 0644))
+		}
+		// Run test
+		_, configString, err := populatePerFunctionConfigs("", "", nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+		var functionsConfig map[string]map[string]any
+		require.NoError(t, json.Unmarshal([]byte(configString), &functionsConfig))
+		assert.Equal(t, "supabase/functions/hello/deno.json", functionsConfig["hello"]["importMapPath"])
+		assert.Equal(t, "supabase/functions/world/deno.json", functionsConfig["world"]["importMapPath"])
+	})
+
+	t.Run("respects verify jwt of each function", func(t *testing.T) {
+		utils.Config.Functions = config.FunctionConfig{
+			"public": {VerifyJWT: cast.Ptr(false)},
+		}
+		defer func() { utils.Config.Functions = nil }()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		for _, slug := range []string{"public", "private"} {
+			entrypoint := filepath.Join(utils.FunctionsDir, slug, "index.ts")
+			require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte{}, 0644))
+		}
+		// Run test
+		_, configString, err := populatePerFunctionConfigs("", "", nil, fsys)
+		// Check error
+		assert.NoError(t, err)
+		var functionsConfig map[string]map[string]any
+		require.NoError(t, json.Unmarshal([]byte(configString), &functionsConfig))
+		assert.Equal(t, false, functionsConfig["public"]["verifyJWT"])
+		assert.Equal(t, true, functionsConfig["private"]["verifyJWT"])
+	})
+
+	t.Run("overrides verify jwt with flag", func(t *testing.T) {
+		utils.Config.Functions = config.FunctionConfig{
+			"private": {VerifyJWT: cast.Ptr(true)},
+		}
+		defer func() { utils.Config.Functions = nil }()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		entrypoint := filepath.Join(utils.FunctionsDir, "private", "index.ts")
+		require.NoError(t, afero.WriteFile(fsys, entrypoint, []byte{}, 0644))
+		// Run test
+		_, configString, err := populatePerFunctionConfigs("", "", cast.Ptr(true), fsys)
+		// Check error
+		assert.NoError(t, err)
+		var functionsConfig map[string]map[string]any
+		require.NoError(t, json.Unmarshal([]byte(configString), &functionsConfig))
+		assert.Equal(t, false, functionsConfig["private"]["verifyJWT"])
+	})
+}
+
+func TestSuperviseRuntime(t *testing.T) {
+	containerId := "test-edge-runtime"
+	original := utils.EdgeRuntimeId
+	utils.EdgeRuntimeId = containerId
+	t.Cleanup(func() { utils.EdgeRuntimeId = original })
+
+	t.Run("restarts crashed runtime", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		require.NoError(t, apitest.MockDockerLogsExitCode(utils.Docker, containerId, 1))
+		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "success"))
+		// Run test
+		restarts := 0
+		err := superviseRuntime(context.Background(), &backoff.ZeroBackOff{}, func() error {
+			restarts++
+			return nil
+		}, nil, os.Stdout, os.Stderr)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, 1, restarts)
+	})
+
+	t.Run("stops on restart failure", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		require.NoError(t, apitest.MockDockerLogsExitCode(utils.Docker, containerId, 1))
+		// Run test
+		err := superviseRuntime(context.Background(), &backoff.ZeroBackOff{}, func() error {
+			return errors.New("failed to start")
+		}, nil, os.Stdout, os.Stderr)
+		// Check error
+		assert.ErrorContains(t, err, "failed to start")
+	})
+
+	t.Run("stops on removed runtime", func(t *testing.T) {
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/" + containerId + "/logs").
+			Reply(http.StatusNotFound)
+		// Run test
+		err := superviseRuntime(context.Background(), &backoff.ZeroBackOff{}, func() error {
+			return errors.New("should not restart")
+		}, nil, os.Stdout, os.Stderr)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestRuntimeOptionArgs(t *testing.T) {
+	t.Run("appends deno args after inspector flags", func(t *testing.T) {
+		option := RuntimeOption{
+			InspectMode: cast.Ptr(InspectModeBrk),
+			DenoArgs:    []string{"--v8-flags=--max-old-space-size=512"},
+		}
+		// Run test
+		args := option.toArgs()
+		// Check output
+		assert.Equal(t, []string{
+			"--inspect-brk=0.0.0.0:8083",
+			"--v8-flags=--max-old-space-size=512",
+		}, args)
+	})
+}
+
+func TestMetricsPath(t *testing.T) {
+	t.Run("serves metrics on relay root", func(t *testing.T) {
+		assert.Contains(t, mainFuncEmbed, `pathname === "/metrics"`)
+	})
+
+	t.Run("throws error on function named metrics", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.FunctionsDir, "metrics", "index.ts"), []byte{}, 0644))
+		// Run test
+		err := assertMetricsPath(fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Cannot serve a Function named metrics")
+	})
+}
+
+func TestRecordMount(t *testing.T) {
+	t.Run("creates record file and binds only the file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		bind, dockerPath, err := getRecordMount("/tmp/requests.ndjson", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "/tmp/requests.ndjson:/tmp/requests.ndjson:rw", bind)
+		assert.Equal(t, "/tmp/requests.ndjson", dockerPath)
+		exists, err := afero.Exists(fsys, "/tmp/requests.ndjson")
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("preserves existing records", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/tmp/requests.ndjson", []byte("{}\n"), 0644))
+		// Run test
+		_, _, err := getRecordMount("/tmp/requests.ndjson", fsys)
+		// Check error
+		assert.NoError(t, err)
+		data, err := afero.ReadFile(fsys, "/tmp/requests.ndjson")
+		assert.NoError(t, err)
+		assert.Equal(t, "{}\n", string(data))
+	})
+}
+
+func TestParseEnvFiles(t *testing.T) {
+	t.Run("merges env files in order", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/base.env", []byte("API_URL=https://example.com\nLOG_LEVEL=info\n"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "/local.env", []byte("LOG_LEVEL=debug\nSUPABASE_URL=http://localhost\n"), 0644))
+		// Run test
+		env, err := parseEnvFiles([]string{"/base.env", "/local.env"}, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"API_URL=https://example.com", "LOG_LEVEL=debug"}, env)
+	})
+
+	t.Run("throws error on missing env file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/base.env", []byte{}, 0644))
+		// Run test
+		_, err := parseEnvFiles([]string{"/base.env", "/local.env"}, fsys)
 		// Check error
 		assert.ErrorIs(t, err, os.ErrNotExist)
 	})
@@ -1,5 +1,6 @@
 import { STATUS_CODE, STATUS_TEXT } from "https://deno.land/std/http/status.ts";
 import * as posix from "https://deno.land/std/path/posix/mod.ts";
+import { encodeBase64 } from "https://deno.land/std/encoding/base64.ts";
 
 import * as jose from "https://deno.land/x/jose@v4.13.1/index.ts";
 
@@ -40,6 +41,16 @@ const WALLCLOCK_LIMIT_SEC = parseInt(
   Deno.env.get("SUPABASE_INTERNAL_WALLCLOCK_LIMIT_SEC"),
 );
 
+const METRICS_ENABLED = Deno.env.get("SUPABASE_INTERNAL_METRICS") === "true";
+const CORS_CONFIG_STRING = Deno.env.get("SUPABASE_INTERNAL_CORS_CONFIG");
+const RECORD_PATH = Deno.env.get("SUPABASE_INTERNAL_RECORD_PATH");
+const REQUEST_TIMEOUT_MS = parseInt(
+  Deno.env.get("SUPABASE_INTERNAL_REQUEST_TIMEOUT_MS"),
+);
+
+// Mounted as a named volume by the CLI
+const DENO_CACHE_DIR = "/root/.cache/deno";
+
 const DENO_SB_ERROR_MAP = new Map([
   [Deno.errors.InvalidWorkerCreation, SB_SPECIFIC_ERROR_CODE.BootError],
   [Deno.errors.InvalidWorkerResponse, SB_SPECIFIC_ERROR_CODE.InvalidWorkerResponse],
@@ -53,6 +64,56 @@ interface FunctionConfig {
   entrypointPath: string;
   importMapPath: string;
   verifyJWT: boolean;
+  memoryLimitMb?: number;
+  timeoutSec?: number;
+}
+
+async function getHealth() {
+  let cached = false;
+  try {
+    cached = (await Deno.stat(DENO_CACHE_DIR)).isDirectory;
+  } catch {
+    // cache directory is created on first download
+  }
+  return {
+    message: "ok",
+    status: "ready",
+    functions: Object.keys(functionsConfig),
+    denoCache: { path: DENO_CACHE_DIR, cached },
+  };
+}
+
+async function fetchWithTimeout(
+  functionName: string,
+  fetch: () => Promise<Response>,
+): Promise<Response> {
+  if (!(REQUEST_TIMEOUT_MS > 0)) {
+    return await fetch();
+  }
+  let timer: number | undefined;
+  const timeout = new Promise<Response>((resolve) => {
+    timer = setTimeout(() => {
+      console.error(
+        `${functionName} did not respond within ${REQUEST_TIMEOUT_MS}ms, returning 504`,
+      );
+      resolve(getResponse(
+        {
+          code: "REQUEST_TIMEOUT",
+          message: "Function did not respond within the configured request timeout",
+        },
+        STATUS_CODE.GatewayTimeout,
+      ));
+    }, REQUEST_TIMEOUT_MS);
+  });
+  try {
+    return await Promise.race([fetch(), timeout]);
+  } finally {
+    clearTimeout(timer);
+  }
+}
+
+function isWebSocketUpgrade(req: Request) {
+  return req.headers.get("upgrade")?.toLowerCase() === "websocket";
 }
 
 function getResponse(payload: any, status: number, customHeaders = {}) {
@@ -115,14 +176,196 @@ async function verifyJWT(jwt: string): Promise<boolean> {
   return true;
 }
 
+// Prometheus histogram buckets for request latency in seconds
+const LATENCY_BUCKETS_SEC = [0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10];
+
+interface FunctionMetrics {
+  requests: Map<number, number>;
+  errors: number;
+  durationSum: number;
+  durationBuckets: number[];
+}
+
+const metrics = new Map<string, FunctionMetrics>();
+
+function recordMetrics(functionName: string, status: number, durationSec: number) {
+  let m = metrics.get(functionName);
+  if (!m) {
+    m = {
+      requests: new Map(),
+      errors: 0,
+      durationSum: 0,
+      durationBuckets: LATENCY_BUCKETS_SEC.map(() => 0),
+    };
+    metrics.set(functionName, m);
+  }
+  m.requests.set(status, (m.requests.get(status) ?? 0) + 1);
+  if (status >= 500) {
+    m.errors++;
+  }
+  m.durationSum += durationSec;
+  LATENCY_BUCKETS_SEC.forEach((le, i) => {
+    if (durationSec <= le) {
+      m.durationBuckets[i]++;
+    }
+  });
+}
+
+function renderMetrics(): string {
+  const lines = [
+    "# HELP function_requests_total Total number of requests served by function and status code.",
+    "# TYPE function_requests_total counter",
+  ];
+  for (const [name, m] of metrics) {
+    for (const [status, count] of m.requests) {
+      lines.push(`function_requests_total{function="${name}",status="${status}"} ${count}`);
+    }
+  }
+  lines.push(
+    "# HELP function_errors_total Total number of requests that failed with a 5xx status code.",
+    "# TYPE function_errors_total counter",
+  );
+  for (const [name, m] of metrics) {
+    lines.push(`function_errors_total{function="${name}"} ${m.errors}`);
+  }
+  lines.push(
+    "# HELP function_request_duration_seconds Latency of requests served by function.",
+    "# TYPE function_request_duration_seconds histogram",
+  );
+  for (const [name, m] of metrics) {
+    let total = 0;
+    for (const count of m.requests.values()) {
+      total += count;
+    }
+    LATENCY_BUCKETS_SEC.forEach((le, i) => {
+      lines.push(`function_request_duration_seconds_bucket{function="${name}",le="${le}"} ${m.durationBuckets[i]}`);
+    });
+    lines.push(`function_request_duration_seconds_bucket{function="${name}",le="+Inf"} ${total}`);
+    lines.push(`function_request_duration_seconds_sum{function="${name}"} ${m.durationSum}`);
+    lines.push(`function_request_duration_seconds_count{function="${name}"} ${total}`);
+  }
+  return lines.join("\n") + "\n";
+}
+
+function withMetrics(handler: (req: Request) => Promise<Response>) {
+  if (!METRICS_ENABLED) {
+    return handler;
+  }
+  return async (req: Request) => {
+    const { pathname } = new URL(req.url);
+    if (pathname === "/metrics") {
+      return new Response(renderMetrics(), {
+        headers: { "Content-Type": "text/plain; version=0.0.4" },
+      });
+    }
+    const functionName = pathname.split("/")[1];
+    if (!functionName || !(functionName in functionsConfig)) {
+      return await handler(req);
+    }
+    const start = performance.now();
+    let status = STATUS_CODE.InternalServerError;
+    try {
+      const resp = await handler(req);
+      status = resp.status;
+      return resp;
+    } finally {
+      recordMetrics(functionName, status, (performance.now() - start) / 1000);
+    }
+  };
+}
+
+interface CorsConfig {
+  allowedOrigins: string[];
+  allowedHeaders: string[] | null;
+}
+
+const DEFAULT_CORS_HEADERS = ["authorization", "x-client-info", "apikey", "content-type"];
+const CORS_METHODS = "GET, POST, PUT, PATCH, DELETE, OPTIONS";
+
+function getCorsHeaders(cors: CorsConfig, req: Request): Record<string, string> {
+  const origin = req.headers.get("origin");
+  if (!origin) {
+    return {};
+  }
+  let allowOrigin = "";
+  if (cors.allowedOrigins.includes("*")) {
+    allowOrigin = "*";
+  } else if (cors.allowedOrigins.includes(origin)) {
+    allowOrigin = origin;
+  } else {
+    return {};
+  }
+  const allowedHeaders = cors.allowedHeaders?.length ? cors.allowedHeaders : DEFAULT_CORS_HEADERS;
+  return {
+    "Access-Control-Allow-Origin": allowOrigin,
+    "Access-Control-Allow-Headers": allowedHeaders.join(", "),
+    "Access-Control-Allow-Methods": CORS_METHODS,
+    "Vary": "Origin",
+  };
+}
+
+function withCors(handler: (req: Request) => Promise<Response>) {
+  if (!CORS_CONFIG_STRING) {
+    return handler;
+  }
+  const cors: CorsConfig = JSON.parse(CORS_CONFIG_STRING);
+  return async (req: Request) => {
+    const corsHeaders = getCorsHeaders(cors, req);
+    // Answer preflight requests without booting a worker
+    if (req.method === "OPTIONS" && req.headers.has("access-control-request-method")) {
+      return new Response(null, { status: STATUS_CODE.NoContent, headers: corsHeaders });
+    }
+    const resp = await handler(req);
+    // Upgraded responses must be returned as is for the socket to be proxied
+    if (Object.keys(corsHeaders).length === 0 || isWebSocketUpgrade(req)) {
+      return resp;
+    }
+    const headers = new Headers(resp.headers);
+    for (const [name, value] of Object.entries(corsHeaders)) {
+      headers.set(name, value);
+    }
+    return new Response(resp.body, {
+      status: resp.status,
+      statusText: resp.statusText,
+      headers,
+    });
+  };
+}
+
+// Serialises appends so that recorded requests are written in arrival order
+let recordQueue = Promise.resolve();
+
+function withRecord(handler: (req: Request) => Promise<Response>) {
+  if (!RECORD_PATH) {
+    return handler;
+  }
+  return async (req: Request) => {
+    const url = new URL(req.url);
+    if (!url.pathname.startsWith("/_internal/") && !isWebSocketUpgrade(req)) {
+      // Bodies are base64 encoded so that binary payloads survive the round trip
+      const body = new Uint8Array(await req.clone().arrayBuffer());
+      const line = JSON.stringify({
+        method: req.method,
+        path: url.pathname + url.search,
+        headers: Object.fromEntries(req.headers),
+        body: body.length > 0 ? encodeBase64(body) : undefined,
+      }) + "\n";
+      recordQueue = recordQueue
+        .then(() => Deno.writeTextFile(RECORD_PATH, line, { append: true }))
+        .catch((e) => console.error("Failed to record request:", e));
+    }
+    return await handler(req);
+  };
+}
+
 Deno.serve({
-  handler: async (req: Request) => {
+  handler: withMetrics(withRecord(withCors(async (req: Request) => {
     const url = new URL(req.url);
     const { pathname } = url;
 
     // handle health checks
     if (pathname === "/_internal/health") {
-      return getResponse({ message: "ok" }, STATUS_CODE.OK);
+      return getResponse(await getHealth(), STATUS_CODE.OK);
     }
 
     // handle metrics
@@ -153,11 +396,16 @@ Deno.serve({
     }
 
     const servicePath = posix.dirname(functionsConfig[functionName].entrypointPath);
-    console.error(`serving the request with ${servicePath}`);
+    // Tags the request so that the CLI can attribute logs to the function being served
+    const requestTag = `function=${functionName} request=${crypto.randomUUID().slice(0, 8)}`;
+    console.error(`serving the request with ${servicePath} ${requestTag}`);
 
     // Ref: https://supabase.com/docs/guides/functions/limits
-    const memoryLimitMb = 256;
-    const workerTimeoutMs = isFinite(WALLCLOCK_LIMIT_SEC) ? WALLCLOCK_LIMIT_SEC * 1000 : 400 * 1000;
+    const memoryLimitMb = functionsConfig[functionName].memoryLimitMb ?? 256;
+    const timeoutSec = functionsConfig[functionName].timeoutSec;
+    const workerTimeoutMs = timeoutSec
+      ? timeoutSec * 1000
+      : isFinite(WALLCLOCK_LIMIT_SEC) ? WALLCLOCK_LIMIT_SEC * 1000 : 400 * 1000;
     const noModuleCache = false;
     const envVarsObj = Deno.env.toObject();
     const envVars = Object.entries(envVarsObj)
@@ -197,7 +445,11 @@ Deno.serve({
         },
       });
 
-      return await worker.fetch(req);
+      // Long lived sockets are not subject to the request timeout
+      if (isWebSocketUpgrade(req)) {
+        return await worker.fetch(req);
+      }
+      return await fetchWithTimeout(functionName, () => worker.fetch(req));
     } catch (e) {
       console.error(e);
 
@@ -221,13 +473,20 @@ Deno.serve({
         },
         STATUS_CODE.InternalServerError,
       );
+    } finally {
+      console.error(`finished the request with ${servicePath} ${requestTag}`);
     }
-  },
+  }))),
 
   onListen: () => {
     console.log(
       `Serving functions on http://127.0.0.1:${HOST_PORT}/functions/v1/<function-name>\nUsing ${Deno.version.deno}`,
     );
+    if (METRICS_ENABLED) {
+      console.log(
+        `Serving metrics on http://127.0.0.1:${HOST_PORT}/functions/v1/metrics`,
+      );
+    }
   },
 
   onError: e => {
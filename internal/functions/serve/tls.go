@@ -0,0 +1,113 @@
+package serve
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const (
+	dockerTlsCertPath = "/root/tls/server.crt"
+	dockerTlsKeyPath  = "/root/tls/server.key"
+)
+
+var (
+	selfSignedCertPath = filepath.Join(utils.TempDir, "tls", "server.crt")
+	selfSignedKeyPath  = filepath.Join(utils.TempDir, "tls", "server.key")
+)
+
+// Resolves the certificate pair to absolute host paths, generating a self-signed
+// pair under the temp directory when none is provided.
+func (i *RuntimeOption) resolveTls(fsys afero.Fs) error {
+	if len(i.TlsCert) == 0 && len(i.TlsKey) == 0 {
+		if !i.Tls {
+			return nil
+		}
+		if err := generateSelfSignedCert(selfSignedCertPath, selfSignedKeyPath, fsys); err != nil {
+			return err
+		}
+		i.TlsCert, i.TlsKey = selfSignedCertPath, selfSignedKeyPath
+	} else if len(i.TlsCert) == 0 || len(i.TlsKey) == 0 {
+		return errors.New("Both --tls-cert and --tls-key must be provided.")
+	}
+	for _, p := range []*string{&i.TlsCert, &i.TlsKey} {
+		if _, err := fsys.Stat(*p); err != nil {
+			return errors.Errorf("failed to read tls certificate: %w", err)
+		}
+		if !filepath.IsAbs(*p) {
+			*p = filepath.Join(utils.CurrentDirAbs, *p)
+		}
+	}
+	i.Tls = true
+	return nil
+}
+
+func (i *RuntimeOption) tlsBinds() []string {
+	return []string{
+		i.TlsCert + ":" + dockerTlsCertPath + ":ro",
+		i.TlsKey + ":" + dockerTlsKeyPath + ":ro",
+	}
+}
+
+func generateSelfSignedCert(certPath, keyPath string, fsys afero.Fs) error {
+	// Reuse existing pair so browsers only need to trust it once
+	if _, err := fsys.Stat(certPath); err == nil {
+		if _, err := fsys.Stat(keyPath); err == nil {
+			return nil
+		}
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return errors.Errorf("failed to generate private key: %w", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return errors.Errorf("failed to generate serial number: %w", err)
+	}
+	now := time.Now()
+	template := x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             now,
+		NotAfter:              now.AddDate(1, 0, 0),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              []string{"localhost", "host.docker.internal"},
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		return errors.Errorf("failed to create certificate: %w", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return errors.Errorf("failed to marshal private key: %w", err)
+	}
+	if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(certPath)); err != nil {
+		return err
+	}
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err := afero.WriteFile(fsys, certPath, certPem, 0644); err != nil {
+		return errors.Errorf("failed to write certificate: %w", err)
+	}
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+	if err := afero.WriteFile(fsys, keyPath, keyPem, 0600); err != nil {
+		return errors.Errorf("failed to write private key: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, "Generated self-signed certificate:", utils.Bold(certPath))
+	return nil
+}
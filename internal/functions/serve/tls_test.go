@@ -0,0 +1,78 @@
+package serve
+
+import (
+	"crypto/tls"
+	"os"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveTls(t *testing.T) {
+	t.Run("generates self-signed certificate", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		opt := RuntimeOption{Tls: true}
+		// Run test
+		err := opt.resolveTls(fsys)
+		// Check error
+		assert.NoError(t, err)
+		certPem, err := afero.ReadFile(fsys, selfSignedCertPath)
+		require.NoError(t, err)
+		keyPem, err := afero.ReadFile(fsys, selfSignedKeyPath)
+		require.NoError(t, err)
+		_, err = tls.X509KeyPair(certPem, keyPem)
+		assert.NoError(t, err)
+		assert.Len(t, opt.tlsBinds(), 2)
+	})
+
+	t.Run("reuses existing certificate", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, selfSignedCertPath, []byte("cert"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, selfSignedKeyPath, []byte("key"), 0600))
+		opt := RuntimeOption{Tls: true}
+		// Run test
+		err := opt.resolveTls(fsys)
+		// Check error
+		assert.NoError(t, err)
+		data, err := afero.ReadFile(fsys, selfSignedCertPath)
+		assert.NoError(t, err)
+		assert.Equal(t, "cert", string(data))
+	})
+
+	t.Run("enables tls with custom certificate", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "/certs/server.crt", []byte("cert"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "/certs/server.key", []byte("key"), 0600))
+		opt := RuntimeOption{TlsCert: "/certs/server.crt", TlsKey: "/certs/server.key"}
+		// Run test
+		err := opt.resolveTls(fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.True(t, opt.Tls)
+		assert.Equal(t, []string{
+			"/certs/server.crt:/root/tls/server.crt:ro",
+			"/certs/server.key:/root/tls/server.key:ro",
+		}, opt.tlsBinds())
+	})
+
+	t.Run("throws error on missing key", func(t *testing.T) {
+		opt := RuntimeOption{TlsCert: "/certs/server.crt"}
+		// Run test
+		err := opt.resolveTls(afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Both --tls-cert and --tls-key must be provided.")
+	})
+
+	t.Run("throws error on missing certificate", func(t *testing.T) {
+		opt := RuntimeOption{TlsCert: "/certs/server.crt", TlsKey: "/certs/server.key"}
+		// Run test
+		err := opt.resolveTls(afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
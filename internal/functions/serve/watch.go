@@ -0,0 +1,147 @@
+package serve
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/config"
+)
+
+const configPollInterval = time.Second
+
+// Polls config.toml for changes to settings that affect the functions runtime.
+type configWatcher struct {
+	fsys     afero.Fs
+	modTime  time.Time
+	snapshot map[string]string
+}
+
+func newConfigWatcher(fsys afero.Fs) (*configWatcher, error) {
+	snapshot, err := flattenConfig(utils.Config.Functions, utils.Config.EdgeRuntime)
+	if err != nil {
+		return nil, err
+	}
+	w := configWatcher{fsys: fsys, snapshot: snapshot}
+	if info, err := fsys.Stat(utils.ConfigPath); err == nil {
+		w.modTime = info.ModTime()
+	}
+	return &w, nil
+}
+
+// Sends a func on reload that applies the changed config before the runtime is restarted.
+func (w *configWatcher) Run(ctx context.Context, interval time.Duration, reload chan<- func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		changes, apply, err := w.check()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "failed to reload config:", err)
+			continue
+		} else if len(changes) == 0 {
+			continue
+		}
+		fmt.Fprintln(os.Stderr, "Detected changes in "+utils.Bold(utils.ConfigPath)+":")
+		for _, line := range changes {
+			fmt.Fprintln(os.Stderr, "  "+line)
+		}
+		select {
+		case reload <- apply:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (w *configWatcher) check() ([]string, func(), error) {
+	info, err := w.fsys.Stat(utils.ConfigPath)
+	if err != nil {
+		return nil, nil, errors.Errorf("failed to stat config: %w", err)
+	} else if info.ModTime().Equal(w.modTime) {
+		return nil, nil, nil
+	}
+	w.modTime = info.ModTime()
+	next := config.NewConfig(config.WithHostname(utils.Config.Hostname))
+	if err := next.Load("", utils.NewRootFS(w.fsys)); err != nil {
+		return nil, nil, err
+	}
+	snapshot, err := flattenConfig(next.Functions, next.EdgeRuntime)
+	if err != nil {
+		return nil, nil, err
+	}
+	changes := diffConfig(w.snapshot, snapshot)
+	if len(changes) == 0 {
+		return nil, nil, nil
+	}
+	w.snapshot = snapshot
+	apply := func() {
+		utils.Config.Functions = next.Functions
+		utils.Config.EdgeRuntime = next.EdgeRuntime
+	}
+	return changes, apply, nil
+}
+
+// Flattens config sections to dotted toml keys for diffing.
+func flattenConfig(functions config.FunctionConfig, edgeRuntime any) (map[string]string, error) {
+	var buf bytes.Buffer
+	sections := map[string]any{"functions": functions, "edge_runtime": edgeRuntime}
+	if err := toml.NewEncoder(&buf).Encode(sections); err != nil {
+		return nil, errors.Errorf("failed to encode config: %w", err)
+	}
+	var decoded map[string]any
+	if _, err := toml.Decode(buf.String(), &decoded); err != nil {
+		return nil, errors.Errorf("failed to decode config: %w", err)
+	}
+	result := map[string]string{}
+	flattenInto(result, "", decoded)
+	return result, nil
+}
+
+func flattenInto(result map[string]string, prefix string, value any) {
+	if table, ok := value.(map[string]any); ok {
+		for k, v := range table {
+			if len(prefix) > 0 {
+				k = prefix + "." + k
+			}
+			flattenInto(result, k, v)
+		}
+		return
+	}
+	// Unset fields are treated as removed
+	if s := fmt.Sprintf("%v", value); len(s) > 0 {
+		result[prefix] = s
+	}
+}
+
+func diffConfig(prev, next map[string]string) []string {
+	var changes []string
+	for k, v := range next {
+		if old, ok := prev[k]; !ok {
+			changes = append(changes, fmt.Sprintf("+ %s = %s", k, v))
+		} else if old != v {
+			changes = append(changes, fmt.Sprintf("~ %s: %s -> %s", k, old, v))
+		}
+	}
+	for k, v := range prev {
+		if _, ok := next[k]; !ok {
+			changes = append(changes, fmt.Sprintf("- %s = %s", k, v))
+		}
+	}
+	// Sort by key rather than change marker
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i][2:] < changes[j][2:]
+	})
+	return changes
+}
@@ -0,0 +1,65 @@
+package serve
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestConfigWatcher(t *testing.T) {
+	defer func() { utils.Config.Functions = nil }()
+
+	t.Run("detects changes to function config", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		require.NoError(t, utils.LoadConfigFS(fsys))
+		watcher, err := newConfigWatcher(fsys)
+		require.NoError(t, err)
+		// Update config
+		data, err := afero.ReadFile(fsys, utils.ConfigPath)
+		require.NoError(t, err)
+		data = append(data, []byte("\n[functions.hello]\nverify_jwt = false\n")...)
+		require.NoError(t, afero.WriteFile(fsys, utils.ConfigPath, data, 0644))
+		require.NoError(t, fsys.Chtimes(utils.ConfigPath, time.Now(), time.Now().Add(time.Second)))
+		// Run test
+		changes, apply, err := watcher.check()
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"+ functions.hello.entrypoint = supabase/functions/hello/index.ts",
+			"+ functions.hello.verify_jwt = false",
+		}, changes)
+		apply()
+		assert.False(t, *utils.Config.Functions["hello"].VerifyJWT)
+	})
+
+	t.Run("ignores unchanged config", func(t *testing.T) {
+		utils.Config.Functions = nil
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		require.NoError(t, utils.LoadConfigFS(fsys))
+		watcher, err := newConfigWatcher(fsys)
+		require.NoError(t, err)
+		require.NoError(t, fsys.Chtimes(utils.ConfigPath, time.Now(), time.Now().Add(time.Second)))
+		// Run test
+		changes, _, err := watcher.check()
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+}
+
+func TestDiffConfig(t *testing.T) {
+	prev := map[string]string{"a.x": "1", "b.y": "2"}
+	next := map[string]string{"a.x": "3", "c.z": "4"}
+	// Run test
+	changes := diffConfig(prev, next)
+	// Check output
+	assert.Equal(t, []string{"~ a.x: 1 -> 3", "- b.y = 2", "+ c.z = 4"}, changes)
+}
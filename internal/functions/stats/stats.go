@@ -0,0 +1,157 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+type FunctionStats struct {
+	Slug        string  `json:"slug" toml:"slug" yaml:"slug"`
+	Invocations int64   `json:"invocations" toml:"invocations" yaml:"invocations"`
+	Errors      int64   `json:"errors" toml:"errors" yaml:"errors"`
+	ErrorRate   float64 `json:"error_rate" toml:"error_rate" yaml:"error_rate"`
+	P50         float64 `json:"p50_ms" toml:"p50_ms" yaml:"p50_ms"`
+	P95         float64 `json:"p95_ms" toml:"p95_ms" yaml:"p95_ms"`
+}
+
+// Aggregates edge logs of deployed functions over the given window.
+func Run(ctx context.Context, slug, projectRef string, window time.Duration, fsys afero.Fs) error {
+	if len(slug) > 0 {
+		if err := utils.ValidateFunctionSlug(slug); err != nil {
+			return err
+		}
+	}
+	if window <= 0 {
+		return errors.Errorf("Invalid window %s: must be a positive duration", window)
+	}
+	slugs, err := listFunctionSlugs(ctx, projectRef)
+	if err != nil {
+		return err
+	}
+	var functionId string
+	if len(slug) > 0 {
+		for id, s := range slugs {
+			if s == slug {
+				functionId = id
+			}
+		}
+		if len(functionId) == 0 {
+			return errors.Errorf("Function %s does not exist on the Supabase project.", utils.Aqua(slug))
+		}
+	}
+	end := time.Now().UTC()
+	stats, err := queryStats(ctx, projectRef, buildQuery(functionId), end.Add(-window), end)
+	if err != nil {
+		return err
+	}
+	for i, s := range stats {
+		// Keep the raw id for functions deleted since they were invoked
+		if name, ok := slugs[s.Slug]; ok {
+			stats[i].Slug = name
+		}
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Slug < stats[j].Slug
+	})
+
+	if utils.OutputFormat.Value == utils.OutputPretty {
+		table := `|FUNCTION|INVOCATIONS|ERRORS|ERROR RATE|P50 (ms)|P95 (ms)|
+|-|-|-|-|-|-|
+`
+		for _, s := range stats {
+			table += fmt.Sprintf("|`%s`|`%d`|`%d`|`%.2f%%`|`%.0f`|`%.0f`|\n", s.Slug, s.Invocations, s.Errors, s.ErrorRate*100, s.P50, s.P95)
+		}
+		return list.RenderTable(table)
+	} else if utils.OutputFormat.Value == utils.OutputToml {
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, struct {
+			Functions []FunctionStats `toml:"functions"`
+		}{
+			Functions: stats,
+		})
+	}
+
+	return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, stats)
+}
+
+// Maps function id to slug.
+func listFunctionSlugs(ctx context.Context, projectRef string) (map[string]string, error) {
+	resp, err := utils.GetSupabase().V1ListAllFunctionsWithResponse(ctx, projectRef)
+	if err != nil {
+		return nil, errors.Errorf("failed to list functions: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return nil, errors.New("Unexpected error retrieving functions: " + string(resp.Body))
+	}
+	result := make(map[string]string, len(*resp.JSON200))
+	for _, f := range *resp.JSON200 {
+		result[f.Id] = f.Slug
+	}
+	return result, nil
+}
+
+func buildQuery(functionId string) string {
+	var where string
+	if len(functionId) > 0 {
+		where = fmt.Sprintf("\nwhere m.function_id = '%s'", functionId)
+	}
+	return fmt.Sprintf(`select m.function_id,
+  count(*) as invocations,
+  countif(response.status_code >= 500) as errors,
+  approx_quantiles(m.execution_time_ms, 100)[offset(50)] as p50,
+  approx_quantiles(m.execution_time_ms, 100)[offset(95)] as p95
+from function_edge_logs
+cross join unnest(metadata) as m
+cross join unnest(m.response) as response%s
+group by m.function_id`, where)
+}
+
+func queryStats(ctx context.Context, projectRef, sql string, start, end time.Time) ([]FunctionStats, error) {
+	resp, err := utils.GetSupabase().V1GetProjectLogsWithResponse(ctx, projectRef, &api.V1GetProjectLogsParams{
+		Sql:               &sql,
+		IsoTimestampStart: &start,
+		IsoTimestampEnd:   &end,
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to query stats: %w", err)
+	}
+	if resp.JSON200 == nil {
+		return nil, errors.New("Unexpected error retrieving stats: " + string(resp.Body))
+	}
+	if resp.JSON200.Error != nil && len(*resp.JSON200.Error) > 0 {
+		return nil, errors.Errorf("failed to query stats: %s", strings.TrimSpace(*resp.JSON200.Error))
+	}
+	if resp.JSON200.Result == nil {
+		return []FunctionStats{}, nil
+	}
+	stats := make([]FunctionStats, len(*resp.JSON200.Result))
+	for i, row := range *resp.JSON200.Result {
+		stats[i] = FunctionStats{
+			Slug:        fmt.Sprint(row["function_id"]),
+			Invocations: int64(toFloat(row["invocations"])),
+			Errors:      int64(toFloat(row["errors"])),
+			P50:         toFloat(row["p50"]),
+			P95:         toFloat(row["p95"]),
+		}
+		if stats[i].Invocations > 0 {
+			stats[i].ErrorRate = float64(stats[i].Errors) / float64(stats[i].Invocations)
+		}
+	}
+	return stats, nil
+}
+
+func toFloat(value any) float64 {
+	if v, ok := value.(float64); ok {
+		return v
+	}
+	return 0
+}
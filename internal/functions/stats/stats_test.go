@@ -0,0 +1,127 @@
+package stats
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/api"
+)
+
+func TestFunctionStats(t *testing.T) {
+	t.Run("aggregates all functions", func(t *testing.T) {
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{{Id: "test-id", Slug: "hello"}})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/"+project+"/analytics/endpoints/logs.all").
+			MatchParam("sql", "group by m.function_id").
+			MatchParam("iso_timestamp_start", ".+").
+			Reply(http.StatusOK).
+			JSON(api.V1AnalyticsResponse{Result: &[]map[string]interface{}{{
+				"function_id": "test-id",
+				"invocations": 200,
+				"errors":      5,
+				"p50":         42,
+				"p95":         180,
+			}, {
+				"function_id": "deleted-id",
+				"invocations": 1,
+				"errors":      0,
+			}}})
+		// Run test
+		err := Run(context.Background(), "", project, time.Hour, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("encodes stats of single function as json", func(t *testing.T) {
+		utils.OutputFormat.Value = utils.OutputJson
+		t.Cleanup(func() { utils.OutputFormat.Value = utils.OutputPretty })
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{{Id: "test-id", Slug: "hello"}})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/"+project+"/analytics/endpoints/logs.all").
+			MatchParam("sql", "where m.function_id = 'test-id'").
+			Reply(http.StatusOK).
+			JSON(api.V1AnalyticsResponse{Result: &[]map[string]interface{}{}})
+		// Run test
+		err := Run(context.Background(), "hello", project, time.Hour, afero.NewMemMapFs())
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on missing function", func(t *testing.T) {
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{})
+		// Run test
+		err := Run(context.Background(), "hello", project, time.Hour, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "does not exist on the Supabase project.")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		// Setup valid project ref
+		project := apitest.RandomProjectRef()
+		// Setup valid access token
+		token := apitest.RandomAccessToken(t)
+		t.Setenv("SUPABASE_ACCESS_TOKEN", string(token))
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{})
+		gock.New(utils.DefaultApiHost).
+			Get("/v1/projects/" + project + "/analytics/endpoints/logs.all").
+			Reply(http.StatusOK).
+			JSON(map[string]string{"error": "syntax error"})
+		// Run test
+		err := Run(context.Background(), "", project, time.Hour, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "failed to query stats: syntax error")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on invalid window", func(t *testing.T) {
+		// Run test
+		err := Run(context.Background(), "", "", 0, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorContains(t, err, "Invalid window 0s")
+	})
+}
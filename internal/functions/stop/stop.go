@@ -0,0 +1,30 @@
+package stop
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func Run(ctx context.Context, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	if err := utils.Docker.ContainerRemove(ctx, utils.EdgeRuntimeId, container.RemoveOptions{
+		RemoveVolumes: true,
+		Force:         true,
+	}); errdefs.IsNotFound(err) {
+		fmt.Fprintln(os.Stderr, "Edge Functions runtime is not running.")
+		return nil
+	} else if err != nil {
+		return errors.Errorf("failed to remove container: %w", err)
+	}
+	fmt.Println("Stopped serving " + utils.Bold(utils.FunctionsDir))
+	return nil
+}
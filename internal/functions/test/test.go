@@ -0,0 +1,190 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/docker/docker/api/types/container"
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/functions/deploy"
+	"github.com/supabase/cli/internal/functions/serve"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/status"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/config"
+)
+
+// Matches the default test file names of `deno test`.
+var testFilePattern = regexp.MustCompile(`(^|[._])test\.(ts|tsx|mts|js|mjs|jsx)$`)
+
+type testResult struct {
+	Slug     string
+	Files    int
+	Duration time.Duration
+	Err      error
+}
+
+func Run(ctx context.Context, slugs []string, envFilePaths []string, fsys afero.Fs) error {
+	// 1. Sanity checks.
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	if err := utils.AssertSupabaseDbIsRunning(); err != nil {
+		return err
+	}
+	if len(slugs) > 0 {
+		for _, s := range slugs {
+			if err := utils.ValidateFunctionSlug(s); err != nil {
+				return err
+			}
+		}
+	} else {
+		var err error
+		if slugs, err = deploy.GetFunctionSlugs(fsys); err != nil {
+			return err
+		}
+	}
+	functionConfig, err := deploy.GetFunctionConfig(utils.RemoveDuplicates(slugs), "", nil, fsys)
+	if err != nil {
+		return err
+	}
+	tests, err := findTestFiles(functionConfig, fsys)
+	if err != nil {
+		return err
+	}
+	if len(tests) == 0 {
+		return errors.Errorf("No test files found in %s", utils.Bold(utils.FunctionsDir))
+	}
+	// 2. Serve functions in an ephemeral runtime unless already serving.
+	if err := utils.AssertServiceIsRunning(ctx, utils.EdgeRuntimeId); err != nil {
+		if !errors.Is(err, utils.ErrServiceNotRunning) {
+			return err
+		}
+		if err := serve.Run(ctx, envFilePaths, nil, "", serve.RuntimeOption{Detach: true}, fsys); err != nil {
+			return err
+		}
+		defer func() {
+			if err := utils.Docker.ContainerRemove(context.Background(), utils.EdgeRuntimeId, container.RemoveOptions{
+				RemoveVolumes: true,
+				Force:         true,
+			}); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}()
+		if err := waitForRuntime(ctx); err != nil {
+			return err
+		}
+	}
+	// 3. Run deno test per function.
+	if err := utils.InstallOrUpgradeDeno(ctx, fsys); err != nil {
+		return err
+	}
+	denoPath, err := utils.GetDenoPath()
+	if err != nil {
+		return err
+	}
+	var results []testResult
+	for _, slug := range sortedKeys(tests) {
+		results = append(results, runTests(ctx, denoPath, slug, functionConfig[slug].ImportMap, tests[slug]))
+	}
+	return printResults(results)
+}
+
+// Detached runtime takes a few seconds to boot the main worker.
+const maxHealthRetries = 30
+
+func waitForRuntime(ctx context.Context) error {
+	policy := backoff.WithContext(backoff.WithMaxRetries(backoff.NewConstantBackOff(time.Second), maxHealthRetries), ctx)
+	if err := backoff.Retry(func() error {
+		_, err := status.GetFunctionsHealth(ctx)
+		return err
+	}, policy); err != nil {
+		return errors.Errorf("Edge Functions runtime is not healthy: %w", err)
+	}
+	return nil
+}
+
+// Searches the directory of each function's entrypoint, which may be outside the functions directory.
+func findTestFiles(functionConfig config.FunctionConfig, fsys afero.Fs) (map[string][]string, error) {
+	tests := map[string][]string{}
+	for slug, function := range functionConfig {
+		funcDir := filepath.Dir(function.Entrypoint)
+		if err := afero.Walk(fsys, funcDir, func(path string, info fs.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() && testFilePattern.MatchString(info.Name()) {
+				tests[slug] = append(tests[slug], path)
+			}
+			return nil
+		}); errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, errors.Errorf("failed to find test files: %w", err)
+		}
+	}
+	return tests, nil
+}
+
+func runTests(ctx context.Context, denoPath, slug, importMap string, files []string) testResult {
+	fmt.Fprintln(os.Stderr, "Testing Function:", utils.Bold(slug))
+	args := []string{"test", "--allow-net", "--allow-env", "--allow-read"}
+	if name := filepath.Base(importMap); name == "deno.json" || name == "deno.jsonc" {
+		args = append(args, "--config", importMap)
+	} else if len(importMap) > 0 {
+		args = append(args, "--import-map", importMap)
+	}
+	args = append(args, files...)
+	cmd := exec.CommandContext(ctx, denoPath, args...)
+	cmd.Env = append(os.Environ(),
+		"SUPABASE_URL="+utils.GetApiUrl(""),
+		"SUPABASE_ANON_KEY="+utils.Config.Auth.AnonKey,
+		"SUPABASE_SERVICE_ROLE_KEY="+utils.Config.Auth.ServiceRoleKey,
+		"FUNCTION_URL="+utils.GetApiUrl("/functions/v1/"+slug),
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	start := time.Now()
+	err := cmd.Run()
+	return testResult{Slug: slug, Files: len(files), Duration: time.Since(start), Err: err}
+}
+
+func printResults(results []testResult) error {
+	table := `|FUNCTION|FILES|RESULT|DURATION|
+|-|-|-|-|
+`
+	var failed int
+	for _, r := range results {
+		status := "PASSED"
+		if r.Err != nil {
+			failed++
+			status = "FAILED"
+		}
+		table += fmt.Sprintf("|`%s`|`%d`|`%s`|`%s`|\n", r.Slug, r.Files, status, r.Duration.Round(time.Millisecond))
+	}
+	if err := list.RenderTable(table); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return errors.Errorf("%d of %d Functions failed tests", failed, len(results))
+	}
+	return nil
+}
+
+func sortedKeys(tests map[string][]string) []string {
+	keys := make([]string, 0, len(tests))
+	for k := range tests {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,98 @@
+package keys
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/joho/godotenv"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/config"
+)
+
+const (
+	localEnvPath = ".env"
+	jwtSecretEnv = "SUPABASE_AUTH_JWT_SECRET"
+)
+
+// Generates the local JWT secret and persists it to .env, which config loads on every command.
+// Anon and service role keys are signed deterministically from the secret.
+func RunLocal(seed, format string, names CustomName, fsys afero.Fs) error {
+	secret, err := loadLocalSecret(seed, fsys)
+	if err != nil {
+		return err
+	}
+	anonKey, err := config.CustomClaims{Role: "anon"}.NewToken().SignedString([]byte(secret))
+	if err != nil {
+		return errors.Errorf("failed to sign anon key: %w", err)
+	}
+	serviceRoleKey, err := config.CustomClaims{Role: "service_role"}.NewToken().SignedString([]byte(secret))
+	if err != nil {
+		return errors.Errorf("failed to sign service_role key: %w", err)
+	}
+	fmt.Fprintln(os.Stderr, "Saved JWT secret to", utils.Bold(localEnvPath)+". Restart the local stack to apply new keys.")
+	return utils.EncodeOutput(format, os.Stdout, map[string]string{
+		names.JWTSecret:      secret,
+		names.AnonKey:        anonKey,
+		names.ServiceRoleKey: serviceRoleKey,
+	})
+}
+
+// Derives the secret from seed if specified, otherwise reuses the persisted secret or generates a random one.
+func loadLocalSecret(seed string, fsys afero.Fs) (string, error) {
+	data, err := afero.ReadFile(fsys, localEnvPath)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", errors.Errorf("failed to read env file: %w", err)
+	}
+	if len(seed) == 0 {
+		env, err := godotenv.Parse(bytes.NewReader(data))
+		if err != nil {
+			return "", errors.Errorf("failed to parse env file: %w", err)
+		}
+		if secret := env[jwtSecretEnv]; len(secret) > 0 {
+			return secret, nil
+		}
+	}
+	secret, err := newLocalSecret(seed)
+	if err != nil {
+		return "", err
+	}
+	if err := utils.WriteFile(localEnvPath, upsertEnv(data, jwtSecretEnv, secret), fsys); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+func newLocalSecret(seed string) (string, error) {
+	if len(seed) > 0 {
+		hash := sha256.Sum256([]byte("supabase:" + seed))
+		return hex.EncodeToString(hash[:]), nil
+	}
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", errors.Errorf("failed to generate JWT secret: %w", err)
+	}
+	return hex.EncodeToString(key), nil
+}
+
+// Replaces the value of an existing variable, keeping other lines and comments in place.
+func upsertEnv(data []byte, key, value string) []byte {
+	line := key + "=" + value
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	for i, l := range lines {
+		if strings.HasPrefix(strings.TrimPrefix(strings.TrimSpace(l), "export "), key+"=") {
+			lines[i] = line
+			return []byte(strings.Join(lines, "\n") + "\n")
+		}
+	}
+	if len(data) == 0 {
+		return []byte(line + "\n")
+	}
+	return []byte(strings.Join(append(lines, line), "\n") + "\n")
+}
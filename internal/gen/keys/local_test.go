@@ -0,0 +1,41 @@
+package keys
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadLocalSecret(t *testing.T) {
+	t.Run("derives secret from seed", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, localEnvPath, []byte("# app config\nexport SUPABASE_AUTH_JWT_SECRET=old\nAPP_NAME=demo\n"), 0644))
+		// Run test
+		secret, err := loadLocalSecret("ci", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Len(t, secret, 64)
+		other, err := newLocalSecret("ci")
+		require.NoError(t, err)
+		assert.Equal(t, other, secret)
+		data, err := afero.ReadFile(fsys, localEnvPath)
+		require.NoError(t, err)
+		assert.Equal(t, "# app config\nSUPABASE_AUTH_JWT_SECRET="+secret+"\nAPP_NAME=demo\n", string(data))
+	})
+
+	t.Run("reuses persisted random secret", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		secret, err := loadLocalSecret("", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Len(t, secret, 64)
+		reused, err := loadLocalSecret("", fsys)
+		assert.NoError(t, err)
+		assert.Equal(t, secret, reused)
+	})
+}
@@ -0,0 +1,104 @@
+package schema
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/status"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/fetcher"
+)
+
+const (
+	FormatOpenApi    = "openapi"
+	FormatJsonSchema = "jsonschema"
+)
+
+// Swagger 2.0 definitions are a subset of JSON Schema draft 4.
+const jsonSchemaDraft = "http://json-schema.org/draft-04/schema#"
+
+// Generates the OpenAPI spec served by the local PostgREST, or the JSON Schema of its definitions.
+func Run(ctx context.Context, format string, serviceRole bool, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	if err := utils.AssertServiceIsRunning(ctx, utils.RestId); err != nil {
+		return err
+	}
+	spec, err := fetchOpenApi(ctx, serviceRole)
+	if err != nil {
+		return err
+	}
+	if format == FormatJsonSchema {
+		if spec, err = toJsonSchema(spec); err != nil {
+			return err
+		}
+	}
+	return writeJson(spec, os.Stdout)
+}
+
+// PostgREST only lists tables and functions that the requesting role has privileges on.
+func fetchOpenApi(ctx context.Context, serviceRole bool) ([]byte, error) {
+	key := utils.Config.Auth.AnonKey
+	if serviceRole {
+		key = utils.Config.Auth.ServiceRoleKey
+	}
+	api := fetcher.NewFetcher(
+		utils.GetApiUrl(""),
+		fetcher.WithHTTPClient(status.NewKongClient()),
+		fetcher.WithBearerToken(key),
+		fetcher.WithUserAgent("SupabaseCLI/"+utils.Version),
+		fetcher.WithExpectedStatus(http.StatusOK),
+	)
+	resp, err := api.Send(ctx, http.MethodGet, "/rest/v1/", nil, func(req *http.Request) {
+		req.Header.Set("apikey", key)
+		req.Header.Set("Accept", "application/openapi+json")
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Errorf("failed to read openapi spec: %w", err)
+	}
+	return data, nil
+}
+
+func toJsonSchema(spec []byte) ([]byte, error) {
+	var openapi struct {
+		Definitions json.RawMessage `json:"definitions"`
+	}
+	if err := json.Unmarshal(spec, &openapi); err != nil {
+		return nil, errors.Errorf("failed to parse openapi spec: %w", err)
+	}
+	if len(openapi.Definitions) == 0 {
+		openapi.Definitions = json.RawMessage("{}")
+	}
+	result, err := json.Marshal(map[string]json.RawMessage{
+		"$schema":     json.RawMessage(`"` + jsonSchemaDraft + `"`),
+		"definitions": openapi.Definitions,
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to encode json schema: %w", err)
+	}
+	return result, nil
+}
+
+func writeJson(data []byte, w io.Writer) error {
+	var out bytes.Buffer
+	if err := json.Indent(&out, data, "", "  "); err != nil {
+		return errors.Errorf("failed to format json: %w", err)
+	}
+	out.WriteByte('\n')
+	if _, err := out.WriteTo(w); err != nil {
+		return errors.Errorf("failed to write schema: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package schema
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+const testSpec = `{"swagger":"2.0","paths":{},"definitions":{"todos":{"required":["id"],"properties":{"id":{"format":"bigint","type":"integer"}},"type":"object"}}}`
+
+func TestGenSchemaCommand(t *testing.T) {
+	t.Run("generates json schema from local api", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_rest_test/json").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{})
+		gock.New("http://127.0.0.1:54321").
+			Get("/rest/v1/").
+			MatchHeader("Accept", "application/openapi\\+json").
+			MatchHeader("Authorization", "^Bearer ey").
+			Reply(http.StatusOK).
+			BodyString(testSpec)
+		// Run test
+		err := Run(context.Background(), FormatJsonSchema, false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error when api is not running", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_rest_test/json").
+			Reply(http.StatusNotFound)
+		// Run test
+		err := Run(context.Background(), FormatOpenApi, false, fsys)
+		// Check error
+		assert.ErrorIs(t, err, utils.ErrServiceNotRunning)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on service unavailable", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_rest_test/json").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{})
+		gock.New("http://127.0.0.1:54321").
+			Get("/rest/v1/").
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := Run(context.Background(), FormatOpenApi, true, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Error status 503")
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestToJsonSchema(t *testing.T) {
+	result, err := toJsonSchema([]byte(testSpec))
+	// Check error
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "http://json-schema.org/draft-04/schema#",
+		"definitions": {"todos":{"required":["id"],"properties":{"id":{"format":"bigint","type":"integer"}},"type":"object"}}
+	}`, string(result))
+}
@@ -0,0 +1,119 @@
+package types
+
+import (
+	"fmt"
+	"go/format"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/go-errors/errors"
+)
+
+const (
+	GoNullablePointer = "pointer"
+	GoNullableSql     = "sql"
+)
+
+var goTypes = map[string]string{
+	"bool":        "bool",
+	"int2":        "int16",
+	"int4":        "int32",
+	"int8":        "int64",
+	"float4":      "float32",
+	"float8":      "float64",
+	"numeric":     "float64",
+	"text":        "string",
+	"varchar":     "string",
+	"bpchar":      "string",
+	"char":        "string",
+	"citext":      "string",
+	"name":        "string",
+	"uuid":        "string",
+	"date":        "string",
+	"time":        "string",
+	"timetz":      "string",
+	"timestamp":   "string",
+	"timestamptz": "time.Time",
+	"interval":    "string",
+	"json":        "json.RawMessage",
+	"jsonb":       "json.RawMessage",
+	"bytea":       "[]byte",
+}
+
+var goSqlNullTypes = map[string]string{
+	"bool":      "sql.NullBool",
+	"int16":     "sql.NullInt16",
+	"int32":     "sql.NullInt32",
+	"int64":     "sql.NullInt64",
+	"float64":   "sql.NullFloat64",
+	"string":    "sql.NullString",
+	"time.Time": "sql.NullTime",
+}
+
+func renderGo(c catalog, nullable string, w io.Writer) error {
+	var body strings.Builder
+	used := map[string]bool{}
+	for _, e := range c.Enums {
+		name := toPascalCase(e.Schema) + toPascalCase(e.Name)
+		fmt.Fprintf(&body, "type %s string\n\nconst (\n", name)
+		for _, label := range e.Labels {
+			fmt.Fprintf(&body, "%s%s %s = %s\n", name, toPascalCase(label), name, strconv.Quote(label))
+		}
+		fmt.Fprint(&body, ")\n\n")
+	}
+	for _, t := range c.Tables {
+		fmt.Fprintf(&body, "type %s%s struct {\n", toPascalCase(t.Schema), toPascalCase(t.Name))
+		for _, col := range t.Columns {
+			goType := toGoType(c, col, nullable)
+			for _, pkg := range []string{"database/sql", "encoding/json", "time"} {
+				if strings.Contains(goType, pkg[strings.LastIndex(pkg, "/")+1:]+".") {
+					used[pkg] = true
+				}
+			}
+			fmt.Fprintf(&body, "%s %s `json:\"%s\" db:\"%s\"`\n", toPascalCase(col.Name), goType, col.Name, col.Name)
+		}
+		fmt.Fprint(&body, "}\n\n")
+	}
+	var imports []string
+	for _, pkg := range []string{"database/sql", "encoding/json", "time"} {
+		if used[pkg] {
+			imports = append(imports, strconv.Quote(pkg))
+		}
+	}
+	src := "package database\n\n"
+	if len(imports) > 0 {
+		src += "import (\n" + strings.Join(imports, "\n") + "\n)\n\n"
+	}
+	formatted, err := format.Source([]byte(src + body.String()))
+	if err != nil {
+		return errors.Errorf("failed to format go types: %w", err)
+	}
+	if _, err := w.Write(formatted); err != nil {
+		return errors.Errorf("failed to write go types: %w", err)
+	}
+	return nil
+}
+
+func toGoType(c catalog, col column, nullable string) string {
+	result, ok := goTypes[col.Type]
+	if name, found := c.findType(col.TypeSchema, col.Type); found {
+		result, ok = name, true
+	} else if !ok {
+		result = "interface{}"
+	}
+	if col.IsArray {
+		return "[]" + result
+	}
+	// Slices, raw messages, and interfaces are already nullable
+	if !col.IsNullable || !ok || strings.HasPrefix(result, "[]") || result == "json.RawMessage" {
+		return result
+	}
+	if nullable == GoNullableSql {
+		if nullType, ok := goSqlNullTypes[result]; ok {
+			return nullType
+		}
+		return "sql.Null[" + result + "]"
+	}
+	return "*" + result
+}
@@ -0,0 +1,147 @@
+package types
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var testCatalog = catalog{
+	Tables: []table{{
+		Schema: "public",
+		Name:   "user_profiles",
+		Columns: []column{
+			{Name: "id", Type: "int8"},
+			{Name: "email", Type: "text", IsNullable: true},
+			{Name: "status", Type: "status", TypeSchema: "public", IsNullable: true},
+			{Name: "tags", Type: "text", IsArray: true, IsNullable: true},
+			{Name: "metadata", Type: "jsonb", IsNullable: true},
+			{Name: "created_at", Type: "timestamptz", IsNullable: true},
+			{Name: "location", Type: "geography", IsNullable: true},
+		},
+	}},
+	Enums: []enum{{
+		Schema: "public",
+		Name:   "status",
+		Labels: []string{"active", "on hold"},
+	}},
+}
+
+func TestRenderGo(t *testing.T) {
+	t.Run("renders nullable columns as pointers", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := renderGo(testCatalog, GoNullablePointer, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, `package database
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type PublicStatus string
+
+const (
+	PublicStatusActive PublicStatus = "active"
+	PublicStatusOnHold PublicStatus = "on hold"
+)
+
+type PublicUserProfiles struct {
+	Id        int64           `+"`json:\"id\" db:\"id\"`"+`
+	Email     *string         `+"`json:\"email\" db:\"email\"`"+`
+	Status    *PublicStatus   `+"`json:\"status\" db:\"status\"`"+`
+	Tags      []string        `+"`json:\"tags\" db:\"tags\"`"+`
+	Metadata  json.RawMessage `+"`json:\"metadata\" db:\"metadata\"`"+`
+	CreatedAt *time.Time      `+"`json:\"created_at\" db:\"created_at\"`"+`
+	Location  interface{}     `+"`json:\"location\" db:\"location\"`"+`
+}
+`, out.String())
+	})
+
+	t.Run("renders nullable columns as sql types", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := renderGo(testCatalog, GoNullableSql, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "\"database/sql\"")
+		assert.Contains(t, out.String(), "Email     sql.NullString")
+		assert.Contains(t, out.String(), "Status    sql.Null[PublicStatus]")
+		assert.Contains(t, out.String(), "CreatedAt sql.NullTime")
+	})
+
+	t.Run("renders dates without time zone as strings", func(t *testing.T) {
+		c := catalog{Tables: []table{{
+			Schema: "public",
+			Name:   "events",
+			Columns: []column{
+				{Name: "day", Type: "date"},
+				{Name: "starts_at", Type: "timestamp"},
+			},
+		}}}
+		var out bytes.Buffer
+		// Run test
+		err := renderGo(c, GoNullablePointer, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "Day      string")
+		assert.Contains(t, out.String(), "StartsAt string")
+		assert.NotContains(t, out.String(), "\"time\"")
+	})
+}
+
+func TestGenerateGo(t *testing.T) {
+	dbConfig := pgconn.Config{
+		Host:     "db.supabase.co",
+		Port:     5432,
+		User:     "admin",
+		Password: "password",
+		Database: "postgres",
+	}
+
+	t.Run("introspects remote database", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listColumnsQuery, []string{"public"}).
+			Reply("SELECT 1", column{Schema: "public", Table: "todos", Name: "done", Type: "bool"}).
+			Query(listEnumsQuery, []string{"public"}).
+			Reply("SELECT 0")
+		var out bytes.Buffer
+		// Run test
+		err := generate(context.Background(), dbConfig, LangGo, []string{"public"}, nil, nil, false, "", GoNullablePointer, "", "", &out, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "type PublicTodos struct {")
+	})
+
+	t.Run("throws error on introspect failure", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(listColumnsQuery, []string{"public"}).
+			ReplyError(pgerrcode.InsufficientPrivilege, "permission denied for table pg_attribute")
+		var out bytes.Buffer
+		// Run test
+		err := generate(context.Background(), dbConfig, LangGo, []string{"public"}, nil, nil, false, "", GoNullablePointer, "", "", &out, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "permission denied for table pg_attribute")
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("throws error on filter without native generator", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := generate(context.Background(), dbConfig, LangGo, []string{"public"}, []string{"todos"}, nil, false, "", "", "", "", &out)
+		// Check error
+		assert.ErrorContains(t, err, "--include and --exclude are not supported for go types without --go-native")
+		assert.Empty(t, out.String())
+	})
+}
@@ -0,0 +1,178 @@
+package types
+
+import (
+	"context"
+	_ "embed"
+	"path"
+	"strings"
+	"unicode"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/pkg/pgxv5"
+)
+
+var (
+	//go:embed templates/columns.sql
+	listColumnsQuery string
+	//go:embed templates/enums.sql
+	listEnumsQuery string
+)
+
+type column struct {
+	Schema      string `db:"schema"`
+	Table       string `db:"table"`
+	IsView      bool   `db:"is_view"`
+	IsComposite bool   `db:"is_composite"`
+	Name        string `db:"name"`
+	Type        string `db:"type"`
+	TypeSchema  string `db:"type_schema"`
+	IsArray     bool   `db:"is_array"`
+	IsNullable  bool   `db:"is_nullable"`
+}
+
+type table struct {
+	Schema      string
+	Name        string
+	IsView      bool
+	IsComposite bool
+	Columns     []column
+}
+
+type enum struct {
+	Schema string   `db:"schema"`
+	Name   string   `db:"name"`
+	Labels []string `db:"labels"`
+}
+
+// Catalog of the database objects that types are generated from.
+type catalog struct {
+	Tables []table
+	Enums  []enum
+	// Full catalog for resolving types referenced by a subset, ie. when splitting output by table
+	parent *catalog
+}
+
+// Finds the generated type name of a user defined enum or composite type.
+func (c catalog) findType(schema, name string) (string, bool) {
+	if c.declares(schema, name) {
+		return toPascalCase(schema) + toPascalCase(name), true
+	}
+	if c.parent != nil {
+		return c.parent.findType(schema, name)
+	}
+	return "", false
+}
+
+func (c catalog) isEnum(schema, name string) bool {
+	for _, e := range c.Enums {
+		if e.Schema == schema && e.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Reports whether a user defined enum or composite type is declared in this catalog.
+func (c catalog) declares(schema, name string) bool {
+	if c.isEnum(schema, name) {
+		return true
+	}
+	for _, t := range c.Tables {
+		if t.IsComposite && t.Schema == schema && t.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func introspect(ctx context.Context, conn *pgx.Conn, schemas []string) (catalog, error) {
+	var result catalog
+	rows, err := conn.Query(ctx, listColumnsQuery, schemas)
+	if err != nil {
+		return result, errors.Errorf("failed to list columns: %w", err)
+	}
+	columns, err := pgxv5.CollectRows[column](rows)
+	if err != nil {
+		return result, err
+	}
+	// Columns are ordered by table
+	for _, c := range columns {
+		if n := len(result.Tables); n == 0 || result.Tables[n-1].Schema != c.Schema || result.Tables[n-1].Name != c.Table {
+			result.Tables = append(result.Tables, table{Schema: c.Schema, Name: c.Table, IsView: c.IsView, IsComposite: c.IsComposite})
+		}
+		last := &result.Tables[len(result.Tables)-1]
+		last.Columns = append(last.Columns, c)
+	}
+	result.Enums, err = listEnums(ctx, conn, schemas)
+	return result, err
+}
+
+func listEnums(ctx context.Context, conn *pgx.Conn, schemas []string) ([]enum, error) {
+	rows, err := conn.Query(ctx, listEnumsQuery, schemas)
+	if err != nil {
+		return nil, errors.Errorf("failed to list enums: %w", err)
+	}
+	return pgxv5.CollectRows[enum](rows)
+}
+
+// Keeps tables and views matching any include pattern and no exclude pattern. Patterns without
+// a schema are matched against the table name only. Composite types are always kept.
+func filterTables(tables []table, include, exclude []string) ([]table, error) {
+	keep, err := newTableFilter(include, exclude)
+	if err != nil {
+		return nil, err
+	}
+	var result []table
+	for _, t := range tables {
+		if t.IsComposite || keep(t.Schema, t.Name) {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+func newTableFilter(include, exclude []string) (func(schema, name string) bool, error) {
+	for _, p := range append(include, exclude...) {
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, errors.Errorf("invalid table pattern %s: %w", p, err)
+		}
+	}
+	matchAny := func(schema, name string, patterns []string) bool {
+		for _, p := range patterns {
+			qualified := name
+			if strings.Contains(p, ".") {
+				qualified = schema + "." + name
+			}
+			if matched, _ := path.Match(p, qualified); matched {
+				return true
+			}
+		}
+		return false
+	}
+	return func(schema, name string) bool {
+		return (len(include) == 0 || matchAny(schema, name, include)) && !matchAny(schema, name, exclude)
+	}, nil
+}
+
+// Converts a database identifier to an exported name, ie. user_profiles becomes UserProfiles.
+func toPascalCase(name string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	result := sb.String()
+	if len(result) == 0 || unicode.IsDigit(rune(result[0])) {
+		result = "T" + result
+	}
+	return result
+}
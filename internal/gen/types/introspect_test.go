@@ -0,0 +1,37 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterTables(t *testing.T) {
+	tables := []table{
+		{Schema: "public", Name: "profiles"},
+		{Schema: "public", Name: "profiles_audit"},
+		{Schema: "extensions", Name: "pg_stat_statements"},
+		{Schema: "public", Name: "address", IsComposite: true},
+	}
+
+	t.Run("keeps all tables by default", func(t *testing.T) {
+		result, err := filterTables(tables, nil, nil)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, tables, result)
+	})
+
+	t.Run("filters by schema qualified patterns", func(t *testing.T) {
+		result, err := filterTables(tables, []string{"public.*"}, []string{"*_audit"})
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []table{tables[0], tables[3]}, result)
+	})
+
+	t.Run("throws error on invalid pattern", func(t *testing.T) {
+		result, err := filterTables(tables, []string{"[public"}, nil)
+		// Check error
+		assert.ErrorContains(t, err, "invalid table pattern [public: syntax error in pattern")
+		assert.Nil(t, result)
+	})
+}
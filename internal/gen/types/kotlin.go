@@ -0,0 +1,128 @@
+package types
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-errors/errors"
+)
+
+var kotlinTypes = map[string]string{
+	"bool":        "Boolean",
+	"int2":        "Short",
+	"int4":        "Int",
+	"int8":        "Long",
+	"float4":      "Float",
+	"float8":      "Double",
+	"numeric":     "Double",
+	"text":        "String",
+	"varchar":     "String",
+	"bpchar":      "String",
+	"char":        "String",
+	"citext":      "String",
+	"name":        "String",
+	"uuid":        "String",
+	"date":        "String",
+	"time":        "String",
+	"timetz":      "String",
+	"timestamp":   "String",
+	"timestamptz": "String",
+	"interval":    "String",
+	"bytea":       "String",
+}
+
+var kotlinKeywords = []string{
+	"as", "break", "class", "continue", "do", "else", "false", "for", "fun", "if", "in",
+	"interface", "is", "null", "object", "package", "return", "super", "this", "throw",
+	"true", "try", "typealias", "typeof", "val", "var", "when", "while",
+}
+
+// Renders kotlinx serializable classes following supabase-kt conventions.
+func renderKotlin(c catalog, enumStyle string, w io.Writer) error {
+	var body strings.Builder
+	for _, e := range c.Enums {
+		if enumStyle == EnumStyleConstObject {
+			fmt.Fprintf(&body, "object %s%s {\n", toPascalCase(e.Schema), toPascalCase(e.Name))
+			for _, label := range e.Labels {
+				fmt.Fprintf(&body, "    const val %s = %s\n", toUpperSnakeCase(label), strconv.Quote(label))
+			}
+			fmt.Fprint(&body, "}\n\n")
+			continue
+		}
+		fmt.Fprintf(&body, "@Serializable\nenum class %s%s {\n", toPascalCase(e.Schema), toPascalCase(e.Name))
+		for _, label := range e.Labels {
+			fmt.Fprintf(&body, "    @SerialName(%s)\n    %s,\n", strconv.Quote(label), toUpperSnakeCase(label))
+		}
+		fmt.Fprint(&body, "}\n\n")
+	}
+	for _, t := range c.Tables {
+		fmt.Fprintf(&body, "@Serializable\ndata class %s%s(\n", toPascalCase(t.Schema), toPascalCase(t.Name))
+		for _, col := range t.Columns {
+			name := toCamelCase(col.Name)
+			if name != col.Name {
+				fmt.Fprintf(&body, "    @SerialName(%s)\n", strconv.Quote(col.Name))
+			}
+			if slices.Contains(kotlinKeywords, name) {
+				name = "`" + name + "`"
+			}
+			fmt.Fprintf(&body, "    val %s: %s,\n", name, toKotlinType(c, col))
+		}
+		fmt.Fprint(&body, ")\n\n")
+	}
+	imports := []string{"kotlinx.serialization.SerialName", "kotlinx.serialization.Serializable"}
+	if strings.Contains(body.String(), "JsonElement") {
+		imports = append(imports, "kotlinx.serialization.json.JsonElement")
+	}
+	var src string
+	for _, i := range imports {
+		src += "import " + i + "\n"
+	}
+	src += "\n" + strings.TrimSuffix(body.String(), "\n")
+	if _, err := io.WriteString(w, src); err != nil {
+		return errors.Errorf("failed to write kotlin types: %w", err)
+	}
+	return nil
+}
+
+func toKotlinType(c catalog, col column) string {
+	result, ok := kotlinTypes[col.Type]
+	if name, found := c.findType(col.TypeSchema, col.Type); found {
+		result = name
+	} else if !ok {
+		result = "JsonElement"
+	}
+	if col.IsArray {
+		result = "List<" + result + ">"
+	}
+	if col.IsNullable {
+		return result + "? = null"
+	}
+	return result
+}
+
+func toCamelCase(name string) string {
+	result := []rune(toPascalCase(name))
+	result[0] = unicode.ToLower(result[0])
+	return string(result)
+}
+
+// Converts an enum label to a constant name, ie. on hold becomes ON_HOLD.
+func toUpperSnakeCase(label string) string {
+	var sb strings.Builder
+	for _, r := range label {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(unicode.ToUpper(r))
+		} else if sb.Len() > 0 && !strings.HasSuffix(sb.String(), "_") {
+			sb.WriteRune('_')
+		}
+	}
+	result := strings.TrimSuffix(sb.String(), "_")
+	if len(result) == 0 || unicode.IsDigit(rune(result[0])) {
+		result = "_" + result
+	}
+	return result
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderKotlin(t *testing.T) {
+	t.Run("renders serializable classes", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := renderKotlin(testCatalog, "", &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, `import kotlinx.serialization.SerialName
+import kotlinx.serialization.Serializable
+import kotlinx.serialization.json.JsonElement
+
+@Serializable
+enum class PublicStatus {
+    @SerialName("active")
+    ACTIVE,
+    @SerialName("on hold")
+    ON_HOLD,
+}
+
+@Serializable
+data class PublicUserProfiles(
+    val id: Long,
+    val email: String? = null,
+    val status: PublicStatus? = null,
+    val tags: List<String>? = null,
+    val metadata: JsonElement? = null,
+    @SerialName("created_at")
+    val createdAt: String? = null,
+    val location: JsonElement? = null,
+)
+`, out.String())
+	})
+
+	t.Run("escapes reserved keywords", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := renderKotlin(catalog{Tables: []table{{
+			Schema:  "public",
+			Name:    "rules",
+			Columns: []column{{Name: "when", Type: "timestamptz"}},
+		}}}, "", &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "    val `when`: String,\n")
+		assert.NotContains(t, out.String(), "JsonElement")
+	})
+}
@@ -0,0 +1,190 @@
+package types
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-errors/errors"
+)
+
+const (
+	PythonFlavorPydantic  = "pydantic"
+	PythonFlavorDataclass = "dataclass"
+)
+
+var pythonTypes = map[string]string{
+	"bool":        "bool",
+	"int2":        "int",
+	"int4":        "int",
+	"int8":        "int",
+	"float4":      "float",
+	"float8":      "float",
+	"numeric":     "float",
+	"text":        "str",
+	"varchar":     "str",
+	"bpchar":      "str",
+	"char":        "str",
+	"citext":      "str",
+	"name":        "str",
+	"uuid":        "str",
+	"date":        "datetime.date",
+	"time":        "datetime.time",
+	"timetz":      "datetime.time",
+	"timestamp":   "datetime.datetime",
+	"timestamptz": "datetime.datetime",
+	"interval":    "str",
+	"bytea":       "str",
+}
+
+var pythonImportPattern = regexp.MustCompile(`\b(Any|List|datetime)\b`)
+
+var pythonKeywords = []string{
+	"False", "None", "True", "and", "as", "assert", "async", "await", "break", "class",
+	"continue", "def", "del", "elif", "else", "except", "finally", "for", "from", "global",
+	"if", "import", "in", "is", "lambda", "nonlocal", "not", "or", "pass", "raise", "return",
+	"try", "while", "with", "yield",
+}
+
+// Renders models for tables, views, and composite types, with enums as string enums.
+func renderPython(c catalog, flavor, enumStyle string, w io.Writer) error {
+	var body strings.Builder
+	used := map[string]bool{}
+	external := map[string]bool{}
+	for _, e := range c.Enums {
+		if enumStyle == EnumStyleUnion {
+			labels := make([]string, len(e.Labels))
+			for i, label := range e.Labels {
+				labels[i] = strconv.Quote(label)
+			}
+			used["Literal"] = true
+			fmt.Fprintf(&body, "\n\n%s%s = Literal[%s]\n", toPascalCase(e.Schema), toPascalCase(e.Name), strings.Join(labels, ", "))
+			continue
+		}
+		fmt.Fprintf(&body, "\n\nclass %s%s(str, Enum):\n", toPascalCase(e.Schema), toPascalCase(e.Name))
+		for _, label := range e.Labels {
+			fmt.Fprintf(&body, "    %s = %s\n", toUpperSnakeCase(label), strconv.Quote(label))
+		}
+	}
+	// Composite types are declared first as they may be referenced by tables
+	tables := slices.Clone(c.Tables)
+	slices.SortStableFunc(tables, func(a, b table) int {
+		if a.IsComposite == b.IsComposite {
+			return 0
+		} else if a.IsComposite {
+			return -1
+		}
+		return 1
+	})
+	for _, t := range tables {
+		name := toPascalCase(t.Schema) + toPascalCase(t.Name)
+		if flavor == PythonFlavorDataclass {
+			fmt.Fprintf(&body, "\n\n@dataclass(kw_only=True)\nclass %s:\n", name)
+		} else {
+			fmt.Fprintf(&body, "\n\nclass %s(BaseModel):\n", name)
+		}
+		for _, col := range t.Columns {
+			field := toPythonIdentifier(col.Name)
+			pyType := toPythonType(c, col)
+			for _, m := range pythonImportPattern.FindAllString(pyType, -1) {
+				used[m] = true
+			}
+			if name, found := c.findType(col.TypeSchema, col.Type); found && !c.declares(col.TypeSchema, col.Type) {
+				external[name] = true
+			}
+			aliased := flavor == PythonFlavorPydantic && field != col.Name
+			switch {
+			case aliased && col.IsNullable:
+				used["Optional"] = true
+				fmt.Fprintf(&body, "    %s: Optional[%s] = Field(default=None, alias=%s)\n", field, pyType, strconv.Quote(col.Name))
+			case aliased:
+				fmt.Fprintf(&body, "    %s: %s = Field(alias=%s)\n", field, pyType, strconv.Quote(col.Name))
+			case col.IsNullable:
+				used["Optional"] = true
+				fmt.Fprintf(&body, "    %s: Optional[%s] = None\n", field, pyType)
+			default:
+				fmt.Fprintf(&body, "    %s: %s\n", field, pyType)
+			}
+			if aliased {
+				used["Field"] = true
+			}
+		}
+		if len(t.Columns) == 0 {
+			fmt.Fprint(&body, "    pass\n")
+		}
+	}
+	imports := []string{"from __future__ import annotations", ""}
+	if used["datetime"] {
+		imports = append(imports, "import datetime")
+	}
+	if len(tables) > 0 && flavor == PythonFlavorDataclass {
+		imports = append(imports, "from dataclasses import dataclass")
+	}
+	if len(c.Enums) > 0 && enumStyle != EnumStyleUnion {
+		imports = append(imports, "from enum import Enum")
+	}
+	var typing []string
+	for _, name := range []string{"Any", "List", "Literal", "Optional"} {
+		if used[name] {
+			typing = append(typing, name)
+		}
+	}
+	if len(typing) > 0 {
+		imports = append(imports, "from typing import "+strings.Join(typing, ", "))
+	}
+	if len(tables) > 0 && flavor != PythonFlavorDataclass {
+		if used["Field"] {
+			imports = append(imports, "", "from pydantic import BaseModel, Field")
+		} else {
+			imports = append(imports, "", "from pydantic import BaseModel")
+		}
+	}
+	// Types declared in other modules of a split package
+	if len(external) > 0 {
+		names := slices.Sorted(maps.Keys(external))
+		imports = append(imports, "", "from ."+splitTypesModule+" import "+strings.Join(names, ", "))
+	}
+	if _, err := io.WriteString(w, strings.Join(imports, "\n")+"\n"+body.String()); err != nil {
+		return errors.Errorf("failed to write python types: %w", err)
+	}
+	return nil
+}
+
+func toPythonType(c catalog, col column) string {
+	result, ok := pythonTypes[col.Type]
+	if name, found := c.findType(col.TypeSchema, col.Type); found {
+		result = name
+	} else if !ok {
+		result = "Any"
+	}
+	if col.IsArray {
+		result = "List[" + result + "]"
+	}
+	return result
+}
+
+// Replaces characters that are invalid in identifiers, ie. a column named from becomes from_.
+func toPythonIdentifier(name string) string {
+	var sb strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteRune('_')
+		}
+	}
+	result := sb.String()
+	// Leading underscores are private attributes in pydantic
+	if len(result) == 0 || result[0] == '_' || unicode.IsDigit(rune(result[0])) {
+		result = "field_" + result
+	}
+	if slices.Contains(pythonKeywords, result) {
+		result += "_"
+	}
+	return result
+}
@@ -0,0 +1,104 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderPython(t *testing.T) {
+	t.Run("renders pydantic models", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := renderPython(testCatalog, PythonFlavorPydantic, "", &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, `from __future__ import annotations
+
+import datetime
+from enum import Enum
+from typing import Any, List, Optional
+
+from pydantic import BaseModel
+
+
+class PublicStatus(str, Enum):
+    ACTIVE = "active"
+    ON_HOLD = "on hold"
+
+
+class PublicUserProfiles(BaseModel):
+    id: int
+    email: Optional[str] = None
+    status: Optional[PublicStatus] = None
+    tags: Optional[List[str]] = None
+    metadata: Optional[Any] = None
+    created_at: Optional[datetime.datetime] = None
+    location: Optional[Any] = None
+`, out.String())
+	})
+
+	t.Run("renders enums as literal unions", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := renderPython(catalog{Enums: testCatalog.Enums}, PythonFlavorPydantic, EnumStyleUnion, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, `from __future__ import annotations
+
+from typing import Literal
+
+
+PublicStatus = Literal["active", "on hold"]
+`, out.String())
+	})
+
+	t.Run("renders composite types before dataclasses", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := renderPython(catalog{Tables: []table{{
+			Schema:  "public",
+			Name:    "orders",
+			Columns: []column{{Name: "from", Type: "text"}, {Name: "ship_to", Type: "address", TypeSchema: "public", IsNullable: true}},
+		}, {
+			Schema:      "public",
+			Name:        "address",
+			IsComposite: true,
+			Columns:     []column{{Name: "city", Type: "text", IsNullable: true}},
+		}}}, PythonFlavorDataclass, "", &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, `from __future__ import annotations
+
+from dataclasses import dataclass
+from typing import Optional
+
+
+@dataclass(kw_only=True)
+class PublicAddress:
+    city: Optional[str] = None
+
+
+@dataclass(kw_only=True)
+class PublicOrders:
+    from_: str
+    ship_to: Optional[PublicAddress] = None
+`, out.String())
+	})
+
+	t.Run("aliases renamed pydantic fields", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := renderPython(catalog{Tables: []table{{
+			Schema:  "public",
+			Name:    "events",
+			Columns: []column{{Name: "class", Type: "text"}, {Name: "_meta", Type: "jsonb", IsNullable: true}},
+		}}}, PythonFlavorPydantic, "", &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "from pydantic import BaseModel, Field\n")
+		assert.Contains(t, out.String(), `    class_: str = Field(alias="class")`+"\n")
+		assert.Contains(t, out.String(), `    field__meta: Optional[Any] = Field(default=None, alias="_meta")`+"\n")
+	})
+}
@@ -0,0 +1,150 @@
+package types
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Enums and composite types are shared by all tables, so they are written to a common file.
+const splitTypesModule = "types"
+
+// Lists the files written by the previous run, so that files of dropped tables can be removed.
+const splitManifest = ".generated"
+
+// Writes one file per table or view to dir, plus a file of shared types and an index for python.
+// TypeScript and Swift types are generated by pg-meta as a single file, so they cannot be split.
+func RunSplit(ctx context.Context, dbConfig pgconn.Config, lang string, schemas, include, exclude []string, goNullable, pythonFlavor, enumStyle, dir string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if lang != LangGo && lang != LangKotlin && lang != LangPython {
+		return errors.Errorf("Splitting output by table is not supported for %s types. Use --split-dir with go, kotlin, or python instead.", lang)
+	}
+	if lang == LangGo && len(goNullable) == 0 {
+		return errors.New("Splitting output by table requires --go-native for go types.")
+	}
+	result, err := loadCatalog(ctx, dbConfig, defaultSchemas(schemas), include, exclude, options...)
+	if err != nil {
+		return err
+	}
+	if result, err = applyEnumStyle(result, lang, enumStyle); err != nil {
+		return err
+	}
+	files, err := splitCatalog(result, lang, goNullable, pythonFlavor, enumStyle)
+	if err != nil {
+		return err
+	}
+	if err := removeGenerated(dir, fsys); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(files))
+	for name, data := range files {
+		if err := utils.WriteFile(filepath.Join(dir, name), data, fsys); err != nil {
+			return err
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if err := utils.WriteFile(filepath.Join(dir, splitManifest), []byte(strings.Join(names, "\n")+"\n"), fsys); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "Generated", len(files), "files in", utils.Bold(dir))
+	return nil
+}
+
+// Only files listed in the manifest are removed, leaving any other files in dir untouched.
+func removeGenerated(dir string, fsys afero.Fs) error {
+	data, err := afero.ReadFile(fsys, filepath.Join(dir, splitManifest))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return errors.Errorf("failed to read manifest: %w", err)
+	}
+	for _, name := range strings.Split(string(data), "\n") {
+		// Ignore entries that escape dir
+		if len(name) == 0 || name != filepath.Base(name) {
+			continue
+		}
+		if err := fsys.Remove(filepath.Join(dir, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return errors.Errorf("failed to remove generated file: %w", err)
+		}
+	}
+	return nil
+}
+
+// Renders the contents of each file in the split output, keyed by file name.
+func splitCatalog(c catalog, lang, goNullable, pythonFlavor, enumStyle string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	add := func(name string, subset catalog) error {
+		subset.parent = &c
+		var out bytes.Buffer
+		if err := render(subset, lang, goNullable, pythonFlavor, enumStyle, &out); err != nil {
+			return err
+		}
+		files[name] = out.Bytes()
+		return nil
+	}
+	shared := catalog{Enums: c.Enums}
+	var index []string
+	for _, t := range c.Tables {
+		if t.IsComposite {
+			shared.Tables = append(shared.Tables, t)
+			continue
+		}
+		name := toFileName(t.Schema + "." + t.Name)
+		if err := add(splitFile(lang, name), catalog{Tables: []table{t}}); err != nil {
+			return nil, err
+		}
+		index = append(index, fmt.Sprintf("from .%s import %s%s", name, toPascalCase(t.Schema), toPascalCase(t.Name)))
+	}
+	if len(shared.Enums) > 0 || len(shared.Tables) > 0 {
+		if err := add(splitFile(lang, splitTypesModule), shared); err != nil {
+			return nil, err
+		}
+		var names []string
+		for _, e := range shared.Enums {
+			names = append(names, toPascalCase(e.Schema)+toPascalCase(e.Name))
+		}
+		for _, t := range shared.Tables {
+			names = append(names, toPascalCase(t.Schema)+toPascalCase(t.Name))
+		}
+		index = append([]string{"from ." + splitTypesModule + " import " + strings.Join(names, ", ")}, index...)
+	}
+	// Go and kotlin files share a package, so only python needs an index
+	if lang == LangPython {
+		files["__init__.py"] = []byte(strings.Join(index, "\n") + "\n")
+	}
+	return files, nil
+}
+
+func splitFile(lang, name string) string {
+	switch lang {
+	case LangKotlin:
+		return toPascalCase(name) + ".kt"
+	case LangPython:
+		return name + ".py"
+	}
+	return name + ".go"
+}
+
+// Converts a database identifier to a lowercase file name, ie. public.User Profiles becomes public_user_profiles.
+func toFileName(name string) string {
+	var sb strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(unicode.ToLower(r))
+		} else {
+			sb.WriteRune('_')
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,79 @@
+package types
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitCatalog(t *testing.T) {
+	t.Run("splits python models by table", func(t *testing.T) {
+		files, err := splitCatalog(testCatalog, LangPython, "", PythonFlavorPydantic, "")
+		// Check error
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"__init__.py", "public_user_profiles.py", "types.py"}, slices.Collect(maps.Keys(files)))
+		assert.Equal(t, `from .types import PublicStatus
+from .public_user_profiles import PublicUserProfiles
+`, string(files["__init__.py"]))
+		assert.Contains(t, string(files["public_user_profiles.py"]), "\nfrom .types import PublicStatus\n")
+		assert.Contains(t, string(files["public_user_profiles.py"]), "status: Optional[PublicStatus] = None\n")
+		assert.NotContains(t, string(files["types.py"]), "class PublicUserProfiles")
+	})
+
+	t.Run("splits go structs by table", func(t *testing.T) {
+		files, err := splitCatalog(testCatalog, LangGo, GoNullablePointer, "", "")
+		// Check error
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"public_user_profiles.go", "types.go"}, slices.Collect(maps.Keys(files)))
+		assert.Contains(t, string(files["public_user_profiles.go"]), "*PublicStatus")
+		assert.NotContains(t, string(files["public_user_profiles.go"]), "type PublicStatus string")
+	})
+
+	t.Run("throws error on unsupported lang", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := RunSplit(context.Background(), pgconn.Config{}, LangTypescript, nil, nil, nil, "", "", "", "types", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Splitting output by table is not supported for typescript types.")
+	})
+}
+
+func TestRemoveGenerated(t *testing.T) {
+	t.Run("removes files listed in manifest", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "types/.generated", []byte("public_todos.go\ntypes.go\n../main.go\n"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "types/public_todos.go", []byte("package types"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "types/custom.go", []byte("package types"), 0644))
+		require.NoError(t, afero.WriteFile(fsys, "main.go", []byte("package main"), 0644))
+		// Run test
+		err := removeGenerated("types", fsys)
+		// Check error
+		assert.NoError(t, err)
+		exists, err := afero.Exists(fsys, "types/public_todos.go")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		exists, err = afero.Exists(fsys, "types/custom.go")
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		exists, err = afero.Exists(fsys, "main.go")
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("skips missing manifest", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := removeGenerated("types", fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,23 @@
+-- Lists columns of tables, views, and composite types, resolving array element types
+select
+  n.nspname as schema,
+  c.relname as table,
+  c.relkind in ('v', 'm') as is_view,
+  c.relkind = 'c' as is_composite,
+  a.attname as name,
+  coalesce(et.typname, t.typname) as type,
+  coalesce(etn.nspname, tn.nspname) as type_schema,
+  et.oid is not null as is_array,
+  not a.attnotnull as is_nullable
+from pg_attribute a
+join pg_class c on c.oid = a.attrelid
+join pg_namespace n on n.oid = c.relnamespace
+join pg_type t on t.oid = a.atttypid
+join pg_namespace tn on tn.oid = t.typnamespace
+left join pg_type et on et.oid = t.typelem and t.typcategory = 'A'
+left join pg_namespace etn on etn.oid = et.typnamespace
+where n.nspname = any($1)
+  and c.relkind in ('r', 'p', 'v', 'm', 'f', 'c')
+  and a.attnum > 0
+  and not a.attisdropped
+order by n.nspname, c.relname, a.attnum
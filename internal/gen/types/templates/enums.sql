@@ -0,0 +1,11 @@
+-- Lists enum types with their labels in sort order
+select
+  n.nspname as schema,
+  t.typname as name,
+  array_agg(e.enumlabel order by e.enumsortorder) as labels
+from pg_type t
+join pg_enum e on e.enumtypid = t.oid
+join pg_namespace n on n.oid = t.typnamespace
+where n.nspname = any($1)
+group by n.nspname, t.typname
+order by n.nspname, t.typname
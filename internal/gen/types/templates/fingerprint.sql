@@ -0,0 +1,45 @@
+-- Hashes the catalog objects that generated types depend on
+select md5(concat_ws(
+  '|',
+  (
+    select string_agg(
+      format('%s.%s.%s:%s:%s:%s', n.nspname, c.relname, a.attname, format_type(a.atttypid, a.atttypmod), a.attnotnull, a.atthasdef),
+      ',' order by n.nspname, c.relname, a.attnum
+    )
+    from pg_attribute a
+    join pg_class c on c.oid = a.attrelid
+    join pg_namespace n on n.oid = c.relnamespace
+    where n.nspname = any($1)
+      and c.relkind in ('r', 'v', 'm', 'f', 'p')
+      and a.attnum > 0
+      and not a.attisdropped
+  ),
+  (
+    select string_agg(
+      format('%s.%s:%s', n.nspname, c.conname, pg_get_constraintdef(c.oid)),
+      ',' order by n.nspname, c.conname
+    )
+    from pg_constraint c
+    join pg_namespace n on n.oid = c.connamespace
+    where n.nspname = any($1)
+  ),
+  (
+    select string_agg(
+      format('%s.%s(%s):%s', n.nspname, p.proname, pg_get_function_arguments(p.oid), pg_get_function_result(p.oid)),
+      ',' order by n.nspname, p.proname, p.oid
+    )
+    from pg_proc p
+    join pg_namespace n on n.oid = p.pronamespace
+    where n.nspname = any($1)
+  ),
+  (
+    select string_agg(
+      format('%s.%s:%s', n.nspname, t.typname, e.enumlabel),
+      ',' order by n.nspname, t.typname, e.enumsortorder
+    )
+    from pg_enum e
+    join pg_type t on t.oid = e.enumtypid
+    join pg_namespace n on n.oid = t.typnamespace
+    where n.nspname = any($1)
+  )
+))
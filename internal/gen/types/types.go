@@ -3,6 +3,7 @@ package types
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -20,6 +21,14 @@ const (
 	LangTypescript = "typescript"
 	LangGo         = "go"
 	LangSwift      = "swift"
+	LangKotlin     = "kotlin"
+	LangPython     = "python"
+)
+
+const (
+	EnumStyleUnion       = "union"
+	EnumStyleEnum        = "enum"
+	EnumStyleConstObject = "const-object"
 )
 
 const (
@@ -27,15 +36,14 @@ const (
 	SwiftInternalAccessControl = "internal"
 )
 
-func Run(ctx context.Context, projectId string, dbConfig pgconn.Config, lang string, schemas []string, postgrestV9Compat bool, swiftAccessControl string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
-	originalURL := utils.ToPostgresURL(dbConfig)
-	// Add default schemas if --schema flag is not specified
-	if len(schemas) == 0 {
-		schemas = utils.RemoveDuplicates(append([]string{"public"}, utils.Config.Api.Schemas...))
-	}
+func Run(ctx context.Context, projectId string, dbConfig pgconn.Config, lang string, schemas, include, exclude []string, postgrestV9Compat bool, swiftAccessControl, goNullable, pythonFlavor, enumStyle string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	schemas = defaultSchemas(schemas)
 	included := strings.Join(schemas, ",")
 
 	if projectId != "" {
+		if len(enumStyle) > 0 && enumStyle != EnumStyleUnion {
+			return errors.Errorf("Unable to generate %s enums for selected project. Try using --db-url flag instead.", enumStyle)
+		}
 		if lang != LangTypescript {
 			return errors.Errorf("Unable to generate %s types for selected project. Try using --db-url flag instead.", lang)
 		}
@@ -50,10 +58,106 @@ func Run(ctx context.Context, projectId string, dbConfig pgconn.Config, lang str
 			return errors.New("failed to retrieve generated types: " + string(resp.Body))
 		}
 
-		fmt.Print(resp.JSON200.Types)
+		output := resp.JSON200.Types
+		if len(include) > 0 || len(exclude) > 0 {
+			keep, err := newTableFilter(include, exclude)
+			if err != nil {
+				return err
+			}
+			output = filterTypescriptTables(output, keep)
+		}
+		fmt.Print(output)
 		return nil
 	}
+	return generate(ctx, dbConfig, lang, schemas, include, exclude, postgrestV9Compat, swiftAccessControl, goNullable, pythonFlavor, enumStyle, os.Stdout, options...)
+}
+
+func generate(ctx context.Context, dbConfig pgconn.Config, lang string, schemas, include, exclude []string, postgrestV9Compat bool, swiftAccessControl, goNullable, pythonFlavor, enumStyle string, stdout io.Writer, options ...func(*pgx.ConnConfig)) error {
+	// Go types are generated by pg-meta unless the native generator picks a nullable representation
+	if lang == LangGo && len(goNullable) == 0 {
+		if len(include) > 0 || len(exclude) > 0 {
+			return errors.Errorf("--include and --exclude are not supported for %s types without --go-native", lang)
+		}
+		return generatePgMeta(ctx, dbConfig, lang, strings.Join(schemas, ","), postgrestV9Compat, swiftAccessControl, stdout, options...)
+	}
+	if lang != LangGo && lang != LangKotlin && lang != LangPython {
+		if lang == LangSwift && len(enumStyle) > 0 && enumStyle != EnumStyleEnum {
+			return errors.Errorf("%s enum style is not supported for %s types", enumStyle, lang)
+		}
+		if lang == LangTypescript {
+			return generateTypescript(ctx, dbConfig, schemas, include, exclude, postgrestV9Compat, enumStyle, stdout, options...)
+		}
+		return generatePgMeta(ctx, dbConfig, lang, strings.Join(schemas, ","), postgrestV9Compat, swiftAccessControl, stdout, options...)
+	}
+	result, err := loadCatalog(ctx, dbConfig, schemas, include, exclude, options...)
+	if err != nil {
+		return err
+	}
+	if result, err = applyEnumStyle(result, lang, enumStyle); err != nil {
+		return err
+	}
+	return render(result, lang, goNullable, pythonFlavor, enumStyle, stdout)
+}
+
+func loadCatalog(ctx context.Context, dbConfig pgconn.Config, schemas, include, exclude []string, options ...func(*pgx.ConnConfig)) (catalog, error) {
+	if utils.IsLocalDatabase(dbConfig) {
+		if err := utils.AssertSupabaseDbIsRunning(); err != nil {
+			return catalog{}, err
+		}
+	}
+	conn, err := utils.ConnectByConfig(ctx, dbConfig, options...)
+	if err != nil {
+		return catalog{}, err
+	}
+	defer conn.Close(context.Background())
+	result, err := introspect(ctx, conn, schemas)
+	if err != nil {
+		return result, err
+	}
+	result.Tables, err = filterTables(result.Tables, include, exclude)
+	return result, err
+}
+
+func render(c catalog, lang, goNullable, pythonFlavor, enumStyle string, w io.Writer) error {
+	switch lang {
+	case LangKotlin:
+		return renderKotlin(c, enumStyle, w)
+	case LangPython:
+		return renderPython(c, pythonFlavor, enumStyle, w)
+	}
+	return renderGo(c, goNullable, w)
+}
 
+// Go and kotlin have no union types, so enum columns are typed as plain strings instead.
+func applyEnumStyle(c catalog, lang, enumStyle string) (catalog, error) {
+	if lang == LangPython && enumStyle == EnumStyleConstObject {
+		return c, errors.Errorf("%s enum style is not supported for %s types", enumStyle, lang)
+	}
+	inline := (enumStyle == EnumStyleUnion && lang != LangPython) || (enumStyle == EnumStyleConstObject && lang == LangKotlin)
+	if !inline {
+		return c, nil
+	}
+	result := catalog{Enums: c.Enums}
+	for _, t := range c.Tables {
+		columns := make([]column, len(t.Columns))
+		for i, col := range t.Columns {
+			if c.isEnum(col.TypeSchema, col.Type) {
+				col.Type, col.TypeSchema = "text", "pg_catalog"
+			}
+			columns[i] = col
+		}
+		t.Columns = columns
+		result.Tables = append(result.Tables, t)
+	}
+	// Constants are still declared for kotlin const objects
+	if enumStyle == EnumStyleUnion {
+		result.Enums = nil
+	}
+	return result, nil
+}
+
+func generatePgMeta(ctx context.Context, dbConfig pgconn.Config, lang, included string, postgrestV9Compat bool, swiftAccessControl string, stdout io.Writer, options ...func(*pgx.ConnConfig)) error {
+	originalURL := utils.ToPostgresURL(dbConfig)
 	hostConfig := container.HostConfig{}
 	if utils.IsLocalDatabase(dbConfig) {
 		if err := utils.AssertSupabaseDbIsRunning(); err != nil {
@@ -100,11 +204,19 @@ func Run(ctx context.Context, projectId string, dbConfig pgconn.Config, lang str
 		hostConfig,
 		network.NetworkingConfig{},
 		"",
-		os.Stdout,
+		stdout,
 		os.Stderr,
 	)
 }
 
+// Adds default schemas if --schema flag is not specified
+func defaultSchemas(schemas []string) []string {
+	if len(schemas) == 0 {
+		return utils.RemoveDuplicates(append([]string{"public"}, utils.Config.Api.Schemas...))
+	}
+	return schemas
+}
+
 func isRequireSSL(ctx context.Context, dbUrl string, options ...func(*pgx.ConnConfig)) (bool, error) {
 	conn, err := utils.ConnectByUrl(ctx, dbUrl+"&sslmode=require", options...)
 	if err != nil {
@@ -0,0 +1,179 @@
+package types
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// pg-meta declares enums as unions, so other styles are declared after its output and
+// referenced from the Database type instead. Tables and views are filtered from its output.
+func generateTypescript(ctx context.Context, dbConfig pgconn.Config, schemas, include, exclude []string, postgrestV9Compat bool, enumStyle string, stdout io.Writer, options ...func(*pgx.ConnConfig)) error {
+	keep, err := newTableFilter(include, exclude)
+	if err != nil {
+		return err
+	}
+	var out bytes.Buffer
+	if err := generatePgMeta(ctx, dbConfig, LangTypescript, strings.Join(schemas, ","), postgrestV9Compat, "", &out, options...); err != nil {
+		return err
+	}
+	output := out.String()
+	if len(include) > 0 || len(exclude) > 0 {
+		output = filterTypescriptTables(output, keep)
+	}
+	var enums []enum
+	if len(enumStyle) > 0 && enumStyle != EnumStyleUnion {
+		conn, err := utils.ConnectByConfig(ctx, dbConfig, options...)
+		if err != nil {
+			return err
+		}
+		defer conn.Close(context.Background())
+		if enums, err = listEnums(ctx, conn, schemas); err != nil {
+			return err
+		}
+		output = referenceEnums(output, enums)
+	}
+	if _, err := io.WriteString(stdout, output); err != nil {
+		return errors.Errorf("failed to write typescript types: %w", err)
+	}
+	return renderTypescriptEnums(enums, enumStyle, stdout)
+}
+
+// Replaces the literal unions under Database[schema]["Enums"] with the declared enum types.
+// Columns and function arguments reference these entries, so they pick up the same style.
+func referenceEnums(output string, enums []enum) string {
+	declared := map[string]string{}
+	for _, e := range enums {
+		declared[e.Schema+"."+e.Name] = toPascalCase(e.Schema) + toPascalCase(e.Name)
+	}
+	return editDatabaseType(output, func(schema, section, name string, entry []string) []string {
+		typeName, ok := declared[schema+"."+name]
+		if section != "Enums" || !ok {
+			return entry
+		}
+		key, _, _ := cutKey(strings.TrimSpace(entry[0]))
+		return []string{entryIndent + key + ": " + typeName}
+	})
+}
+
+// Removes tables and views from the Database type. Relationships of the remaining tables may
+// still name removed tables, but only as string literals.
+func filterTypescriptTables(output string, keep func(schema, name string) bool) string {
+	return editDatabaseType(output, func(schema, section, name string, entry []string) []string {
+		if (section == "Tables" || section == "Views") && !keep(schema, name) {
+			return nil
+		}
+		return entry
+	})
+}
+
+const entryIndent = "      "
+
+// Rewrites the entries of each schema section in the Database type of pg-meta output, ie.
+// Database["public"]["Tables"]["todos"]. Returning an empty slice from edit removes the entry.
+func editDatabaseType(output string, edit func(schema, section, name string, entry []string) []string) string {
+	lines := strings.Split(output, "\n")
+	result := make([]string, 0, len(lines))
+	var inDatabase bool
+	var schema, section string
+	var kept, removed int
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if !inDatabase {
+			inDatabase = strings.HasPrefix(line, "export type Database = {")
+			result = append(result, line)
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		switch indent := len(line) - len(strings.TrimLeft(line, " ")); {
+		case line == "}":
+			inDatabase = false
+		case indent == 2 && strings.HasSuffix(trimmed, ": {"):
+			schema, _, _ = cutKey(trimmed)
+			schema = unquoteKey(schema)
+		case indent == 4 && strings.HasSuffix(trimmed, ": {"):
+			section, _, _ = cutKey(trimmed)
+			kept, removed = 0, 0
+		case indent == 4 && trimmed == "}":
+			// Sections with no entries are typed as empty, like pg-meta does
+			if removed > 0 && kept == 0 {
+				result = append(result, entryIndent+"[_ in never]: never")
+			}
+			section = ""
+		case indent == 6 && len(section) > 0 && !strings.HasPrefix(trimmed, "["):
+			// Continuation lines are indented further, except for closing brackets
+			j := i + 1
+			for ; j < len(lines); j++ {
+				next := strings.TrimLeft(lines[j], " ")
+				nextIndent := len(lines[j]) - len(next)
+				if nextIndent < 6 || (nextIndent == 6 && !strings.HasPrefix(next, "}") && !strings.HasPrefix(next, "]")) {
+					break
+				}
+			}
+			key, _, _ := cutKey(trimmed)
+			entry := edit(schema, section, unquoteKey(key), lines[i:j])
+			if len(entry) > 0 {
+				kept++
+			} else {
+				removed++
+			}
+			result = append(result, entry...)
+			i = j - 1
+			continue
+		}
+		result = append(result, line)
+	}
+	return strings.Join(result, "\n")
+}
+
+// Splits an object property into its key and value, allowing for quoted keys.
+func cutKey(line string) (string, string, bool) {
+	if strings.HasPrefix(line, `"`) {
+		if key, err := strconv.QuotedPrefix(line); err == nil {
+			value, found := strings.CutPrefix(line[len(key):], ":")
+			return key, strings.TrimSpace(value), found
+		}
+	}
+	key, value, found := strings.Cut(line, ":")
+	return key, strings.TrimSpace(value), found
+}
+
+func unquoteKey(key string) string {
+	if unquoted, err := strconv.Unquote(key); err == nil {
+		return unquoted
+	}
+	return key
+}
+
+// Renders enums as TypeScript enums or const objects, to be appended to the pg-meta output.
+func renderTypescriptEnums(enums []enum, enumStyle string, w io.Writer) error {
+	var body strings.Builder
+	for _, e := range enums {
+		name := toPascalCase(e.Schema) + toPascalCase(e.Name)
+		if enumStyle == EnumStyleConstObject {
+			fmt.Fprintf(&body, "\nexport const %s = {\n", name)
+			for _, label := range e.Labels {
+				fmt.Fprintf(&body, "  %s: %s,\n", toPascalCase(label), strconv.Quote(label))
+			}
+			fmt.Fprintf(&body, "} as const\n\nexport type %s = (typeof %s)[keyof typeof %s]\n", name, name, name)
+			continue
+		}
+		fmt.Fprintf(&body, "\nexport enum %s {\n", name)
+		for _, label := range e.Labels {
+			fmt.Fprintf(&body, "  %s = %s,\n", toPascalCase(label), strconv.Quote(label))
+		}
+		fmt.Fprint(&body, "}\n")
+	}
+	if _, err := io.WriteString(w, body.String()); err != nil {
+		return errors.Errorf("failed to write typescript enums: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pgMetaTypes = `export type Json = string | number | boolean | null
+
+export type Database = {
+  public: {
+    Tables: {
+      user_profiles: {
+        Row: {
+          id: number
+          status: Database["public"]["Enums"]["status"] | null
+        }
+        Relationships: [
+          {
+            foreignKeyName: "user_profiles_id_fkey"
+            referencedRelation: "users"
+          },
+        ]
+      }
+      "audit-log": {
+        Row: {
+          id: number
+        }
+        Relationships: []
+      }
+    }
+    Views: {
+      [_ in never]: never
+    }
+    Enums: {
+      status:
+        | "active"
+        | "on hold"
+    }
+  }
+}
+
+export const Constants = {
+  public: {
+    Enums: {
+      status: ["active", "on hold"],
+    },
+  },
+} as const
+`
+
+func TestReferenceEnums(t *testing.T) {
+	t.Run("references declared enums from database type", func(t *testing.T) {
+		// Run test
+		output := referenceEnums(pgMetaTypes, testCatalog.Enums)
+		// Check output
+		assert.Contains(t, output, `
+    Enums: {
+      status: PublicStatus
+    }
+`)
+		assert.Contains(t, output, `status: Database["public"]["Enums"]["status"] | null`)
+		assert.Contains(t, output, `status: ["active", "on hold"],`)
+	})
+
+	t.Run("keeps output without database type", func(t *testing.T) {
+		// Run test
+		output := referenceEnums("hello world\n", testCatalog.Enums)
+		// Check output
+		assert.Equal(t, "hello world\n", output)
+	})
+}
+
+func TestFilterTypescriptTables(t *testing.T) {
+	t.Run("removes excluded tables", func(t *testing.T) {
+		keep, err := newTableFilter(nil, []string{"audit-log"})
+		require.NoError(t, err)
+		// Run test
+		output := filterTypescriptTables(pgMetaTypes, keep)
+		// Check output
+		assert.NotContains(t, output, `"audit-log"`)
+		assert.Contains(t, output, `
+        Relationships: [
+          {
+            foreignKeyName: "user_profiles_id_fkey"
+            referencedRelation: "users"
+          },
+        ]
+      }
+    }
+    Views: {`)
+	})
+
+	t.Run("types empty sections as never", func(t *testing.T) {
+		keep, err := newTableFilter([]string{"private.*"}, nil)
+		require.NoError(t, err)
+		// Run test
+		output := filterTypescriptTables(pgMetaTypes, keep)
+		// Check output
+		assert.Contains(t, output, `
+    Tables: {
+      [_ in never]: never
+    }
+    Views: {
+      [_ in never]: never
+    }
+`)
+	})
+}
+
+func TestRenderTypescriptEnums(t *testing.T) {
+	t.Run("renders typescript enums", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := renderTypescriptEnums(testCatalog.Enums, EnumStyleEnum, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, `
+export enum PublicStatus {
+  Active = "active",
+  OnHold = "on hold",
+}
+`, out.String())
+	})
+
+	t.Run("renders const objects", func(t *testing.T) {
+		var out bytes.Buffer
+		// Run test
+		err := renderTypescriptEnums(testCatalog.Enums, EnumStyleConstObject, &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, `
+export const PublicStatus = {
+  Active: "active",
+  OnHold: "on hold",
+} as const
+
+export type PublicStatus = (typeof PublicStatus)[keyof typeof PublicStatus]
+`, out.String())
+	})
+}
+
+func TestApplyEnumStyle(t *testing.T) {
+	t.Run("types go enums as strings", func(t *testing.T) {
+		result, err := applyEnumStyle(testCatalog, LangGo, EnumStyleUnion)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, result.Enums)
+		assert.Equal(t, column{Name: "status", Type: "text", TypeSchema: "pg_catalog", IsNullable: true}, result.Tables[0].Columns[2])
+		assert.Equal(t, "public", testCatalog.Tables[0].Columns[2].TypeSchema)
+	})
+
+	t.Run("keeps kotlin constants", func(t *testing.T) {
+		result, err := applyEnumStyle(testCatalog, LangKotlin, EnumStyleConstObject)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, testCatalog.Enums, result.Enums)
+		assert.Equal(t, "text", result.Tables[0].Columns[2].Type)
+	})
+
+	t.Run("throws error on unsupported style", func(t *testing.T) {
+		_, err := applyEnumStyle(testCatalog, LangPython, EnumStyleConstObject)
+		// Check error
+		assert.ErrorContains(t, err, "const-object enum style is not supported for python types")
+	})
+}
@@ -0,0 +1,93 @@
+package types
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/utils"
+)
+
+var (
+	//go:embed templates/fingerprint.sql
+	fingerprintQuery string
+	watchInterval    = 2 * time.Second
+)
+
+// Regenerates types to the output path whenever the database schema changes, until interrupted.
+func Watch(ctx context.Context, dbConfig pgconn.Config, lang string, schemas, include, exclude []string, postgrestV9Compat bool, swiftAccessControl, goNullable, pythonFlavor, enumStyle, path string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	schemas = defaultSchemas(schemas)
+	var conn *pgx.Conn
+	defer func() {
+		if conn != nil {
+			conn.Close(context.Background())
+		}
+	}()
+	var last string
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for {
+		// Reconnects after the database restarts, ie. on db reset
+		if conn == nil {
+			var err error
+			if conn, err = utils.ConnectByConfigStream(ctx, dbConfig, io.Discard, options...); err != nil {
+				fmt.Fprintln(os.Stderr, "Failed to connect to database:", err)
+			}
+		}
+		if conn != nil {
+			if hash, err := fingerprint(ctx, conn, schemas); err != nil {
+				fmt.Fprintln(os.Stderr, "Failed to inspect schema:", err)
+				conn.Close(context.Background())
+				conn = nil
+			} else if hash != last {
+				if err := writeTypes(ctx, dbConfig, lang, schemas, include, exclude, postgrestV9Compat, swiftAccessControl, goNullable, pythonFlavor, enumStyle, path, fsys, options...); err != nil {
+					fmt.Fprintln(os.Stderr, "Failed to generate types:", err)
+				} else {
+					last = hash
+					fmt.Fprintln(os.Stderr, "Types written to "+utils.Bold(path)+". Watching for schema changes...")
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func fingerprint(ctx context.Context, conn *pgx.Conn, schemas []string) (string, error) {
+	var hash string
+	if err := conn.QueryRow(ctx, fingerprintQuery, schemas).Scan(&hash); err != nil {
+		return "", errors.Errorf("failed to fingerprint schema: %w", err)
+	}
+	return hash, nil
+}
+
+// Writes to a temporary file first so that readers never see partially generated types.
+func writeTypes(ctx context.Context, dbConfig pgconn.Config, lang string, schemas, include, exclude []string, postgrestV9Compat bool, swiftAccessControl, goNullable, pythonFlavor, enumStyle, path string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	var out bytes.Buffer
+	if err := generate(ctx, dbConfig, lang, schemas, include, exclude, postgrestV9Compat, swiftAccessControl, goNullable, pythonFlavor, enumStyle, &out, options...); err != nil {
+		return err
+	}
+	if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(path)); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := afero.WriteFile(fsys, tmp, out.Bytes(), 0644); err != nil {
+		return errors.Errorf("failed to write types: %w", err)
+	}
+	if err := fsys.Rename(tmp, path); err != nil {
+		return errors.Errorf("failed to rename types: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package types
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/h2non/gock"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+func TestWriteTypes(t *testing.T) {
+	utils.DbId = "test-db"
+	utils.Config.Hostname = "localhost"
+	utils.Config.Db.Port = 5432
+
+	dbConfig := pgconn.Config{
+		Host:     utils.Config.Hostname,
+		Port:     utils.Config.Db.Port,
+		User:     "admin",
+		Password: "password",
+	}
+
+	t.Run("replaces output file", func(t *testing.T) {
+		const containerId = "test-pgmeta"
+		imageUrl := utils.GetRegistryImageUrl(utils.Config.Studio.PgmetaImage)
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "types/database.ts", []byte("stale"), 0644))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.DbId).
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{})
+		apitest.MockDockerStart(utils.Docker, imageUrl, containerId)
+		require.NoError(t, apitest.MockDockerLogs(utils.Docker, containerId, "export type Json = string\n"))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		// Run test
+		err := writeTypes(context.Background(), dbConfig, LangTypescript, []string{"public"}, nil, nil, true, "", "", "", "", "types/database.ts", fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		contents, err := afero.ReadFile(fsys, "types/database.ts")
+		assert.NoError(t, err)
+		assert.Equal(t, "export type Json = string\n", string(contents))
+		exists, err := afero.Exists(fsys, "types/database.ts.tmp")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("keeps output file on failure", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, "types/database.ts", []byte("stale"), 0644))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/" + utils.DbId).
+			Reply(http.StatusServiceUnavailable)
+		// Run test
+		err := writeTypes(context.Background(), dbConfig, LangTypescript, []string{"public"}, nil, nil, true, "", "", "", "", "types/database.ts", fsys)
+		// Check error
+		assert.Error(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+		contents, err := afero.ReadFile(fsys, "types/database.ts")
+		assert.NoError(t, err)
+		assert.Equal(t, "stale", string(contents))
+	})
+}
+
+func TestFingerprint(t *testing.T) {
+	t.Run("hashes schema catalog", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(fingerprintQuery, []string{"public"}).
+			Reply("SELECT 1", []interface{}{"d41d8cd98f00b204e9800998ecf8427e"})
+		// Run test
+		hash, err := fingerprint(context.Background(), conn.MockClient(t), []string{"public"})
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "d41d8cd98f00b204e9800998ecf8427e", hash)
+	})
+
+	t.Run("throws error on query failure", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(fingerprintQuery, []string{"public"}).
+			ReplyError(pgerrcode.InsufficientPrivilege, "permission denied for table pg_attribute")
+		// Run test
+		_, err := fingerprint(context.Background(), conn.MockClient(t), []string{"public"})
+		// Check error
+		assert.ErrorContains(t, err, "permission denied for table pg_attribute")
+	})
+}
+
+func TestWatchTypes(t *testing.T) {
+	t.Run("stops on cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Run test
+		err := Watch(ctx, pgconn.Config{}, LangTypescript, nil, nil, nil, false, "", "", "", "", "database.ts", fsys)
+		// Check error
+		assert.NoError(t, err)
+		exists, err := afero.Exists(fsys, "database.ts")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
@@ -10,7 +10,7 @@ import (
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/db/reset"
-	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/inspect/output"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/pgxv5"
 )
@@ -19,11 +19,11 @@ import (
 var BloatQuery string
 
 type Result struct {
-	Type        string
-	Schemaname  string
-	Object_name string
-	Bloat       string
-	Waste       string
+	Type        string `json:"type" toml:"type" yaml:"type"`
+	Schemaname  string `json:"schemaname" toml:"schemaname" yaml:"schemaname"`
+	Object_name string `json:"object_name" toml:"object_name" yaml:"object_name"`
+	Bloat       string `json:"bloat" toml:"bloat" yaml:"bloat"`
+	Waste       string `json:"waste" toml:"waste" yaml:"waste"`
 }
 
 func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
@@ -40,10 +40,11 @@ func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...fu
 	if err != nil {
 		return err
 	}
-
-	table := "|Type|Schema name|Object name|Bloat|Waste\n|-|-|-|-|-|\n"
-	for _, r := range result {
-		table += fmt.Sprintf("|`%s`|`%s`|`%s`|`%s`|`%s`|\n", r.Type, r.Schemaname, r.Object_name, r.Bloat, r.Waste)
-	}
-	return list.RenderTable(table)
+	return output.RenderRows(result, func() string {
+		table := "|Type|Schema name|Object name|Bloat|Waste\n|-|-|-|-|-|\n"
+		for _, r := range result {
+			table += fmt.Sprintf("|`%s`|`%s`|`%s`|`%s`|`%s`|\n", r.Type, r.Schemaname, r.Object_name, r.Bloat, r.Waste)
+		}
+		return table
+	})
 }
@@ -10,7 +10,7 @@ import (
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/afero"
-	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/inspect/output"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/pgxv5"
 )
@@ -19,12 +19,12 @@ import (
 var BlockingQuery string
 
 type Result struct {
-	Blocked_pid        int
-	Blocking_statement string
-	Blocking_duration  string
-	Blocking_pid       int
-	Blocked_statement  string
-	Blocked_duration   string
+	Blocked_pid        int    `json:"blocked_pid" toml:"blocked_pid" yaml:"blocked_pid"`
+	Blocking_statement string `json:"blocking_statement" toml:"blocking_statement" yaml:"blocking_statement"`
+	Blocking_duration  string `json:"blocking_duration" toml:"blocking_duration" yaml:"blocking_duration"`
+	Blocking_pid       int    `json:"blocking_pid" toml:"blocking_pid" yaml:"blocking_pid"`
+	Blocked_statement  string `json:"blocked_statement" toml:"blocked_statement" yaml:"blocked_statement"`
+	Blocked_duration   string `json:"blocked_duration" toml:"blocked_duration" yaml:"blocked_duration"`
 }
 
 func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
@@ -42,19 +42,20 @@ func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...fu
 	if err != nil {
 		return err
 	}
+	return output.RenderRows(result, func() string {
+		table := "|blocked pid|blocking statement|blocking duration|blocking pid|blocked statement|blocked duration|\n|-|-|-|-|-|-|\n"
+		for _, r := range result {
+			// remove whitespace from query
+			re := regexp.MustCompile(`\s+|\r+|\n+|\t+|\v`)
+			blocking_statement := re.ReplaceAllString(r.Blocking_statement, " ")
+			blocked_statement := re.ReplaceAllString(r.Blocked_statement, " ")
 
-	table := "|blocked pid|blocking statement|blocking duration|blocking pid|blocked statement|blocked duration|\n|-|-|-|-|-|-|\n"
-	for _, r := range result {
-		// remove whitespace from query
-		re := regexp.MustCompile(`\s+|\r+|\n+|\t+|\v`)
-		blocking_statement := re.ReplaceAllString(r.Blocking_statement, " ")
-		blocked_statement := re.ReplaceAllString(r.Blocked_statement, " ")
-
-		// escape pipes in query
-		re = regexp.MustCompile(`\|`)
-		blocking_statement = re.ReplaceAllString(blocking_statement, `\|`)
-		blocked_statement = re.ReplaceAllString(blocked_statement, `\|`)
-		table += fmt.Sprintf("|`%d`|`%s`|`%s`|`%d`|%s|`%s`|\n", r.Blocked_pid, blocking_statement, r.Blocking_duration, r.Blocking_pid, blocked_statement, r.Blocked_duration)
-	}
-	return list.RenderTable(table)
+			// escape pipes in query
+			re = regexp.MustCompile(`\|`)
+			blocking_statement = re.ReplaceAllString(blocking_statement, `\|`)
+			blocked_statement = re.ReplaceAllString(blocked_statement, `\|`)
+			table += fmt.Sprintf("|`%d`|`%s`|`%s`|`%d`|%s|`%s`|\n", r.Blocked_pid, blocking_statement, r.Blocking_duration, r.Blocking_pid, blocked_statement, r.Blocked_duration)
+		}
+		return table
+	})
 }
@@ -9,7 +9,7 @@ import (
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/afero"
-	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/inspect/output"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/pgxv5"
 )
@@ -18,8 +18,8 @@ import (
 var CacheQuery string
 
 type Result struct {
-	Name  string
-	Ratio float64
+	Name  string  `json:"name" toml:"name" yaml:"name"`
+	Ratio float64 `json:"ratio" toml:"ratio" yaml:"ratio"`
 }
 
 func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
@@ -37,20 +37,22 @@ func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...fu
 	if err != nil {
 		return err
 	}
-	// TODO: implement a markdown table marshaller
-	table := "|Name|Ratio|OK?|Explanation|\n|-|-|-|-|\n"
-	for _, r := range result {
-		ok := "Yup!"
-		if r.Ratio < 0.94 {
-			ok = "Maybe not..."
+	return output.RenderRows(result, func() string {
+		// TODO: implement a markdown table marshaller
+		table := "|Name|Ratio|OK?|Explanation|\n|-|-|-|-|\n"
+		for _, r := range result {
+			ok := "Yup!"
+			if r.Ratio < 0.94 {
+				ok = "Maybe not..."
+			}
+			var explanation string
+			if r.Name == "index hit rate" {
+				explanation = "This is the ratio of index hits to index scans. If this ratio is low, it means that the database is not using indexes effectively. Check the `index-usage` command for more info."
+			} else if r.Name == "table hit rate" {
+				explanation = "This is the ratio of table hits to table scans. If this ratio is low, it means that your queries are not finding the data effectively. Check your query performance and it might be worth increasing your compute."
+			}
+			table += fmt.Sprintf("|`%s`|`%.6f`|`%s`|`%s`|\n", r.Name, r.Ratio, ok, explanation)
 		}
-		var explanation string
-		if r.Name == "index hit rate" {
-			explanation = "This is the ratio of index hits to index scans. If this ratio is low, it means that the database is not using indexes effectively. Check the `index-usage` command for more info."
-		} else if r.Name == "table hit rate" {
-			explanation = "This is the ratio of table hits to table scans. If this ratio is low, it means that your queries are not finding the data effectively. Check your query performance and it might be worth increasing your compute."
-		}
-		table += fmt.Sprintf("|`%s`|`%.6f`|`%s`|`%s`|\n", r.Name, r.Ratio, ok, explanation)
-	}
-	return list.RenderTable(table)
+		return table
+	})
 }
@@ -10,7 +10,7 @@ import (
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/db/reset"
-	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/inspect/output"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/pgxv5"
 )
@@ -19,9 +19,9 @@ import (
 var IndexUsageQuery string
 
 type Result struct {
-	Name                        string
-	Percent_of_times_index_used string
-	Rows_in_table               int64
+	Name                        string `json:"name" toml:"name" yaml:"name"`
+	Percent_of_times_index_used string `json:"percent_of_times_index_used" toml:"percent_of_times_index_used" yaml:"percent_of_times_index_used"`
+	Rows_in_table               int64  `json:"rows_in_table" toml:"rows_in_table" yaml:"rows_in_table"`
 }
 
 func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
@@ -38,10 +38,12 @@ func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...fu
 	if err != nil {
 		return err
 	}
-	// TODO: implement a markdown table marshaller
-	table := "|Table name|Percentage of times index used|Rows in table|\n|-|-|-|\n"
-	for _, r := range result {
-		table += fmt.Sprintf("|`%s`|`%s`|`%d`|\n", r.Name, r.Percent_of_times_index_used, r.Rows_in_table)
-	}
-	return list.RenderTable(table)
+	return output.RenderRows(result, func() string {
+		// TODO: implement a markdown table marshaller
+		table := "|Table name|Percentage of times index used|Rows in table|\n|-|-|-|\n"
+		for _, r := range result {
+			table += fmt.Sprintf("|`%s`|`%s`|`%d`|\n", r.Name, r.Percent_of_times_index_used, r.Rows_in_table)
+		}
+		return table
+	})
 }
@@ -10,7 +10,7 @@ import (
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/afero"
-	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/inspect/output"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/pgxv5"
 )
@@ -19,12 +19,12 @@ import (
 var LocksQuery string
 
 type Result struct {
-	Pid           int
-	Relname       string
-	Transactionid string
-	Granted       bool
-	Query         string
-	Age           string
+	Pid           int    `json:"pid" toml:"pid" yaml:"pid"`
+	Relname       string `json:"relname" toml:"relname" yaml:"relname"`
+	Transactionid string `json:"transactionid" toml:"transactionid" yaml:"transactionid"`
+	Granted       bool   `json:"granted" toml:"granted" yaml:"granted"`
+	Query         string `json:"query" toml:"query" yaml:"query"`
+	Age           string `json:"age" toml:"age" yaml:"age"`
 }
 
 func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
@@ -41,17 +41,18 @@ func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...fu
 	if err != nil {
 		return err
 	}
+	return output.RenderRows(result, func() string {
+		table := "|pid|relname|transaction id|granted|query|age|\n|-|-|-|-|-|-|\n"
+		for _, r := range result {
+			// remove whitespace from query
+			re := regexp.MustCompile(`\s+|\r+|\n+|\t+|\v`)
+			query := re.ReplaceAllString(r.Query, " ")
 
-	table := "|pid|relname|transaction id|granted|query|age|\n|-|-|-|-|-|-|\n"
-	for _, r := range result {
-		// remove whitespace from query
-		re := regexp.MustCompile(`\s+|\r+|\n+|\t+|\v`)
-		query := re.ReplaceAllString(r.Query, " ")
-
-		// escape pipes in query
-		re = regexp.MustCompile(`\|`)
-		query = re.ReplaceAllString(query, `\|`)
-		table += fmt.Sprintf("|`%d`|`%s`|`%s`|`%t`|%s|`%s`|\n", r.Pid, r.Relname, r.Transactionid, r.Granted, query, r.Age)
-	}
-	return list.RenderTable(table)
+			// escape pipes in query
+			re = regexp.MustCompile(`\|`)
+			query = re.ReplaceAllString(query, `\|`)
+			table += fmt.Sprintf("|`%d`|`%s`|`%s`|`%t`|%s|`%s`|\n", r.Pid, r.Relname, r.Transactionid, r.Granted, query, r.Age)
+		}
+		return table
+	})
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/jackc/pgconn"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/pgtest"
 )
 
@@ -39,4 +40,27 @@ func TestLocksCommand(t *testing.T) {
 		// Check error
 		assert.NoError(t, err)
 	})
+
+	t.Run("inspects locks as json", func(t *testing.T) {
+		utils.OutputFormat.Value = utils.OutputJson
+		t.Cleanup(func() { utils.OutputFormat.Value = utils.OutputPretty })
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(LocksQuery).
+			Reply("SELECT 1", Result{
+				Pid:           1,
+				Relname:       "rel",
+				Transactionid: "9301",
+				Granted:       true,
+				Query:         "select 1",
+				Age:           "300ms",
+			})
+		// Run test
+		err := Run(context.Background(), dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
 }
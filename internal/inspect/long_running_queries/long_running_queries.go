@@ -9,7 +9,7 @@ import (
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/afero"
-	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/inspect/output"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/pgxv5"
 )
@@ -18,9 +18,9 @@ import (
 var LongRunningQueriesQuery string
 
 type Result struct {
-	Pid      int
-	Duration string
-	Query    string
+	Pid      int    `json:"pid" toml:"pid" yaml:"pid"`
+	Duration string `json:"duration" toml:"duration" yaml:"duration"`
+	Query    string `json:"query" toml:"query" yaml:"query"`
 }
 
 func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
@@ -37,10 +37,11 @@ func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...fu
 	if err != nil {
 		return err
 	}
-
-	table := "|pid|Duration|Query|\n|-|-|-|\n"
-	for _, r := range result {
-		table += fmt.Sprintf("|`%d`|`%s`|`%s`|\n", r.Pid, r.Duration, r.Query)
-	}
-	return list.RenderTable(table)
+	return output.RenderRows(result, func() string {
+		table := "|pid|Duration|Query|\n|-|-|-|\n"
+		for _, r := range result {
+			table += fmt.Sprintf("|`%d`|`%s`|`%s`|\n", r.Pid, r.Duration, r.Query)
+		}
+		return table
+	})
 }
@@ -0,0 +1,23 @@
+package output
+
+import (
+	"os"
+
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/utils"
+)
+
+// Prints query results in the format selected by --output, rendering the markdown table for pretty output.
+func RenderRows[T any](rows []T, table func() string) error {
+	switch utils.OutputFormat.Value {
+	case utils.OutputPretty:
+		return list.RenderTable(table())
+	case utils.OutputToml:
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, struct {
+			Rows []T `toml:"rows"`
+		}{
+			Rows: rows,
+		})
+	}
+	return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, rows)
+}
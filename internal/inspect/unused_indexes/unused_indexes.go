@@ -10,7 +10,7 @@ import (
 	"github.com/jackc/pgx/v4"
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/db/reset"
-	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/inspect/output"
 	"github.com/supabase/cli/internal/utils"
 	"github.com/supabase/cli/pkg/pgxv5"
 )
@@ -19,10 +19,10 @@ import (
 var UnusedIndexesQuery string
 
 type Result struct {
-	Table       string
-	Index       string
-	Index_size  string
-	Index_scans int64
+	Table       string `json:"table" toml:"table" yaml:"table"`
+	Index       string `json:"index" toml:"index" yaml:"index"`
+	Index_size  string `json:"index_size" toml:"index_size" yaml:"index_size"`
+	Index_scans int64  `json:"index_scans" toml:"index_scans" yaml:"index_scans"`
 }
 
 func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
@@ -39,10 +39,11 @@ func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...fu
 	if err != nil {
 		return err
 	}
-
-	table := "|Table|Index|Index Size|Index Scans\n|-|-|-|-|\n"
-	for _, r := range result {
-		table += fmt.Sprintf("|`%s`|`%s`|`%s`|`%d`|\n", r.Table, r.Index, r.Index_size, r.Index_scans)
-	}
-	return list.RenderTable(table)
+	return output.RenderRows(result, func() string {
+		table := "|Table|Index|Index Size|Index Scans\n|-|-|-|-|\n"
+		for _, r := range result {
+			table += fmt.Sprintf("|`%s`|`%s`|`%s`|`%d`|\n", r.Table, r.Index, r.Index_size, r.Index_scans)
+		}
+		return table
+	})
 }
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net/http"
 	"os"
-	"strconv"
 	"sync"
 
 	"github.com/go-errors/errors"
@@ -213,15 +212,9 @@ func linkDatabase(ctx context.Context, config pgconn.Config, options ...func(*pg
 }
 
 func updatePostgresConfig(conn *pgx.Conn) {
-	serverVersion := conn.PgConn().ParameterStatus("server_version")
-	// Safe to assume that supported Postgres version is 10.0 <= n < 100.0
-	majorDigits := len(serverVersion)
-	if majorDigits > 2 {
-		majorDigits = 2
-	}
 	// Treat error as unchanged
-	if dbMajorVersion, err := strconv.ParseUint(serverVersion[:majorDigits], 10, 7); err == nil {
-		utils.Config.Db.MajorVersion = uint(dbMajorVersion)
+	if dbMajorVersion := utils.GetServerMajorVersion(conn); dbMajorVersion > 0 {
+		utils.Config.Db.MajorVersion = dbMajorVersion
 	}
 }
 
@@ -0,0 +1,47 @@
+package down
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/migration/repair"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+)
+
+func Run(ctx context.Context, count uint, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	if count == 0 {
+		return errors.New("Number of migrations to revert must be at least 1.")
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	remote, err := migration.ListRemoteMigrations(ctx, conn)
+	if err != nil {
+		return err
+	}
+	if len(remote) == 0 {
+		fmt.Fprintln(os.Stderr, "No migrations to revert.")
+		return nil
+	}
+	if int(count) > len(remote) {
+		return errors.Errorf("Cannot revert %d migrations: only %d have been applied.", count, len(remote))
+	}
+	// Reverts the latest migrations first
+	var reverted []string
+	for i := len(remote) - 1; i >= len(remote)-int(count); i-- {
+		path, err := repair.GetMigrationFile(remote[i], fsys)
+		if err != nil {
+			return err
+		}
+		reverted = append(reverted, path)
+	}
+	return migration.RevertMigrations(ctx, reverted, conn, afero.NewIOFS(fsys))
+}
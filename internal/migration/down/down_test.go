@@ -0,0 +1,86 @@
+package down
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+var dbConfig = pgconn.Config{
+	Host:     "127.0.0.1",
+	Port:     5432,
+	User:     "admin",
+	Password: "password",
+	Database: "postgres",
+}
+
+func TestMigrationDown(t *testing.T) {
+	t.Run("reverts latest migrations in reverse order", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		files := map[string]string{
+			"0_init.sql":         "create table a()",
+			"1_todos.sql":        "create table todos()",
+			"1_todos_down.sql":   "drop table todos",
+			"2_profile.sql":      "create table profile()",
+			"2_profile_down.sql": "drop table profile",
+		}
+		for name, sql := range files {
+			path := filepath.Join(utils.MigrationsDir, name)
+			require.NoError(t, afero.WriteFile(fsys, path, []byte(sql), 0644))
+		}
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.LIST_MIGRATION_VERSION).
+			Reply("SELECT 3", []interface{}{"0"}, []interface{}{"1"}, []interface{}{"2"}).
+			Query("drop table profile").
+			Reply("DROP TABLE").
+			Query(migration.DELETE_MIGRATION_VERSION, "{2}").
+			Reply("DELETE 1").
+			Query("drop table todos").
+			Reply("DROP TABLE").
+			Query(migration.DELETE_MIGRATION_VERSION, "{1}").
+			Reply("DELETE 1")
+		// Run test
+		err := Run(context.Background(), 2, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on missing down migration", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_init.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("create table a()"), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.LIST_MIGRATION_VERSION).
+			Reply("SELECT 1", []interface{}{"0"})
+		// Run test
+		err := Run(context.Background(), 1, dbConfig, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "Missing down migration for 0_init.sql")
+	})
+
+	t.Run("throws error on count exceeding history", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.LIST_MIGRATION_VERSION).
+			Reply("SELECT 1", []interface{}{"0"})
+		// Run test
+		err := Run(context.Background(), 2, dbConfig, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "Cannot revert 2 migrations: only 1 have been applied.")
+	})
+}
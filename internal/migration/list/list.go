@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/glamour"
 	"github.com/go-errors/errors"
@@ -16,7 +19,7 @@ import (
 )
 
 func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
-	remoteVersions, err := loadRemoteVersions(ctx, config, options...)
+	remoteVersions, modified, err := loadRemoteVersions(ctx, config, fsys, options...)
 	if err != nil {
 		return err
 	}
@@ -24,22 +27,75 @@ func Run(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...fu
 	if err != nil {
 		return err
 	}
-	table := makeTable(remoteVersions, localVersions)
-	return RenderTable(table)
+	statuses := mergeVersions(remoteVersions, localVersions)
+	if markModified(statuses, modified) {
+		fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), migration.ErrModifiedLocal.Error())
+		fmt.Fprintln(os.Stderr, "Run "+utils.Aqua("supabase migration repair --recalculate")+" if the edits are intended.")
+	}
+	switch utils.OutputFormat.Value {
+	case utils.OutputPretty:
+		return RenderTable(makeTable(statuses))
+	case utils.OutputToml:
+		return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, struct {
+			Migrations []MigrationStatus `toml:"migrations"`
+		}{
+			Migrations: statuses,
+		})
+	}
+	return utils.EncodeOutput(utils.OutputFormat.Value, os.Stdout, statuses)
 }
 
-func loadRemoteVersions(ctx context.Context, config pgconn.Config, options ...func(*pgx.ConnConfig)) ([]string, error) {
+// Loads remote versions and the paths of applied migrations that were modified locally.
+func loadRemoteVersions(ctx context.Context, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) ([]string, []string, error) {
 	conn, err := utils.ConnectByConfig(ctx, config, options...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer conn.Close(context.Background())
-	return migration.ListRemoteMigrations(ctx, conn)
+	versions, err := migration.ListRemoteMigrations(ctx, conn)
+	if err != nil {
+		return nil, nil, err
+	}
+	localMigrations, err := migration.ListLocalMigrations(utils.MigrationsDir, afero.NewIOFS(fsys))
+	if err != nil {
+		return nil, nil, err
+	}
+	// Modified migrations are only a hard failure when pushing
+	modified, err := migration.FindModifiedMigrations(ctx, localMigrations, conn, afero.NewIOFS(fsys))
+	if err != nil && !errors.Is(err, migration.ErrModifiedLocal) {
+		return nil, nil, err
+	}
+	return versions, modified, nil
+}
+
+type MigrationStatus struct {
+	Version  string `json:"version" toml:"version" yaml:"version"`
+	Local    bool   `json:"local" toml:"local" yaml:"local"`
+	Remote   bool   `json:"remote" toml:"remote" yaml:"remote"`
+	Time     string `json:"time" toml:"time" yaml:"time"`
+	Modified bool   `json:"modified,omitempty" toml:"modified,omitempty" yaml:"modified,omitempty"`
+}
+
+// Marks statuses of the given migration paths as modified, returning true if any was found.
+func markModified(statuses []MigrationStatus, paths []string) bool {
+	versions := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		version, _, _ := strings.Cut(filepath.Base(p), "_")
+		versions[version] = true
+	}
+	var found bool
+	for i, s := range statuses {
+		if versions[s.Version] {
+			statuses[i].Modified = true
+			found = true
+		}
+	}
+	return found
 }
 
-func makeTable(remoteMigrations, localMigrations []string) string {
+func mergeVersions(remoteMigrations, localMigrations []string) []MigrationStatus {
 	var err error
-	table := "|Local|Remote|Time (UTC)|\n|-|-|-|\n"
+	var result []MigrationStatus
 	for i, j := 0, 0; i < len(remoteMigrations) || j < len(localMigrations); {
 		remoteTimestamp := math.MaxInt
 		if i < len(remoteMigrations) {
@@ -57,16 +113,35 @@ func makeTable(remoteMigrations, localMigrations []string) string {
 		}
 		// Top to bottom chronological order
 		if localTimestamp < remoteTimestamp {
-			table += fmt.Sprintf("|`%s`|` `|`%s`|\n", localMigrations[j], utils.FormatTimestampVersion(localMigrations[j]))
+			result = append(result, MigrationStatus{Version: localMigrations[j], Local: true})
 			j++
 		} else if remoteTimestamp < localTimestamp {
-			table += fmt.Sprintf("|` `|`%s`|`%s`|\n", remoteMigrations[i], utils.FormatTimestampVersion(remoteMigrations[i]))
+			result = append(result, MigrationStatus{Version: remoteMigrations[i], Remote: true})
 			i++
 		} else {
-			table += fmt.Sprintf("|`%s`|`%s`|`%s`|\n", localMigrations[j], remoteMigrations[i], utils.FormatTimestampVersion(remoteMigrations[i]))
+			result = append(result, MigrationStatus{Version: localMigrations[j], Local: true, Remote: true})
 			i++
 			j++
 		}
+		result[len(result)-1].Time = utils.FormatTimestampVersion(result[len(result)-1].Version)
+	}
+	return result
+}
+
+func makeTable(statuses []MigrationStatus) string {
+	table := "|Local|Remote|Time (UTC)|\n|-|-|-|\n"
+	for _, s := range statuses {
+		local, remote := " ", " "
+		if s.Local {
+			local = s.Version
+		}
+		if s.Modified {
+			local += " (modified)"
+		}
+		if s.Remote {
+			remote = s.Version
+		}
+		table += fmt.Sprintf("|`%s`|`%s`|`%s`|\n", local, remote, s.Time)
 	}
 	return table
 }
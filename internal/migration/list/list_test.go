@@ -34,6 +34,8 @@ func TestMigrationList(t *testing.T) {
 		conn := pgtest.NewConn()
 		defer conn.Close(t)
 		conn.Query(migration.LIST_MIGRATION_VERSION).
+			Reply("SELECT 0").
+			Query(migration.SELECT_VERSION_TABLE).
 			Reply("SELECT 0")
 		// Run test
 		err := Run(context.Background(), dbConfig, fsys, conn.Intercept)
@@ -71,17 +73,43 @@ func TestRemoteMigrations(t *testing.T) {
 		conn := pgtest.NewConn()
 		defer conn.Close(t)
 		conn.Query(migration.LIST_MIGRATION_VERSION).
-			Reply("SELECT 1", []interface{}{"20220727064247"})
+			Reply("SELECT 1", []interface{}{"20220727064247"}).
+			Query(migration.SELECT_VERSION_TABLE).
+			Reply("SELECT 0")
+		// Run test
+		versions, _, err := loadRemoteVersions(context.Background(), dbConfig, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"20220727064247"}, versions)
+	})
+
+	t.Run("loads modified migrations without error", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "20220727064247_test.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("select 2"), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.LIST_MIGRATION_VERSION).
+			Reply("SELECT 1", []interface{}{"20220727064247"}).
+			Query(migration.SELECT_VERSION_TABLE).
+			Reply("SELECT 1", migration.MigrationFile{
+				Version:    "20220727064247",
+				Name:       "test",
+				Statements: []string{"select 1"},
+			})
 		// Run test
-		versions, err := loadRemoteVersions(context.Background(), dbConfig, conn.Intercept)
+		versions, modified, err := loadRemoteVersions(context.Background(), dbConfig, fsys, conn.Intercept)
 		// Check error
 		assert.NoError(t, err)
 		assert.ElementsMatch(t, []string{"20220727064247"}, versions)
+		assert.ElementsMatch(t, []string{path}, modified)
 	})
 
 	t.Run("throws error on connect failure", func(t *testing.T) {
 		// Run test
-		_, err := loadRemoteVersions(context.Background(), pgconn.Config{})
+		_, _, err := loadRemoteVersions(context.Background(), pgconn.Config{}, afero.NewMemMapFs())
 		// Check error
 		assert.ErrorContains(t, err, "invalid port (outside range)")
 	})
@@ -91,9 +119,11 @@ func TestRemoteMigrations(t *testing.T) {
 		conn := pgtest.NewConn()
 		defer conn.Close(t)
 		conn.Query(migration.LIST_MIGRATION_VERSION).
+			ReplyError(pgerrcode.UndefinedTable, "relation \"supabase_migrations.schema_migrations\" does not exist").
+			Query(migration.SELECT_VERSION_TABLE).
 			ReplyError(pgerrcode.UndefinedTable, "relation \"supabase_migrations.schema_migrations\" does not exist")
 		// Run test
-		versions, err := loadRemoteVersions(context.Background(), dbConfig, conn.Intercept)
+		versions, _, err := loadRemoteVersions(context.Background(), dbConfig, afero.NewMemMapFs(), conn.Intercept)
 		// Check error
 		assert.NoError(t, err)
 		assert.Empty(t, versions)
@@ -106,7 +136,7 @@ func TestRemoteMigrations(t *testing.T) {
 		conn.Query(migration.LIST_MIGRATION_VERSION).
 			Reply("SELECT 1", []interface{}{})
 		// Run test
-		_, err := loadRemoteVersions(context.Background(), dbConfig, conn.Intercept)
+		_, _, err := loadRemoteVersions(context.Background(), dbConfig, afero.NewMemMapFs(), conn.Intercept)
 		// Check error
 		assert.ErrorContains(t, err, "number of field descriptions must equal number of destinations, got 0 and 1")
 	})
@@ -154,7 +184,7 @@ func TestLocalMigrations(t *testing.T) {
 func TestMakeTable(t *testing.T) {
 	t.Run("tabulate version", func(t *testing.T) {
 		// Run test
-		table := makeTable([]string{"0", "2"}, []string{"0", "1"})
+		table := makeTable(mergeVersions([]string{"0", "2"}, []string{"0", "1"}))
 		// Check error
 		lines := strings.Split(strings.TrimSpace(table), "\n")
 		assert.ElementsMatch(t, []string{
@@ -168,7 +198,7 @@ func TestMakeTable(t *testing.T) {
 
 	t.Run("tabulate timestamp", func(t *testing.T) {
 		// Run test
-		table := makeTable([]string{"20220727064246", "20220727064248"}, []string{"20220727064246", "20220727064247"})
+		table := makeTable(mergeVersions([]string{"20220727064246", "20220727064248"}, []string{"20220727064246", "20220727064247"}))
 		// Check error
 		lines := strings.Split(strings.TrimSpace(table), "\n")
 		assert.ElementsMatch(t, []string{
@@ -180,9 +210,24 @@ func TestMakeTable(t *testing.T) {
 		}, lines)
 	})
 
+	t.Run("marks modified migrations", func(t *testing.T) {
+		statuses := mergeVersions([]string{"0", "1"}, []string{"0", "1"})
+		// Run test
+		found := markModified(statuses, []string{filepath.Join(utils.MigrationsDir, "1_test.sql")})
+		// Check error
+		assert.True(t, found)
+		lines := strings.Split(strings.TrimSpace(makeTable(statuses)), "\n")
+		assert.ElementsMatch(t, []string{
+			"|Local|Remote|Time (UTC)|",
+			"|-|-|-|",
+			"|`0`|`0`|`0`|",
+			"|`1 (modified)`|`1`|`1`|",
+		}, lines)
+	})
+
 	t.Run("ignores string values", func(t *testing.T) {
 		// Run test
-		table := makeTable([]string{"a", "c"}, []string{"a", "b"})
+		table := makeTable(mergeVersions([]string{"a", "c"}, []string{"a", "b"}))
 		// Check error
 		lines := strings.Split(strings.TrimSpace(table), "\n")
 		assert.ElementsMatch(t, []string{
@@ -191,3 +236,16 @@ func TestMakeTable(t *testing.T) {
 		}, lines)
 	})
 }
+
+func TestMergeVersions(t *testing.T) {
+	t.Run("marks local and remote status", func(t *testing.T) {
+		// Run test
+		statuses := mergeVersions([]string{"0", "2"}, []string{"0", "1"})
+		// Check output
+		assert.Equal(t, []MigrationStatus{
+			{Version: "0", Local: true, Remote: true, Time: "0"},
+			{Version: "1", Local: true, Time: "1"},
+			{Version: "2", Remote: true, Time: "2"},
+		}, statuses)
+	})
+}
@@ -1,17 +1,35 @@
 package new
 
 import (
+	"embed"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
 
 	"github.com/go-errors/errors"
 	"github.com/spf13/afero"
 	"github.com/supabase/cli/internal/utils"
 )
 
-func Run(migrationName string, stdin afero.File, fsys afero.Fs) error {
+var (
+	//go:embed templates
+	templatesEmbed embed.FS
+
+	Templates = []string{
+		"table",
+		"rls-policy",
+		"function",
+		"trigger",
+	}
+
+	identifierPattern = regexp.MustCompile(`[^a-z0-9_]+`)
+)
+
+func Run(migrationName, templateName string, stdin afero.File, fsys afero.Fs) error {
 	path := GetMigrationPath(utils.GetCurrentTimestamp(), migrationName)
 	if err := utils.MkdirIfNotExistFS(fsys, filepath.Dir(path)); err != nil {
 		return err
@@ -25,9 +43,36 @@ func Run(migrationName string, stdin afero.File, fsys afero.Fs) error {
 		// File descriptor will always be closed when process quits
 		_ = f.Close()
 	}()
+	if len(templateName) > 0 {
+		return writeTemplate(templateName, migrationName, f)
+	}
 	return CopyStdinIfExists(stdin, f)
 }
 
+type templateConfig struct {
+	Name string
+}
+
+func writeTemplate(templateName, migrationName string, w io.Writer) error {
+	tmpl, err := template.ParseFS(templatesEmbed, "templates/"+templateName+".sql")
+	if err != nil {
+		return errors.Errorf("unknown migration template %s: %w", templateName, err)
+	}
+	if err := tmpl.Execute(w, templateConfig{Name: toIdentifier(migrationName)}); err != nil {
+		return errors.Errorf("failed to write migration template: %w", err)
+	}
+	return nil
+}
+
+// Derives an object name from the migration name, ie. create_todos_table becomes todos.
+func toIdentifier(migrationName string) string {
+	name := identifierPattern.ReplaceAllString(strings.ToLower(migrationName), "_")
+	for _, prefix := range []string{"create_", "add_"} {
+		name = strings.TrimPrefix(name, prefix)
+	}
+	return strings.TrimSuffix(name, "_table")
+}
+
 func GetMigrationPath(timestamp, name string) string {
 	fullName := fmt.Sprintf("%s_%s.sql", timestamp, name)
 	return filepath.Join(utils.MigrationsDir, fullName)
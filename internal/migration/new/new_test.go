@@ -19,7 +19,7 @@ func TestNewCommand(t *testing.T) {
 		stdin, err := fsys.Create("/dev/stdin")
 		require.NoError(t, err)
 		// Run test
-		assert.NoError(t, Run("test_migrate", stdin, fsys))
+		assert.NoError(t, Run("test_migrate", "", stdin, fsys))
 		// Validate output
 		files, err := afero.ReadDir(fsys, utils.MigrationsDir)
 		assert.NoError(t, err)
@@ -38,7 +38,7 @@ func TestNewCommand(t *testing.T) {
 		require.NoError(t, err)
 		require.NoError(t, w.Close())
 		// Run test
-		assert.NoError(t, Run("test_migrate", r, fsys))
+		assert.NoError(t, Run("test_migrate", "", r, fsys))
 		// Validate output
 		files, err := afero.ReadDir(fsys, utils.MigrationsDir)
 		assert.NoError(t, err)
@@ -49,6 +49,37 @@ func TestNewCommand(t *testing.T) {
 		assert.Equal(t, []byte(script), contents)
 	})
 
+	t.Run("creates migration from template", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup empty stdin
+		stdin, err := fsys.Create("/dev/stdin")
+		require.NoError(t, err)
+		// Run test
+		assert.NoError(t, Run("create_todos_table", "table", stdin, fsys))
+		// Validate output
+		files, err := afero.ReadDir(fsys, utils.MigrationsDir)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(files))
+		path := filepath.Join(utils.MigrationsDir, files[0].Name())
+		contents, err := afero.ReadFile(fsys, path)
+		assert.NoError(t, err)
+		assert.Contains(t, string(contents), "create table if not exists public.todos (")
+		assert.Contains(t, string(contents), "alter table public.todos enable row level security;")
+	})
+
+	t.Run("throws error on unknown template", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup empty stdin
+		stdin, err := fsys.Create("/dev/stdin")
+		require.NoError(t, err)
+		// Run test
+		err = Run("test_migrate", "view", stdin, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "unknown migration template view")
+	})
+
 	t.Run("throws error on failure to create directory", func(t *testing.T) {
 		// Setup read-only fs
 		fsys := afero.NewMemMapFs()
@@ -56,7 +87,7 @@ func TestNewCommand(t *testing.T) {
 		stdin, err := fsys.Create("/dev/stdin")
 		require.NoError(t, err)
 		// Run test
-		assert.Error(t, Run("test_migrate", stdin, afero.NewReadOnlyFs(fsys)))
+		assert.Error(t, Run("test_migrate", "", stdin, afero.NewReadOnlyFs(fsys)))
 	})
 
 	t.Run("throws error on closed pipe", func(t *testing.T) {
@@ -67,6 +98,6 @@ func TestNewCommand(t *testing.T) {
 		require.NoError(t, err)
 		require.NoError(t, r.Close())
 		// Run test
-		assert.Error(t, Run("test_migrate", r, fsys))
+		assert.Error(t, Run("test_migrate", "", r, fsys))
 	})
 }
@@ -0,0 +1,10 @@
+create or replace function public.{{ .Name }}()
+returns void
+language plpgsql
+security invoker
+set search_path = ''
+as $$
+begin
+  -- Object names must be schema qualified because search_path is empty
+end;
+$$;
@@ -0,0 +1,23 @@
+-- Assumes public.{{ .Name }} has a user_id column referencing auth.users
+alter table public.{{ .Name }} enable row level security;
+
+create policy "Users can view their own {{ .Name }}"
+on public.{{ .Name }} for select
+to authenticated
+using ((select auth.uid()) = user_id);
+
+create policy "Users can insert their own {{ .Name }}"
+on public.{{ .Name }} for insert
+to authenticated
+with check ((select auth.uid()) = user_id);
+
+create policy "Users can update their own {{ .Name }}"
+on public.{{ .Name }} for update
+to authenticated
+using ((select auth.uid()) = user_id)
+with check ((select auth.uid()) = user_id);
+
+create policy "Users can delete their own {{ .Name }}"
+on public.{{ .Name }} for delete
+to authenticated
+using ((select auth.uid()) = user_id);
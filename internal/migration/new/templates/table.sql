@@ -0,0 +1,22 @@
+create table if not exists public.{{ .Name }} (
+  id bigint generated always as identity primary key,
+  created_at timestamptz not null default now(),
+  updated_at timestamptz not null default now()
+);
+
+alter table public.{{ .Name }} enable row level security;
+
+create or replace function public.{{ .Name }}_set_updated_at()
+returns trigger
+language plpgsql
+set search_path = ''
+as $$
+begin
+  new.updated_at = now();
+  return new;
+end;
+$$;
+
+create trigger {{ .Name }}_set_updated_at
+before update on public.{{ .Name }}
+for each row execute function public.{{ .Name }}_set_updated_at();
@@ -0,0 +1,15 @@
+create or replace function public.{{ .Name }}()
+returns trigger
+language plpgsql
+security invoker
+set search_path = ''
+as $$
+begin
+  return new;
+end;
+$$;
+
+-- Replace public.your_table with the table to attach this trigger to
+create trigger {{ .Name }}
+before insert or update on public.your_table
+for each row execute function public.{{ .Name }}();
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 
 	"github.com/go-errors/errors"
@@ -87,12 +88,52 @@ func UpdateMigrationTable(ctx context.Context, conn *pgx.Conn, version []string,
 	return nil
 }
 
+// Records the statements of local migration files as applied, so that intended edits are no longer reported as modified.
+func RunRecalculate(ctx context.Context, config pgconn.Config, version []string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	for _, v := range version {
+		if _, err := strconv.Atoi(v); err != nil {
+			return errors.Errorf("failed to parse %s: %w", v, ErrInvalidVersion)
+		}
+	}
+	if len(version) == 0 {
+		local, err := list.LoadLocalVersions(fsys)
+		if err != nil {
+			return err
+		}
+		version = local
+	}
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	batch := &pgx.Batch{}
+	for _, v := range version {
+		f, err := NewMigrationFromVersion(v, fsys)
+		if err != nil {
+			return err
+		}
+		// Versions missing from the history table are left unapplied
+		batch.Queue(migration.UPDATE_MIGRATION_VERSION, f.Version, f.Name, f.Statements)
+	}
+	if err := conn.SendBatch(ctx, batch).Close(); err != nil {
+		return errors.Errorf("failed to update migration table: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Recalculated migration history: %v\n", version)
+	utils.CmdSuggestion = fmt.Sprintf("Run %s to show the updated migration history.", utils.Aqua("supabase migration list"))
+	return nil
+}
+
 func GetMigrationFile(version string, fsys afero.Fs) (string, error) {
 	path := filepath.Join(utils.MigrationsDir, version+"_*.sql")
 	matches, err := afero.Glob(fsys, path)
 	if err != nil {
 		return "", errors.Errorf("failed to glob migration files: %w", err)
 	}
+	all := slices.Clone(matches)
+	matches = slices.DeleteFunc(matches, func(name string) bool {
+		return migration.IsDownMigration(name, all)
+	})
 	if len(matches) == 0 {
 		return "", errors.Errorf("glob %s: %w", path, os.ErrNotExist)
 	}
@@ -153,3 +153,33 @@ func TestRepairAll(t *testing.T) {
 		assert.ErrorIs(t, err, os.ErrPermission)
 	})
 }
+
+func TestRepairRecalculate(t *testing.T) {
+	t.Run("updates applied statements", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		path := filepath.Join(utils.MigrationsDir, "0_test.sql")
+		require.NoError(t, afero.WriteFile(fsys, path, []byte("select 1"), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.UPDATE_MIGRATION_VERSION, "0", "test", []string{"select 1"}).
+			Reply("UPDATE 1")
+		// Run test
+		err := RunRecalculate(context.Background(), dbConfig, nil, fsys, conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on missing file", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		// Run test
+		err := RunRecalculate(context.Background(), dbConfig, []string{"0"}, fsys, conn.Intercept)
+		// Check error
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
@@ -0,0 +1,222 @@
+package squash
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/dump"
+	"github.com/supabase/cli/internal/migration/list"
+	"github.com/supabase/cli/internal/migration/repair"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+	"github.com/supabase/cli/pkg/parser"
+)
+
+// Squashes migrations between from and to versions inclusive, preserving earlier migrations.
+func RunRange(ctx context.Context, from, to string, archive bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	for _, v := range []string{from, to} {
+		if len(v) == 0 {
+			continue
+		}
+		if _, err := strconv.Atoi(v); err != nil {
+			return errors.New(repair.ErrInvalidVersion)
+		}
+		if _, err := repair.GetMigrationFile(v, fsys); err != nil {
+			return err
+		}
+	}
+	if len(to) > 0 && from > to {
+		return errors.Errorf("Invalid range: --from %s is later than --to %s", from, to)
+	}
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	// 1. Squash local migrations
+	versions, err := squashRange(ctx, from, to, archive, fsys, options...)
+	if err != nil || len(versions) < 2 {
+		return err
+	}
+	// 2. Update migration history
+	if utils.IsLocalDatabase(config) {
+		return nil
+	}
+	if shouldUpdate, err := utils.NewConsole().PromptYesNo(ctx, "Update remote migration history table?", true); err != nil {
+		return err
+	} else if !shouldUpdate {
+		return nil
+	}
+	return replaceMigrations(ctx, config, versions, fsys, options...)
+}
+
+// Returns the versions that were squashed, the last of which holds the squashed schema.
+func squashRange(ctx context.Context, from, to string, archive bool, fsys afero.Fs, options ...func(*pgx.ConnConfig)) ([]string, error) {
+	migrations, err := list.LoadPartialMigrations(to, fsys)
+	if err != nil {
+		return nil, err
+	}
+	start := slices.IndexFunc(migrations, func(path string) bool {
+		return getVersion(path) >= from
+	})
+	if start < 0 {
+		return nil, errors.New(ErrMissingVersion)
+	}
+	selected := migrations[start:]
+	local := selected[len(selected)-1]
+	if len(selected) == 1 {
+		fmt.Fprintln(os.Stderr, utils.Bold(local), "is the only migration in range.")
+		return nil, nil
+	}
+	shadow, conn, err := setupShadowDatabase(ctx, fsys, options...)
+	if err != nil {
+		return nil, err
+	}
+	defer utils.DockerRemove(shadow)
+	defer conn.Close(context.Background())
+	// Dump the schema before and after replaying selected migrations
+	if err := migration.ApplyMigrations(ctx, migrations[:start], conn, afero.NewIOFS(fsys)); err != nil {
+		return nil, err
+	}
+	var before, after bytes.Buffer
+	if err := dumpAllSchemas(ctx, &before); err != nil {
+		return nil, err
+	}
+	if err := migration.ApplyMigrations(ctx, selected, conn, afero.NewIOFS(fsys)); err != nil {
+		return nil, err
+	}
+	if err := dumpAllSchemas(ctx, &after); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "-- Squashed migrations %s to %s\n\n", getVersion(selected[0]), getVersion(local))
+	if err := diffStatements(&before, &after, &out); err != nil {
+		return nil, err
+	}
+	if err := utils.WriteFile(local, out.Bytes(), fsys); err != nil {
+		return nil, err
+	}
+	fmt.Fprintln(os.Stderr, "Squashed local migrations to", utils.Bold(local))
+	if err := removeMigrations(selected[:len(selected)-1], archive, fsys); err != nil {
+		return nil, err
+	}
+	versions := make([]string, len(selected))
+	for i, path := range selected {
+		versions[i] = getVersion(path)
+	}
+	return versions, nil
+}
+
+// Dumps user schemas followed by managed schemas that user migrations may alter.
+func dumpAllSchemas(ctx context.Context, w io.Writer) error {
+	config := shadowConfig()
+	if err := dump.DumpSchema(ctx, config, nil, false, false, w); err != nil {
+		return err
+	}
+	return dump.DumpSchema(ctx, config, []string{"auth", "storage"}, false, false, w)
+}
+
+// Writes statements in after that are missing from before. Since pg_dump emits a whole object
+// per statement, any before statement missing from after means an object was altered or dropped.
+func diffStatements(before, after io.Reader, w io.Writer) error {
+	prev, err := parser.SplitAndTrim(before)
+	if err != nil {
+		return err
+	}
+	next, err := parser.SplitAndTrim(after)
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]int, len(prev))
+	for _, stat := range prev {
+		seen[stat]++
+	}
+	for _, stat := range next {
+		if !isSessionSetting(stat) && seen[stat] > 0 {
+			seen[stat]--
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s;\n\n", stat); err != nil {
+			return errors.Errorf("failed to write statement: %w", err)
+		}
+	}
+	for _, stat := range prev {
+		if !isSessionSetting(stat) && seen[stat] > 0 {
+			utils.CmdSuggestion = fmt.Sprintf("Squash from the first migration by omitting %s instead.", utils.Aqua("--from"))
+			return errors.Errorf("Migrations in range alter or drop an existing object, which cannot be squashed without losing changes:\n%s", stat)
+		}
+	}
+	return nil
+}
+
+// Session settings emitted at the start of each dump must be kept in the squashed file.
+func isSessionSetting(stat string) bool {
+	return strings.HasPrefix(stat, "SET ") || strings.HasPrefix(stat, "SELECT pg_catalog.set_config(")
+}
+
+func getVersion(path string) string {
+	version, _, _ := strings.Cut(filepath.Base(path), "_")
+	return version
+}
+
+// Moves squashed migrations out of the migrations directory.
+func archiveMigrations(paths []string, fsys afero.Fs) error {
+	if err := utils.MkdirIfNotExistFS(fsys, utils.MigrationsArchiveDir); err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if err := fsys.Rename(path, filepath.Join(utils.MigrationsArchiveDir, filepath.Base(path))); err != nil {
+			return errors.Errorf("failed to archive migration: %w", err)
+		}
+	}
+	fmt.Fprintln(os.Stderr, "Archived", len(paths), "migrations to", utils.Bold(utils.MigrationsArchiveDir))
+	return nil
+}
+
+// Replaces squashed versions in the remote history table with the last version.
+func replaceMigrations(ctx context.Context, config pgconn.Config, versions []string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	conn, err := utils.ConnectByConfig(ctx, config, options...)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+	remote, err := migration.ListRemoteMigrations(ctx, conn)
+	if err != nil {
+		return err
+	}
+	var applied int
+	for _, v := range versions {
+		if slices.Contains(remote, v) {
+			applied++
+		}
+	}
+	if applied == 0 {
+		fmt.Fprintln(os.Stderr, "Squashed migrations have not been applied to the remote database.")
+		return nil
+	} else if applied < len(versions) {
+		return errors.Errorf("Remote database has applied %d of %d squashed migrations. Run %s before updating the migration history.", applied, len(versions), utils.Aqua("supabase db push"))
+	}
+	last := versions[len(versions)-1]
+	fmt.Fprintln(os.Stderr, "Replacing migration history from", versions[0], "to", last)
+	m, err := repair.NewMigrationFromVersion(last, fsys)
+	if err != nil {
+		return err
+	}
+	// Data statements don't mutate schemas, safe to use statement cache
+	batch := pgx.Batch{}
+	batch.Queue(migration.DELETE_MIGRATION_VERSION, versions)
+	batch.Queue(migration.INSERT_MIGRATION_VERSION, m.Version, m.Name, m.Statements)
+	if err := conn.SendBatch(ctx, &batch).Close(); err != nil {
+		return errors.Errorf("failed to update migration history: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package squash
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/migration/repair"
+	"github.com/supabase/cli/internal/utils"
+	"github.com/supabase/cli/pkg/migration"
+	"github.com/supabase/cli/pkg/pgtest"
+)
+
+func TestSquashRange(t *testing.T) {
+	t.Run("throws error on invalid version", func(t *testing.T) {
+		// Run test
+		err := RunRange(context.Background(), "1_init", "", false, pgconn.Config{}, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, repair.ErrInvalidVersion)
+	})
+
+	t.Run("throws error on missing version", func(t *testing.T) {
+		// Run test
+		err := RunRange(context.Background(), "1", "", false, pgconn.Config{}, afero.NewMemMapFs())
+		// Check error
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("throws error on reversed range", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.MigrationsDir, "1_init.sql"), []byte{}, 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.MigrationsDir, "2_table.sql"), []byte{}, 0644))
+		// Run test
+		err := RunRange(context.Background(), "2", "1", false, pgconn.Config{}, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid range: --from 2 is later than --to 1")
+	})
+
+	t.Run("skips single migration in range", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.MigrationsDir, "1_init.sql"), []byte{}, 0644))
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.MigrationsDir, "2_table.sql"), []byte{}, 0644))
+		// Run test
+		versions, err := squashRange(context.Background(), "2", "", false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, versions)
+	})
+
+	t.Run("throws error on empty range", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.MigrationsDir, "1_init.sql"), []byte{}, 0644))
+		// Run test
+		versions, err := squashRange(context.Background(), "2", "", false, fsys)
+		// Check error
+		assert.ErrorIs(t, err, ErrMissingVersion)
+		assert.Empty(t, versions)
+	})
+}
+
+func TestDiffStatements(t *testing.T) {
+	header := "SET statement_timeout = 0;\nSELECT pg_catalog.set_config('search_path', '', false);\n"
+
+	t.Run("keeps new objects and session settings", func(t *testing.T) {
+		before := header + "CREATE TABLE public.a (id bigint);\nGRANT ALL ON TABLE public.a TO anon;\n"
+		after := before + "CREATE SEQUENCE public.b_id_seq;\nCREATE TYPE public.c AS (x int);\nGRANT ALL ON SEQUENCE public.b_id_seq TO anon;\n"
+		// Run test
+		var out bytes.Buffer
+		err := diffStatements(strings.NewReader(before), strings.NewReader(after), &out)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, "SET statement_timeout = 0;\n\n"+
+			"SELECT pg_catalog.set_config('search_path', '', false);\n\n"+
+			"CREATE SEQUENCE public.b_id_seq;\n\n"+
+			"CREATE TYPE public.c AS (x int);\n\n"+
+			"GRANT ALL ON SEQUENCE public.b_id_seq TO anon;\n\n", out.String())
+	})
+
+	t.Run("throws error on altered object", func(t *testing.T) {
+		before := header + "CREATE TABLE public.a (id bigint);\n"
+		after := header + "CREATE TABLE public.a (id bigint, name text);\n"
+		// Run test
+		err := diffStatements(strings.NewReader(before), strings.NewReader(after), io.Discard)
+		// Check error
+		assert.ErrorContains(t, err, "Migrations in range alter or drop an existing object")
+		assert.ErrorContains(t, err, "CREATE TABLE public.a (id bigint)")
+	})
+}
+
+func TestArchiveMigrations(t *testing.T) {
+	// Setup in-memory fs
+	fsys := afero.NewMemMapFs()
+	path := filepath.Join(utils.MigrationsDir, "1_init.sql")
+	require.NoError(t, afero.WriteFile(fsys, path, []byte("create schema test"), 0644))
+	// Run test
+	err := archiveMigrations([]string{path}, fsys)
+	// Check error
+	assert.NoError(t, err)
+	exists, err := afero.Exists(fsys, path)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+	match, err := afero.FileContainsBytes(fsys, filepath.Join(utils.MigrationsArchiveDir, "1_init.sql"), []byte("create schema test"))
+	assert.NoError(t, err)
+	assert.True(t, match)
+}
+
+func TestReplaceMigrations(t *testing.T) {
+	t.Run("replaces squashed versions", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		sql := "create schema test"
+		require.NoError(t, afero.WriteFile(fsys, filepath.Join(utils.MigrationsDir, "2_table.sql"), []byte(sql), 0644))
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.LIST_MIGRATION_VERSION).
+			Reply("SELECT 3", []interface{}{"0"}, []interface{}{"1"}, []interface{}{"2"}).
+			Query("DELETE FROM supabase_migrations.schema_migrations WHERE version = ANY( '{1,2}' );INSERT INTO supabase_migrations.schema_migrations(version, name, statements) VALUES( '2' ,  'table' ,  '{create schema test}' )").
+			Reply("INSERT 0 1")
+		// Run test
+		err := replaceMigrations(context.Background(), dbConfig, []string{"1", "2"}, fsys, conn.Intercept, func(cc *pgx.ConnConfig) {
+			cc.PreferSimpleProtocol = true
+		})
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("skips unapplied versions", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.LIST_MIGRATION_VERSION).
+			Reply("SELECT 1", []interface{}{"0"})
+		// Run test
+		err := replaceMigrations(context.Background(), dbConfig, []string{"1", "2"}, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on partially applied versions", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.LIST_MIGRATION_VERSION).
+			Reply("SELECT 1", []interface{}{"1"})
+		// Run test
+		err := replaceMigrations(context.Background(), dbConfig, []string{"1", "2"}, afero.NewMemMapFs(), conn.Intercept)
+		// Check error
+		assert.ErrorContains(t, err, "Remote database has applied 1 of 2 squashed migrations.")
+	})
+}
@@ -25,7 +25,7 @@ import (
 
 var ErrMissingVersion = errors.New("version not found")
 
-func Run(ctx context.Context, version string, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+func Run(ctx context.Context, version string, archive bool, config pgconn.Config, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
 	if len(version) > 0 {
 		if _, err := strconv.Atoi(version); err != nil {
 			return errors.New(repair.ErrInvalidVersion)
@@ -38,7 +38,7 @@ func Run(ctx context.Context, version string, config pgconn.Config, fsys afero.F
 		return err
 	}
 	// 1. Squash local migrations
-	if err := squashToVersion(ctx, version, fsys, options...); err != nil {
+	if err := squashToVersion(ctx, version, archive, fsys, options...); err != nil {
 		return err
 	}
 	// 2. Update migration history
@@ -53,7 +53,7 @@ func Run(ctx context.Context, version string, config pgconn.Config, fsys afero.F
 	return baselineMigrations(ctx, config, version, fsys, options...)
 }
 
-func squashToVersion(ctx context.Context, version string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+func squashToVersion(ctx context.Context, version string, archive bool, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
 	migrations, err := list.LoadPartialMigrations(version, fsys)
 	if err != nil {
 		return err
@@ -71,42 +71,53 @@ func squashToVersion(ctx context.Context, version string, fsys afero.Fs, options
 		return err
 	}
 	fmt.Fprintln(os.Stderr, "Squashed local migrations to", utils.Bold(local))
-	// Remove merged files
-	for _, path := range migrations[:len(migrations)-1] {
-		if err := fsys.Remove(path); err != nil {
-			fmt.Fprintln(os.Stderr, err)
-		}
-	}
-	return nil
+	return removeMigrations(migrations[:len(migrations)-1], archive, fsys)
 }
 
-func squashMigrations(ctx context.Context, migrations []string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
-	// 1. Start shadow database
+// Starts a shadow database with managed schemas, which the caller must remove.
+func setupShadowDatabase(ctx context.Context, fsys afero.Fs, options ...func(*pgx.ConnConfig)) (string, *pgx.Conn, error) {
 	shadow, err := diff.CreateShadowDatabase(ctx, utils.Config.Db.ShadowPort)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
-	defer utils.DockerRemove(shadow)
 	if err := start.WaitForHealthyService(ctx, start.HealthTimeout, shadow); err != nil {
-		return err
+		utils.DockerRemove(shadow)
+		return "", nil, err
 	}
 	conn, err := diff.ConnectShadowDatabase(ctx, 10*time.Second, options...)
 	if err != nil {
-		return err
+		utils.DockerRemove(shadow)
+		return "", nil, err
 	}
-	defer conn.Close(context.Background())
 	if err := start.SetupDatabase(ctx, conn, shadow[:12], os.Stderr, fsys); err != nil {
-		return err
+		conn.Close(context.Background())
+		utils.DockerRemove(shadow)
+		return "", nil, err
 	}
-	// Assuming entities in managed schemas are not altered, we can simply diff the dumps before and after migrations.
-	schemas := []string{"auth", "storage"}
-	config := pgconn.Config{
+	return shadow, conn, nil
+}
+
+func shadowConfig() pgconn.Config {
+	return pgconn.Config{
 		Host:     utils.Config.Hostname,
 		Port:     utils.Config.Db.ShadowPort,
 		User:     "postgres",
 		Password: utils.Config.Db.Password,
 		Database: "postgres",
 	}
+}
+
+func squashMigrations(ctx context.Context, migrations []string, fsys afero.Fs, options ...func(*pgx.ConnConfig)) error {
+	// 1. Start shadow database
+	shadow, conn, err := setupShadowDatabase(ctx, fsys, options...)
+	if err != nil {
+		return err
+	}
+	defer utils.DockerRemove(shadow)
+	defer conn.Close(context.Background())
+	// Assuming entities in managed schemas are not altered, we can simply diff the dumps before and after migrations.
+	schemas := []string{"auth", "storage"}
+	config := shadowConfig()
 	var before, after bytes.Buffer
 	if err := dump.DumpSchema(ctx, config, schemas, false, false, &before); err != nil {
 		return err
@@ -133,6 +144,19 @@ func squashMigrations(ctx context.Context, migrations []string, fsys afero.Fs, o
 	return lineByLineDiff(&before, &after, f)
 }
 
+// Removes merged files, or moves them out of the migrations directory if archive is set.
+func removeMigrations(paths []string, archive bool, fsys afero.Fs) error {
+	if archive {
+		return archiveMigrations(paths, fsys)
+	}
+	for _, path := range paths {
+		if err := fsys.Remove(path); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+	return nil
+}
+
 const separatorComment = `
 --
 -- Dumped schema changes for auth and storage
@@ -90,7 +90,7 @@ func TestSquashCommand(t *testing.T) {
 			Query(migration.INSERT_MIGRATION_VERSION, "1", "target", nil).
 			Reply("INSERT 0 1")
 		// Run test
-		err := Run(context.Background(), "", pgconn.Config{
+		err := Run(context.Background(), "", false, pgconn.Config{
 			Host: "127.0.0.1",
 			Port: 54322,
 		}, fsys, conn.Intercept)
@@ -100,6 +100,9 @@ func TestSquashCommand(t *testing.T) {
 		exists, err := afero.Exists(fsys, paths[0])
 		assert.NoError(t, err)
 		assert.False(t, exists)
+		exists, err = afero.Exists(fsys, utils.MigrationsArchiveDir)
+		assert.NoError(t, err)
+		assert.False(t, exists)
 		match, err := afero.FileContainsBytes(fsys, paths[1], []byte(sql))
 		assert.NoError(t, err)
 		assert.True(t, match)
@@ -119,7 +122,7 @@ func TestSquashCommand(t *testing.T) {
 			Query(fmt.Sprintf("DELETE FROM supabase_migrations.schema_migrations WHERE version <=  '0' ;INSERT INTO supabase_migrations.schema_migrations(version, name, statements) VALUES( '0' ,  'init' ,  '{%s}' )", sql)).
 			Reply("INSERT 0 1")
 		// Run test
-		err := Run(context.Background(), "0", dbConfig, fsys, conn.Intercept, func(cc *pgx.ConnConfig) {
+		err := Run(context.Background(), "0", false, dbConfig, fsys, conn.Intercept, func(cc *pgx.ConnConfig) {
 			cc.PreferSimpleProtocol = true
 		})
 		// Check error
@@ -133,7 +136,7 @@ func TestSquashCommand(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), "0_init", pgconn.Config{}, fsys)
+		err := Run(context.Background(), "0_init", false, pgconn.Config{}, fsys)
 		// Check error
 		assert.ErrorIs(t, err, repair.ErrInvalidVersion)
 	})
@@ -142,7 +145,7 @@ func TestSquashCommand(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := Run(context.Background(), "0", pgconn.Config{}, fsys)
+		err := Run(context.Background(), "0", false, pgconn.Config{}, fsys)
 		// Check error
 		assert.ErrorIs(t, err, os.ErrNotExist)
 	})
@@ -153,7 +156,7 @@ func TestSquashVersion(t *testing.T) {
 		// Setup in-memory fs
 		fsys := &fstest.OpenErrorFs{DenyPath: utils.MigrationsDir}
 		// Run test
-		err := squashToVersion(context.Background(), "0", fsys)
+		err := squashToVersion(context.Background(), "0", false, fsys)
 		// Check error
 		assert.ErrorIs(t, err, os.ErrPermission)
 	})
@@ -162,7 +165,7 @@ func TestSquashVersion(t *testing.T) {
 		// Setup in-memory fs
 		fsys := afero.NewMemMapFs()
 		// Run test
-		err := squashToVersion(context.Background(), "0", fsys)
+		err := squashToVersion(context.Background(), "0", false, fsys)
 		// Check error
 		assert.ErrorIs(t, err, ErrMissingVersion)
 	})
@@ -181,7 +184,7 @@ func TestSquashVersion(t *testing.T) {
 			Get("/v" + utils.Docker.ClientVersion() + "/images/" + utils.GetRegistryImageUrl(utils.Config.Db.Image) + "/json").
 			ReplyError(errors.New("network error"))
 		// Run test
-		err := squashToVersion(context.Background(), "1", fsys)
+		err := squashToVersion(context.Background(), "1", false, fsys)
 		// Check error
 		assert.ErrorContains(t, err, "network error")
 		assert.Empty(t, apitest.ListUnmatchedRequests())
@@ -3,6 +3,7 @@ package up
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/go-errors/errors"
@@ -61,3 +62,28 @@ func suggestIgnoreFlag(paths []string) string {
 	result += fmt.Sprintln(utils.Bold(strings.Join(paths, "\n")))
 	return result
 }
+
+func CheckModifiedMigrations(ctx context.Context, conn *pgx.Conn, fsys afero.Fs) error {
+	localMigrations, err := migration.ListLocalMigrations(utils.MigrationsDir, afero.NewIOFS(fsys))
+	if err != nil {
+		return err
+	}
+	modified, err := migration.FindModifiedMigrations(ctx, localMigrations, conn, afero.NewIOFS(fsys))
+	if errors.Is(err, migration.ErrModifiedLocal) {
+		utils.CmdSuggestion = suggestRecalculate(modified)
+	}
+	return err
+}
+
+func suggestRecalculate(paths []string) string {
+	result := fmt.Sprintln("\nThese migrations no longer match the statements applied to the remote database:")
+	result += fmt.Sprintln(utils.Bold(strings.Join(paths, "\n")))
+	result += fmt.Sprintln("\nRevert your local changes and create a new migration instead. If the edits are intended, update the migration history table:")
+	var versions []string
+	for _, path := range paths {
+		version, _, _ := strings.Cut(filepath.Base(path), "_")
+		versions = append(versions, version)
+	}
+	result += fmt.Sprintln(utils.Bold("supabase migration repair --recalculate " + strings.Join(versions, " ")))
+	return result
+}
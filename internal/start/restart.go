@@ -0,0 +1,111 @@
+package start
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/errdefs"
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/spf13/afero"
+	"github.com/supabase/cli/internal/db/start"
+	"github.com/supabase/cli/internal/utils"
+)
+
+type localService struct {
+	Alias string
+	Id    string
+	Image string
+}
+
+func listServices() []localService {
+	return []localService{
+		{Alias: utils.KongAliases[0], Id: utils.KongId, Image: utils.Config.Api.KongImage},
+		{Alias: utils.GotrueAliases[0], Id: utils.GotrueId, Image: utils.Config.Auth.Image},
+		{Alias: utils.InbucketAliases[0], Id: utils.InbucketId, Image: utils.Config.Inbucket.Image},
+		{Alias: utils.RealtimeAliases[0], Id: utils.RealtimeId, Image: utils.Config.Realtime.Image},
+		{Alias: utils.RestAliases[0], Id: utils.RestId, Image: utils.Config.Api.Image},
+		{Alias: utils.StorageAliases[0], Id: utils.StorageId, Image: utils.Config.Storage.Image},
+		{Alias: utils.ImgProxyAliases[0], Id: utils.ImgProxyId, Image: utils.Config.Storage.ImageTransformation.Image},
+		{Alias: utils.PgmetaAliases[0], Id: utils.PgmetaId, Image: utils.Config.Studio.PgmetaImage},
+		{Alias: utils.StudioAliases[0], Id: utils.StudioId, Image: utils.Config.Studio.Image},
+		{Alias: utils.EdgeRuntimeAliases[0], Id: utils.EdgeRuntimeId, Image: utils.Config.EdgeRuntime.Image},
+		{Alias: utils.LogflareAliases[0], Id: utils.LogflareId, Image: utils.Config.Analytics.Image},
+		{Alias: utils.VectorAliases[0], Id: utils.VectorId, Image: utils.Config.Analytics.VectorImage},
+		{Alias: utils.PoolerAliases[0], Id: utils.PoolerId, Image: utils.Config.Db.Pooler.Image},
+	}
+}
+
+// Service can be referenced by its network alias, ie. rest, or its image name, ie. postgrest.
+func findService(name string) (localService, error) {
+	var names []string
+	for _, s := range listServices() {
+		if s.Alias == name || utils.ShortContainerImageName(s.Image) == name {
+			return s, nil
+		}
+		names = append(names, s.Alias)
+	}
+	return localService{}, errors.Errorf("Unknown service: %s. Must be one of: %s", name, strings.Join(names, ", "))
+}
+
+// Image proxy mounts volumes from storage so both containers must be recreated together.
+func withDependents(service localService) []localService {
+	if service.Id != utils.StorageId && service.Id != utils.ImgProxyId {
+		return []localService{service}
+	}
+	imgproxy, _ := findService(utils.ImgProxyAliases[0])
+	storage, _ := findService(utils.StorageAliases[0])
+	// Dependent container is removed first
+	return []localService{imgproxy, storage}
+}
+
+// Recreates a single service container with the latest config, leaving the database and other services running.
+func Restart(ctx context.Context, name string, ignoreHealthCheck bool, fsys afero.Fs) error {
+	if err := utils.LoadConfigFS(fsys); err != nil {
+		return err
+	}
+	service, err := findService(name)
+	if err != nil {
+		return err
+	}
+	if err := utils.AssertSupabaseDbIsRunning(); err != nil {
+		return err
+	}
+	included := map[string]bool{}
+	for _, s := range withDependents(service) {
+		if err := utils.Docker.ContainerRemove(ctx, s.Id, container.RemoveOptions{
+			RemoveVolumes: true,
+			Force:         true,
+		}); err != nil && !errdefs.IsNotFound(err) {
+			return errors.Errorf("failed to remove container: %w", err)
+		}
+		included[utils.ShortContainerImageName(s.Image)] = true
+	}
+	// Exclude the database and all other services from being started again
+	excluded := []string{utils.ShortContainerImageName(utils.Config.Db.Image)}
+	for _, s := range listServices() {
+		if name := utils.ShortContainerImageName(s.Image); !included[name] {
+			excluded = append(excluded, name)
+		}
+	}
+	if err := utils.RunProgram(ctx, func(p utils.Program, ctx context.Context) error {
+		dbConfig := pgconn.Config{
+			Host:     utils.DbId,
+			Port:     5432,
+			User:     "postgres",
+			Password: utils.Config.Db.Password,
+			Database: "postgres",
+		}
+		return run(p, ctx, fsys, excluded, dbConfig)
+	}); err != nil {
+		if !ignoreHealthCheck || !start.IsUnhealthyError(err) {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, err)
+	}
+	fmt.Fprintln(os.Stderr, "Restarted", utils.Aqua(service.Alias)+".")
+	return nil
+}
@@ -0,0 +1,109 @@
+package start
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/h2non/gock"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/internal/testing/apitest"
+	"github.com/supabase/cli/internal/utils"
+)
+
+func TestRestartCommand(t *testing.T) {
+	t.Run("recreates a single service", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_test/json").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{})
+		gock.New(utils.Docker.DaemonHost()).
+			Delete("/v" + utils.Docker.ClientVersion() + "/containers/supabase_rest_test").
+			Reply(http.StatusOK)
+		apitest.MockDockerStart(utils.Docker, utils.GetRegistryImageUrl(utils.Config.Api.Image), "supabase_rest_test")
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_rest_test/json").
+			Reply(http.StatusOK).
+			JSON(types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{
+				State: &types.ContainerState{Running: true},
+			}})
+		gock.New(utils.Config.Api.ExternalUrl).
+			Head("/rest-admin/v1/ready").
+			Reply(http.StatusOK)
+		// Run test
+		err := Restart(context.Background(), "rest", false, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+
+	t.Run("throws error on unknown service", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Run test
+		err := Restart(context.Background(), "postgres", false, fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Unknown service: postgres")
+	})
+
+	t.Run("throws error when db is not running", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := afero.NewMemMapFs()
+		require.NoError(t, utils.InitConfig(utils.InitParams{ProjectId: "test"}, fsys))
+		// Setup mock docker
+		require.NoError(t, apitest.MockDocker(utils.Docker))
+		defer gock.OffAll()
+		gock.New(utils.Docker.DaemonHost()).
+			Get("/v" + utils.Docker.ClientVersion() + "/containers/supabase_db_test/json").
+			Reply(http.StatusNotFound)
+		// Run test
+		err := Restart(context.Background(), "auth", false, fsys)
+		// Check error
+		assert.ErrorIs(t, err, utils.ErrNotRunning)
+		assert.Empty(t, apitest.ListUnmatchedRequests())
+	})
+}
+
+func TestWithDependents(t *testing.T) {
+	utils.Config.Storage.ImageTransformation.Image = "darthsim/imgproxy:v3.8.0"
+
+	t.Run("recreates imgproxy with storage", func(t *testing.T) {
+		storage, err := findService("storage")
+		require.NoError(t, err)
+		// Run test
+		services := withDependents(storage)
+		// Check output
+		assert.Len(t, services, 2)
+		assert.Equal(t, utils.ImgProxyId, services[0].Id)
+		assert.Equal(t, utils.StorageId, services[1].Id)
+	})
+
+	t.Run("recreates storage with imgproxy", func(t *testing.T) {
+		imgproxy, err := findService("imgproxy")
+		require.NoError(t, err)
+		// Run test
+		services := withDependents(imgproxy)
+		// Check output
+		assert.Len(t, services, 2)
+		assert.Equal(t, utils.StorageId, services[1].Id)
+	})
+
+	t.Run("recreates other services alone", func(t *testing.T) {
+		rest, err := findService("rest")
+		require.NoError(t, err)
+		// Run test
+		services := withDependents(rest)
+		// Check output
+		assert.Equal(t, []localService{rest}, services)
+	})
+}
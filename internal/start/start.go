@@ -49,6 +49,7 @@ func Run(ctx context.Context, fsys afero.Fs, excludedContainers []string, ignore
 		if err := utils.LoadConfigFS(fsys); err != nil {
 			return err
 		}
+		excludedContainers = append(excludedContainers, utils.Config.Services.Disabled...)
 		if err := utils.AssertSupabaseDbIsRunning(); err == nil {
 			fmt.Fprintln(os.Stderr, utils.Aqua("supabase start")+" is already running.")
 			utils.CmdSuggestion = fmt.Sprintf("Run %s to show status of local Supabase containers.", utils.Aqua("supabase status"))
@@ -167,7 +168,8 @@ var (
 	poolerTenantTemplate = template.Must(template.New("poolerTenant").Parse(poolerTenantEmbed))
 )
 
-var serviceTimeout = 30 * time.Second
+// Maximum duration to wait for all services to become healthy.
+var ServiceTimeout = 30 * time.Second
 
 func run(p utils.Program, ctx context.Context, fsys afero.Fs, excludedContainers []string, dbConfig pgconn.Config, options ...func(*pgx.ConnConfig)) error {
 	excluded := make(map[string]bool)
@@ -182,7 +184,7 @@ func run(p utils.Program, ctx context.Context, fsys afero.Fs, excludedContainers
 
 	// Start Postgres.
 	w := utils.StatusWriter{Program: p}
-	if dbConfig.Host == utils.DbId {
+	if dbConfig.Host == utils.DbId && !isContainerExcluded(utils.Config.Db.Image, excluded) {
 		if err := start.StartDatabase(ctx, fsys, w, options...); err != nil {
 			return err
 		}
@@ -293,7 +295,7 @@ EOF
 		}); err != nil {
 			return errors.Errorf("failed to exec template: %w", err)
 		}
-		var binds, env []string
+		var binds, env, securityOpts []string
 		// Special case for GitLab pipeline
 		parsed, err := client.ParseHostURL(utils.Docker.DaemonHost())
 		if err != nil {
@@ -311,6 +313,12 @@ EOF
 			fmt.Fprintln(os.Stderr, utils.Yellow("WARNING:"), "analytics requires docker daemon exposed on tcp://localhost:2375")
 			env = append(env, "DOCKER_HOST="+dindHost.String())
 		case "unix":
+			if utils.IsPodman() {
+				// Vector connects to the default docker socket inside its container
+				binds = append(binds, parsed.Host+":/var/run/docker.sock:ro")
+				securityOpts = append(securityOpts, "label:disable")
+				break
+			}
 			if parsed, err = client.ParseHostURL(client.DefaultDockerHost); err != nil {
 				return errors.Errorf("failed to parse default host: %w", err)
 			}
@@ -339,6 +347,7 @@ EOF
 			},
 			container.HostConfig{
 				Binds:         binds,
+				SecurityOpt:   securityOpts,
 				RestartPolicy: container.RestartPolicy{Name: "always"},
 			},
 			network.NetworkingConfig{
@@ -433,6 +442,12 @@ EOF
 					"8443/tcp": {},
 					nat.Port(fmt.Sprintf("%d/tcp", nginxTemplateServerPort)): {},
 				},
+				Healthcheck: &container.HealthConfig{
+					Test:     []string{"CMD", "kong", "health"},
+					Interval: 10 * time.Second,
+					Timeout:  2 * time.Second,
+					Retries:  3,
+				},
 			},
 			container.HostConfig{
 				Binds: binds,
@@ -837,6 +852,11 @@ EOF
 	// Start Storage.
 	if isStorageEnabled {
 		dockerStoragePath := "/mnt"
+		// Storage is only published on the host when a port is configured
+		var storagePortBindings nat.PortMap
+		if utils.Config.Storage.Port > 0 {
+			storagePortBindings = nat.PortMap{"5000/tcp": []nat.PortBinding{{HostPort: strconv.FormatUint(uint64(utils.Config.Storage.Port), 10)}}}
+		}
 		if _, err := utils.DockerStart(
 			ctx,
 			container.Config{
@@ -877,6 +897,7 @@ EOF
 			container.HostConfig{
 				RestartPolicy: container.RestartPolicy{Name: "always"},
 				Binds:         []string{utils.StorageId + ":" + dockerStoragePath},
+				PortBindings:  storagePortBindings,
 			},
 			network.NetworkingConfig{
 				EndpointsConfig: map[string]*network.EndpointSettings{
@@ -931,7 +952,7 @@ EOF
 	// Start all functions.
 	if utils.Config.EdgeRuntime.Enabled && !isContainerExcluded(utils.Config.EdgeRuntime.Image, excluded) {
 		dbUrl := fmt.Sprintf("postgresql://%s:%s@%s:%d/%s", dbConfig.User, dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.Database)
-		if err := serve.ServeFunctions(ctx, "", nil, "", dbUrl, serve.RuntimeOption{}, fsys); err != nil {
+		if err := serve.ServeFunctions(ctx, nil, nil, "", dbUrl, serve.RuntimeOption{}, fsys); err != nil {
 			return err
 		}
 		started = append(started, utils.EdgeRuntimeId)
@@ -1100,7 +1121,7 @@ EOF
 
 	p.Send(utils.StatusMsg("Waiting for health checks..."))
 	if utils.NoBackupVolume && utils.SliceContains(started, utils.StorageId) {
-		if err := start.WaitForHealthyService(ctx, serviceTimeout, utils.StorageId); err != nil {
+		if err := start.WaitForHealthyService(ctx, ServiceTimeout, utils.StorageId); err != nil {
 			return err
 		}
 		// Disable prompts when seeding
@@ -1108,7 +1129,7 @@ EOF
 			return err
 		}
 	}
-	return start.WaitForHealthyService(ctx, serviceTimeout, started...)
+	return start.WaitForHealthyService(ctx, ServiceTimeout, started...)
 }
 
 func isContainerExcluded(imageName string, excluded map[string]bool) bool {
@@ -11,6 +11,8 @@ import (
 	"net/url"
 	"os"
 	"reflect"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -35,6 +37,12 @@ type CustomName struct {
 	StorageS3AccessKeyId     string `env:"storage.s3_access_key_id,default=S3_PROTOCOL_ACCESS_KEY_ID"`
 	StorageS3SecretAccessKey string `env:"storage.s3_secret_access_key,default=S3_PROTOCOL_ACCESS_KEY_SECRET"`
 	StorageS3Region          string `env:"storage.s3_region,default=S3_PROTOCOL_REGION"`
+	FunctionsURL             string `env:"functions.url,default=FUNCTIONS_URL"`
+	ApiPort                  string `env:"api.port,default=API_PORT"`
+	DbPort                   string `env:"db.port,default=DB_PORT"`
+	StudioPort               string `env:"studio.port,default=STUDIO_PORT"`
+	InbucketPort             string `env:"inbucket.port,default=INBUCKET_PORT"`
+	StoppedServices          string `env:"status.stopped_services,default=STOPPED_SERVICES"`
 }
 
 func (c *CustomName) toValues(exclude ...string) map[string]string {
@@ -62,6 +70,9 @@ func (c *CustomName) toValues(exclude ...string) map[string]string {
 		values[c.StorageS3SecretAccessKey] = utils.Config.Storage.S3Credentials.SecretAccessKey
 		values[c.StorageS3Region] = utils.Config.Storage.S3Credentials.Region
 	}
+	if utils.Config.EdgeRuntime.Enabled && !utils.SliceContains(exclude, utils.EdgeRuntimeId) && !utils.SliceContains(exclude, utils.ShortContainerImageName(utils.Config.EdgeRuntime.Image)) {
+		values[c.FunctionsURL] = utils.GetApiUrl("/functions/v1")
+	}
 	return values
 }
 
@@ -80,6 +91,15 @@ func Run(ctx context.Context, names CustomName, format string, fsys afero.Fs) er
 	if len(stopped) > 0 {
 		fmt.Fprintln(os.Stderr, "Stopped services:", stopped)
 	}
+	if utils.Config.EdgeRuntime.Enabled && !utils.SliceContains(stopped, utils.EdgeRuntimeId) {
+		// Functions URL is only reported once the relay is serving requests
+		if health, err := GetFunctionsHealth(ctx); err != nil {
+			fmt.Fprintln(os.Stderr, "Edge Functions runtime is not ready:", err)
+			stopped = append(stopped, utils.EdgeRuntimeId)
+		} else {
+			fmt.Fprintf(os.Stderr, "Serving %d Edge Functions: %s\n", len(health.Functions), strings.Join(health.Functions, ", "))
+		}
+	}
 	if format == utils.OutputPretty {
 		fmt.Fprintf(os.Stderr, "%s local development setup is running.\n\n", utils.Aqua("supabase"))
 		PrettyPrint(os.Stdout, stopped...)
@@ -113,6 +133,8 @@ func checkServiceHealth(ctx context.Context) ([]string, error) {
 func assertContainerHealthy(ctx context.Context, container string) error {
 	if resp, err := utils.Docker.ContainerInspect(ctx, container); err != nil {
 		return errors.Errorf("failed to inspect container health: %w", err)
+	} else if resp.State.Restarting {
+		return errors.Errorf("%s container is restarting: exit %d", container, resp.State.ExitCode)
 	} else if !resp.State.Running {
 		return errors.Errorf("%s container is not running: %s", container, resp.State.Status)
 	} else if resp.State.Health != nil && resp.State.Health.Status != types.Healthy {
@@ -133,6 +155,23 @@ func IsServiceReady(ctx context.Context, container string) error {
 	return assertContainerHealthy(ctx, container)
 }
 
+type FunctionsHealth struct {
+	Status    string   `json:"status"`
+	Functions []string `json:"functions"`
+	DenoCache struct {
+		Path   string `json:"path"`
+		Cached bool   `json:"cached"`
+	} `json:"denoCache"`
+}
+
+func GetFunctionsHealth(ctx context.Context) (FunctionsHealth, error) {
+	resp, err := getHealthClient().Send(ctx, http.MethodGet, "/functions/v1/_internal/health", nil)
+	if err != nil {
+		return FunctionsHealth{}, err
+	}
+	return fetcher.ParseJSON[FunctionsHealth](resp.Body)
+}
+
 var (
 	//go:embed kong.local.crt
 	KongCert string
@@ -173,7 +212,7 @@ var (
 	healthOnce   sync.Once
 )
 
-func checkHTTPHead(ctx context.Context, path string) error {
+func getHealthClient() *fetcher.Fetcher {
 	healthOnce.Do(func() {
 		server := utils.Config.Api.ExternalUrl
 		header := func(req *http.Request) {
@@ -187,8 +226,12 @@ func checkHTTPHead(ctx context.Context, path string) error {
 			fetcher.WithExpectedStatus(http.StatusOK),
 		)
 	})
+	return healthClient
+}
+
+func checkHTTPHead(ctx context.Context, path string) error {
 	// HEAD method does not return response body
-	resp, err := healthClient.Send(ctx, http.MethodHead, path, nil)
+	resp, err := getHealthClient().Send(ctx, http.MethodHead, path, nil)
 	if err != nil {
 		return err
 	}
@@ -198,6 +241,25 @@ func checkHTTPHead(ctx context.Context, path string) error {
 
 func printStatus(names CustomName, format string, w io.Writer, exclude ...string) (err error) {
 	values := names.toValues(exclude...)
+	// Ports and health are only reported in machine readable formats
+	set := func(name string, value uint16) {
+		if len(name) > 0 {
+			values[name] = strconv.FormatUint(uint64(value), 10)
+		}
+	}
+	set(names.DbPort, utils.Config.Db.Port)
+	if _, ok := values[names.ApiURL]; ok {
+		set(names.ApiPort, utils.Config.Api.Port)
+	}
+	if _, ok := values[names.StudioURL]; ok {
+		set(names.StudioPort, utils.Config.Studio.Port)
+	}
+	if _, ok := values[names.InbucketURL]; ok {
+		set(names.InbucketPort, utils.Config.Inbucket.Port)
+	}
+	if len(names.StoppedServices) > 0 {
+		values[names.StoppedServices] = strings.Join(exclude, ",")
+	}
 	return utils.EncodeOutput(format, w, values)
 }
 
@@ -215,6 +277,7 @@ func PrettyPrint(w io.Writer, exclude ...string) {
 		StorageS3AccessKeyId:     "   " + utils.Aqua("S3 Access Key"),
 		StorageS3SecretAccessKey: "   " + utils.Aqua("S3 Secret Key"),
 		StorageS3Region:          "       " + utils.Aqua("S3 Region"),
+		FunctionsURL:             "   " + utils.Aqua("Functions URL"),
 	}
 	values := names.toValues(exclude...)
 	// Iterate through map in order of declared struct fields
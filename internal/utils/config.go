@@ -55,6 +55,9 @@ var (
 	InitialSchemaPg14Sql string
 )
 
+// Deno cache is shared across projects so that remote modules are only downloaded once.
+const DenoCacheVolume = "supabase_deno_cache"
+
 func GetId(name string) string {
 	return "supabase_" + name + "_" + Config.ProjectId
 }
@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -140,6 +141,21 @@ func ConnectByConfig(ctx context.Context, config pgconn.Config, options ...func(
 	return ConnectByConfigStream(ctx, config, os.Stderr, options...)
 }
 
+// Returns the major version of the connected server, or 0 if it cannot be parsed.
+func GetServerMajorVersion(conn *pgx.Conn) uint {
+	serverVersion := conn.PgConn().ParameterStatus("server_version")
+	// Safe to assume that supported Postgres version is 10.0 <= n < 100.0
+	majorDigits := len(serverVersion)
+	if majorDigits > 2 {
+		majorDigits = 2
+	}
+	dbMajorVersion, err := strconv.ParseUint(serverVersion[:majorDigits], 10, 7)
+	if err != nil {
+		return 0
+	}
+	return uint(dbMajorVersion)
+}
+
 func IsLocalDatabase(config pgconn.Config) bool {
 	return config.Host == Config.Hostname && config.Port == Config.Db.Port
 }
@@ -130,6 +130,31 @@ func TestConnectLocal(t *testing.T) {
 	})
 }
 
+func TestServerMajorVersion(t *testing.T) {
+	t.Run("parses major version", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		// Run test
+		version := GetServerMajorVersion(conn.MockClient(t))
+		// Check output
+		assert.Equal(t, uint(14), version)
+	})
+
+	t.Run("returns zero on invalid version", func(t *testing.T) {
+		// Setup mock postgres
+		conn := pgtest.NewWithStatus(map[string]string{
+			"standard_conforming_strings": "on",
+			"server_version":              "beta",
+		})
+		defer conn.Close(t)
+		// Run test
+		version := GetServerMajorVersion(conn.MockClient(t))
+		// Check output
+		assert.Zero(t, version)
+	})
+}
+
 func TestPoolerConfig(t *testing.T) {
 	t.Run("parses options ref", func(t *testing.T) {
 		Config.Db.Pooler.ConnectionString = PG13_POOLER_URL
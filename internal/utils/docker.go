@@ -10,6 +10,7 @@ import (
 	"log"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -17,6 +18,7 @@ import (
 	podman "github.com/containers/common/libnetwork/types"
 	"github.com/docker/cli/cli/command"
 	"github.com/docker/cli/cli/compose/loader"
+	"github.com/docker/cli/cli/compose/types"
 	dockerConfig "github.com/docker/cli/cli/config"
 	dockerFlags "github.com/docker/cli/cli/flags"
 	"github.com/docker/cli/cli/streams"
@@ -51,9 +53,66 @@ func NewDocker() *client.Client {
 	if err := cli.Initialize(&dockerFlags.ClientOptions{}); err != nil {
 		log.Fatalln("Failed to initialize Docker client:", err)
 	}
+	if host := findPodmanHost(cli); len(host) > 0 {
+		podmanClient, err := client.NewClientWithOpts(client.WithHost(host), client.WithAPIVersionNegotiation())
+		if err != nil {
+			log.Fatalln("Failed to create Podman client:", err)
+		}
+		return podmanClient
+	}
 	return cli.Client().(*client.Client)
 }
 
+// Falls back to podman socket only if the default docker socket is not installed.
+func findPodmanHost(cli *command.DockerCli) string {
+	if len(os.Getenv(client.EnvOverrideHost)) > 0 || cli.CurrentContext() != "default" {
+		return ""
+	}
+	if cli.DockerEndpoint().Host != client.DefaultDockerHost {
+		return ""
+	}
+	if parsed, err := client.ParseHostURL(client.DefaultDockerHost); err != nil || parsed.Scheme != "unix" {
+		return ""
+	} else if _, err := os.Stat(parsed.Host); !errors.Is(err, os.ErrNotExist) {
+		return ""
+	}
+	for _, host := range podmanHosts() {
+		if parsed, err := client.ParseHostURL(host); err == nil {
+			if _, err := os.Stat(parsed.Host); err == nil {
+				return host
+			}
+		}
+	}
+	return ""
+}
+
+// Podman serves a docker compatible API from its own socket path, ie. /run/podman/podman.sock
+func IsPodman() bool {
+	return strings.Contains(Docker.DaemonHost(), "podman")
+}
+
+// Adds a shared SELinux label to host paths so that rootless containers can read them.
+func relabelBinds(binds []string) []string {
+	result := make([]string, len(binds))
+	for i, bind := range binds {
+		result[i] = bind
+		spec, err := loader.ParseVolume(bind)
+		if err != nil || spec.Type != string(mount.TypeBind) {
+			continue
+		}
+		// Relabelling a socket changes access for the host daemon
+		if strings.HasSuffix(spec.Source, ".sock") {
+			continue
+		}
+		if strings.Count(bind, ":") < 2 {
+			result[i] += ":z"
+		} else if opts := bind[strings.LastIndex(bind, ":")+1:]; !strings.ContainsAny(opts, "zZ") {
+			result[i] += ",z"
+		}
+	}
+	return result
+}
+
 const (
 	DinDHost            = "host.docker.internal"
 	CliProjectLabel     = "com.supabase.cli.project"
@@ -265,7 +324,12 @@ func DockerStart(ctx context.Context, config container.Config, hostConfig contai
 	config.Labels[CliProjectLabel] = Config.ProjectId
 	config.Labels[composeProjectLabel] = Config.ProjectId
 	// Configure container network
-	hostConfig.ExtraHosts = append(hostConfig.ExtraHosts, extraHosts...)
+	// Podman resolves host.docker.internal natively while older versions reject host-gateway
+	if IsPodman() {
+		hostConfig.Binds = relabelBinds(hostConfig.Binds)
+	} else {
+		hostConfig.ExtraHosts = append(hostConfig.ExtraHosts, extraHosts...)
+	}
 	if networkId := viper.GetString("network-id"); len(networkId) > 0 {
 		hostConfig.NetworkMode = container.NetworkMode(networkId)
 	} else if len(hostConfig.NetworkMode) == 0 {
@@ -274,6 +338,14 @@ func DockerStart(ctx context.Context, config container.Config, hostConfig contai
 	if err := DockerNetworkCreateIfNotExists(ctx, hostConfig.NetworkMode, config.Labels); err != nil {
 		return "", err
 	}
+	// Remote daemons cannot see local paths so we copy them into the container instead
+	var copies []types.ServiceVolumeConfig
+	if IsRemoteDocker() {
+		var err error
+		if hostConfig.Binds, copies, err = splitHostBinds(hostConfig.Binds); err != nil {
+			return "", err
+		}
+	}
 	// Configure container volumes
 	var binds, sources []string
 	for _, bind := range hostConfig.Binds {
@@ -309,6 +381,9 @@ func DockerStart(ctx context.Context, config container.Config, hostConfig contai
 	if err != nil {
 		return "", errors.Errorf("failed to create docker container: %w", err)
 	}
+	if err := copyToContainer(ctx, resp.ID, copies); err != nil {
+		return resp.ID, err
+	}
 	// Run container in background
 	err = Docker.ContainerStart(ctx, resp.ID, container.StartOptions{})
 	if err != nil {
@@ -373,7 +448,24 @@ func DockerRunOnceWithConfig(ctx context.Context, config container.Config, hostC
 		return err
 	}
 	defer DockerRemove(container)
-	return DockerStreamLogs(ctx, container, stdout, stderr)
+	err = DockerStreamLogs(ctx, container, stdout, stderr)
+	if IsRemoteDocker() {
+		// Copy back partial output even when the container failed
+		if copyErr := copyBinds(ctx, container, hostConfig.Binds); err == nil {
+			err = copyErr
+		} else if copyErr != nil {
+			fmt.Fprintln(os.Stderr, copyErr)
+		}
+	}
+	return err
+}
+
+func copyBinds(ctx context.Context, containerId string, binds []string) error {
+	_, copies, err := splitHostBinds(binds)
+	if err != nil {
+		return err
+	}
+	return copyFromContainer(ctx, containerId, copies)
 }
 
 func DockerStreamLogs(ctx context.Context, containerId string, stdout, stderr io.Writer) error {
@@ -416,6 +508,23 @@ func DockerStreamLogsOnce(ctx context.Context, containerId string, stdout, stder
 	return nil
 }
 
+// Copies only the last n lines of container logs, which is sufficient for debugging crashes.
+func DockerStreamLogsTail(ctx context.Context, containerId string, n uint, stdout, stderr io.Writer) error {
+	logs, err := Docker.ContainerLogs(ctx, containerId, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Tail:       strconv.FormatUint(uint64(n), 10),
+	})
+	if err != nil {
+		return errors.Errorf("failed to read docker logs: %w", err)
+	}
+	defer logs.Close()
+	if _, err := stdcopy.StdCopy(stdout, stderr, logs); err != nil {
+		return errors.Errorf("failed to copy docker logs: %w", err)
+	}
+	return nil
+}
+
 // Exec a command once inside a container, returning stdout and throwing error on non-zero exit code.
 func DockerExecOnce(ctx context.Context, containerId string, env []string, cmd []string) (string, error) {
 	stderr := io.Discard
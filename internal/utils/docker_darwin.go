@@ -9,3 +9,8 @@ var extraHosts []string
 func isUserDefined(mode container.NetworkMode) bool {
 	return mode.IsUserDefined()
 }
+
+// Podman machine exposes the default docker socket when podman-mac-helper is installed.
+func podmanHosts() []string {
+	return nil
+}
@@ -2,7 +2,12 @@
 
 package utils
 
-import "github.com/docker/docker/api/types/container"
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/docker/docker/api/types/container"
+)
 
 // Allows containers to resolve host network: https://stackoverflow.com/a/62431165
 var extraHosts = []string{DinDHost + ":host-gateway"}
@@ -10,3 +15,12 @@ var extraHosts = []string{DinDHost + ":host-gateway"}
 func isUserDefined(mode container.NetworkMode) bool {
 	return mode.IsUserDefined()
 }
+
+// Rootless podman listens on a per user socket, otherwise falls back to the rootful socket.
+func podmanHosts() []string {
+	var hosts []string
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); len(dir) > 0 {
+		hosts = append(hosts, "unix://"+filepath.Join(dir, "podman", "podman.sock"))
+	}
+	return append(hosts, "unix:///run/podman/podman.sock")
+}
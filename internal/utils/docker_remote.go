@@ -0,0 +1,218 @@
+package utils
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/docker/cli/cli/compose/loader"
+	"github.com/docker/cli/cli/compose/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/client"
+	"github.com/go-errors/errors"
+	"github.com/spf13/viper"
+)
+
+var (
+	// Only resolved once per command invocation
+	remoteOnce   sync.Once
+	remoteDocker bool
+)
+
+// Bind mounts of local paths are not visible to a docker daemon running on another machine.
+// Set SUPABASE_DOCKER_REMOTE=true when the daemon does not share our filesystem, ie. in devcontainers.
+func IsRemoteDocker() bool {
+	remoteOnce.Do(func() {
+		remoteDocker = viper.GetBool("DOCKER_REMOTE") || isRemoteHost(Docker.DaemonHost())
+	})
+	return remoteDocker
+}
+
+func isRemoteHost(host string) bool {
+	parsed, err := client.ParseHostURL(host)
+	if err != nil {
+		return false
+	}
+	switch parsed.Scheme {
+	case "ssh":
+		return true
+	case "tcp", "http", "https":
+		host, _, err := net.SplitHostPort(parsed.Host)
+		if err != nil {
+			host = parsed.Host
+		}
+		if host == "localhost" {
+			return false
+		}
+		ip := net.ParseIP(host)
+		return ip == nil || !ip.IsLoopback()
+	}
+	return false
+}
+
+// Separates bind mounts of host paths from named volumes, which remote daemons can still mount.
+func splitHostBinds(binds []string) ([]string, []types.ServiceVolumeConfig, error) {
+	var remain []string
+	var copies []types.ServiceVolumeConfig
+	for _, bind := range binds {
+		spec, err := loader.ParseVolume(bind)
+		if err != nil {
+			return nil, nil, errors.Errorf("failed to parse docker volume: %w", err)
+		}
+		// Sockets cannot be copied and must be exposed on the remote host instead
+		if spec.Type != string(mount.TypeBind) || strings.HasSuffix(spec.Source, ".sock") {
+			remain = append(remain, bind)
+		} else {
+			copies = append(copies, spec)
+		}
+	}
+	return remain, copies, nil
+}
+
+// Streams host paths as a tar archive into a created container before it is started.
+func copyToContainer(ctx context.Context, containerId string, copies []types.ServiceVolumeConfig) error {
+	if len(copies) == 0 {
+		return nil
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeBindsTar(copies, pw))
+	}()
+	if err := Docker.CopyToContainer(ctx, containerId, "/", pr, container.CopyToContainerOptions{}); err != nil {
+		_ = pr.CloseWithError(err)
+		return errors.Errorf("failed to copy files to container: %w", err)
+	}
+	return nil
+}
+
+func writeBindsTar(copies []types.ServiceVolumeConfig, w io.Writer) error {
+	tw := tar.NewWriter(w)
+	for _, spec := range copies {
+		if err := addToTar(tw, spec.Source, spec.Target); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return errors.Errorf("failed to close tar: %w", err)
+	}
+	return nil
+}
+
+func addToTar(tw *tar.Writer, source, target string) error {
+	if _, err := os.Stat(source); errors.Is(err, os.ErrNotExist) {
+		// Docker creates an empty directory for missing bind sources
+		return nil
+	}
+	return filepath.WalkDir(source, func(hostPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return errors.Errorf("failed to walk %s: %w", hostPath, err)
+		}
+		info, err := d.Info()
+		if err != nil {
+			return errors.Errorf("failed to stat %s: %w", hostPath, err)
+		}
+		var link string
+		if d.Type()&fs.ModeSymlink != 0 {
+			if link, err = os.Readlink(hostPath); err != nil {
+				return errors.Errorf("failed to read link: %w", err)
+			}
+		}
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return errors.Errorf("failed to create tar header: %w", err)
+		}
+		rel, err := filepath.Rel(source, hostPath)
+		if err != nil {
+			return errors.Errorf("failed to resolve relative path: %w", err)
+		}
+		header.Name = strings.TrimPrefix(path.Join(target, filepath.ToSlash(rel)), "/")
+		if d.IsDir() {
+			header.Name += "/"
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return errors.Errorf("failed to write tar header: %w", err)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(hostPath)
+		if err != nil {
+			return errors.Errorf("failed to open file: %w", err)
+		}
+		defer f.Close()
+		if _, err := io.Copy(tw, f); err != nil {
+			return errors.Errorf("failed to write tar: %w", err)
+		}
+		return nil
+	})
+}
+
+// Writable bind mounts are copied back to the host after a one-off container exits.
+func copyFromContainer(ctx context.Context, containerId string, copies []types.ServiceVolumeConfig) error {
+	for _, spec := range copies {
+		if spec.ReadOnly {
+			continue
+		}
+		rc, _, err := Docker.CopyFromContainer(ctx, containerId, spec.Target)
+		if err != nil {
+			return errors.Errorf("failed to copy files from container: %w", err)
+		}
+		err = extractTar(rc, spec.Source)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Docker archives the requested path under its base name, which maps to the bind source.
+func extractTar(r io.Reader, source string) error {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return errors.Errorf("failed to read tar: %w", err)
+		}
+		_, rel, _ := strings.Cut(strings.TrimPrefix(header.Name, "/"), "/")
+		hostPath := filepath.Join(source, filepath.FromSlash(rel))
+		if relPath, err := filepath.Rel(source, hostPath); err != nil || strings.HasPrefix(relPath, "..") {
+			return errors.Errorf("invalid path in tar: %s", header.Name)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(hostPath, 0755); err != nil {
+				return errors.Errorf("failed to create directory: %w", err)
+			}
+		case tar.TypeReg:
+			if err := writeTarFile(tr, hostPath, header.FileInfo().Mode()); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func writeTarFile(r io.Reader, hostPath string, mode fs.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(hostPath), 0755); err != nil {
+		return errors.Errorf("failed to create directory: %w", err)
+	}
+	f, err := os.OpenFile(hostPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return errors.Errorf("failed to open file: %w", err)
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, r); err != nil {
+		return errors.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package utils
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/cli/cli/compose/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitHostBinds(t *testing.T) {
+	t.Run("copies host paths only", func(t *testing.T) {
+		binds := []string{
+			"supabase_edge_runtime_test:/root/.cache/deno:rw",
+			"/home/user/supabase/functions:/home/deno/functions:ro",
+			"/var/run/docker.sock:/var/run/docker.sock:ro",
+		}
+		// Run test
+		remain, copies, err := splitHostBinds(binds)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{binds[0], binds[2]}, remain)
+		require.Len(t, copies, 1)
+		assert.Equal(t, "/home/user/supabase/functions", copies[0].Source)
+		assert.Equal(t, "/home/deno/functions", copies[0].Target)
+		assert.True(t, copies[0].ReadOnly)
+	})
+
+	t.Run("throws error on invalid bind", func(t *testing.T) {
+		remain, copies, err := splitHostBinds([]string{""})
+		// Check error
+		assert.ErrorContains(t, err, "failed to parse docker volume:")
+		assert.Empty(t, remain)
+		assert.Empty(t, copies)
+	})
+}
+
+func TestCopyBinds(t *testing.T) {
+	t.Run("round trips directory through tar", func(t *testing.T) {
+		// Setup host directory
+		source := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(source, "hello"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(source, "hello", "index.ts"), []byte("serve()"), 0644))
+		// Docker archives the path under its base name, same as a top level target
+		copies := []types.ServiceVolumeConfig{{Source: source, Target: "/functions"}}
+		// Run test
+		var archive bytes.Buffer
+		require.NoError(t, writeBindsTar(copies, &archive))
+		output := t.TempDir()
+		require.NoError(t, extractTar(&archive, output))
+		// Check result
+		data, err := os.ReadFile(filepath.Join(output, "hello", "index.ts"))
+		assert.NoError(t, err)
+		assert.Equal(t, "serve()", string(data))
+	})
+
+	t.Run("skips missing source", func(t *testing.T) {
+		copies := []types.ServiceVolumeConfig{{Source: filepath.Join(t.TempDir(), "missing"), Target: "/tmp"}}
+		// Run test
+		var archive bytes.Buffer
+		err := writeBindsTar(copies, &archive)
+		// Check error
+		assert.NoError(t, err)
+	})
+}
+
+func TestIsRemoteHost(t *testing.T) {
+	for host, expected := range map[string]bool{
+		"unix:///var/run/docker.sock": false,
+		"tcp://127.0.0.1:2375":        false,
+		"tcp://localhost:2375":        false,
+		"tcp://10.0.0.5:2376":         true,
+		"ssh://user@remote":           true,
+		"not a url":                   false,
+	} {
+		assert.Equal(t, expected, isRemoteHost(host), host)
+	}
+}
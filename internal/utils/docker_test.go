@@ -303,3 +303,24 @@ func TestExecOnce(t *testing.T) {
 
 	// TODO: mock tcp hijack
 }
+
+func TestRelabelBinds(t *testing.T) {
+	binds := []string{
+		"/home/user/supabase/functions:/home/deno/functions:ro",
+		"/home/user/.env:/root/.env",
+		"/home/user/cache:/root/cache:ro,Z",
+		"/run/user/1000/podman/podman.sock:/var/run/docker.sock:ro",
+		"supabase_db_test:/var/lib/postgresql/data",
+	}
+	// Run test
+	result := relabelBinds(binds)
+	// Check result
+	assert.Equal(t, []string{
+		"/home/user/supabase/functions:/home/deno/functions:ro,z",
+		"/home/user/.env:/root/.env:z",
+		"/home/user/cache:/root/cache:ro,Z",
+		"/run/user/1000/podman/podman.sock:/var/run/docker.sock:ro",
+		"supabase_db_test:/var/lib/postgresql/data",
+	}, result)
+	assert.Equal(t, "/home/user/.env:/root/.env", binds[1])
+}
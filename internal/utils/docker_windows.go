@@ -13,3 +13,8 @@ func isUserDefined(mode container.NetworkMode) bool {
 	// Host network requires explicit check on windows: https://github.com/supabase/cli/pull/952
 	return mode.IsUserDefined() && mode.UserDefined() != network.NetworkHost
 }
+
+// Podman machine exposes the default docker pipe on windows.
+func podmanHosts() []string {
+	return nil
+}
@@ -134,6 +134,7 @@ var (
 	CurrBranchPath        = filepath.Join(SupabaseDirPath, ".branches", "_current_branch")
 	SchemasDir            = filepath.Join(SupabaseDirPath, "schemas")
 	MigrationsDir         = filepath.Join(SupabaseDirPath, "migrations")
+	MigrationsArchiveDir  = filepath.Join(SupabaseDirPath, "migrations_archive")
 	FunctionsDir          = filepath.Join(SupabaseDirPath, "functions")
 	FallbackImportMapPath = filepath.Join(FunctionsDir, "import_map.json")
 	FallbackEnvFilePath   = filepath.Join(FunctionsDir, ".env")
@@ -144,6 +145,8 @@ var (
 	ErrInvalidRef  = errors.New("Invalid project ref format. Must be like `abcdefghijklmnopqrst`.")
 	ErrInvalidSlug = errors.New("Invalid Function name. Must start with at least one letter, and only include alphanumeric characters, underscores, and hyphens. (^[A-Za-z][A-Za-z0-9_-]*$)")
 	ErrNotRunning  = errors.Errorf("%s is not running.", Aqua("supabase start"))
+	// Services may be excluded by supabase start -x or services.disabled in config
+	ErrServiceNotRunning = errors.Errorf("container is not running. Make sure %s is running without excluding the service via %s flag or %s in %s.", Aqua("supabase start"), Aqua("-x"), Aqua("services.disabled"), Bold(ConfigPath))
 )
 
 func GetCurrentTimestamp() string {
@@ -167,6 +170,9 @@ func AssertSupabaseDbIsRunning() error {
 func AssertServiceIsRunning(ctx context.Context, containerId string) error {
 	if _, err := Docker.ContainerInspect(ctx, containerId); err != nil {
 		if client.IsErrNotFound(err) {
+			if containerId != DbId {
+				return errors.Errorf("%s %w", containerId, ErrServiceNotRunning)
+			}
 			return errors.New(ErrNotRunning)
 		}
 		if client.IsErrConnectionFailed(err) {
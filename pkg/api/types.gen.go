@@ -5,6 +5,7 @@ package api
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/oapi-codegen/runtime"
 )
@@ -1567,6 +1568,12 @@ type UpgradeDatabaseBody struct {
 	TargetVersion  string         `json:"target_version"`
 }
 
+// V1AnalyticsResponse defines model for V1AnalyticsResponse.
+type V1AnalyticsResponse struct {
+	Error  *string                   `json:"error,omitempty"`
+	Result *[]map[string]interface{} `json:"result,omitempty"`
+}
+
 // V1Backup defines model for V1Backup.
 type V1Backup struct {
 	InsertedAt       string         `json:"inserted_at"`
@@ -1850,6 +1857,13 @@ type V1AuthorizeUserParamsResponseType string
 // V1AuthorizeUserParamsCodeChallengeMethod defines parameters for V1AuthorizeUser.
 type V1AuthorizeUserParamsCodeChallengeMethod string
 
+// V1GetProjectLogsParams defines parameters for V1GetProjectLogs.
+type V1GetProjectLogsParams struct {
+	Sql               *string    `form:"sql,omitempty" json:"sql,omitempty"`
+	IsoTimestampStart *time.Time `form:"iso_timestamp_start,omitempty" json:"iso_timestamp_start,omitempty"`
+	IsoTimestampEnd   *time.Time `form:"iso_timestamp_end,omitempty" json:"iso_timestamp_end,omitempty"`
+}
+
 // V1GetProjectApiKeysParams defines parameters for V1GetProjectApiKeys.
 type V1GetProjectApiKeysParams struct {
 	Reveal bool `form:"reveal" json:"reveal"`
@@ -10,6 +10,7 @@ import (
 	"io"
 	"io/fs"
 	"maps"
+	"math"
 	"net"
 	"net/http"
 	"net/url"
@@ -130,9 +131,14 @@ type (
 		EdgeRuntime  edgeRuntime    `toml:"edge_runtime"`
 		Functions    FunctionConfig `toml:"functions"`
 		Analytics    analytics      `toml:"analytics"`
+		Services     services       `toml:"services"`
 		Experimental experimental   `toml:"experimental"`
 	}
 
+	services struct {
+		Disabled []string `toml:"disabled"`
+	}
+
 	config struct {
 		baseConfig `mapstructure:",squash"`
 		Overrides  map[string]interface{} `toml:"remotes"`
@@ -169,19 +175,34 @@ type (
 	}
 
 	edgeRuntime struct {
-		Enabled       bool          `toml:"enabled"`
-		Image         string        `toml:"-"`
-		Policy        RequestPolicy `toml:"policy"`
-		InspectorPort uint16        `toml:"inspector_port"`
+		Enabled        bool          `toml:"enabled"`
+		Image          string        `toml:"-"`
+		Policy         RequestPolicy `toml:"policy"`
+		InspectorPort  uint16        `toml:"inspector_port"`
+		TlsPort        uint16        `toml:"tls_port"`
+		RequestTimeout time.Duration `toml:"request_timeout"`
+		DenoArgs       []string      `toml:"deno_args"`
+		Cors           cors          `toml:"cors"`
+	}
+
+	cors struct {
+		Enabled        bool     `toml:"enabled" json:"-"`
+		AllowedOrigins []string `toml:"allowed_origins" json:"allowedOrigins"`
+		AllowedHeaders []string `toml:"allowed_headers" json:"allowedHeaders"`
 	}
 
 	FunctionConfig map[string]function
 
 	function struct {
-		Enabled    *bool  `toml:"enabled" json:"-"`
-		VerifyJWT  *bool  `toml:"verify_jwt" json:"verifyJWT"`
-		ImportMap  string `toml:"import_map" json:"importMapPath,omitempty"`
-		Entrypoint string `toml:"entrypoint" json:"entrypointPath,omitempty"`
+		Enabled     *bool    `toml:"enabled" json:"-"`
+		VerifyJWT   *bool    `toml:"verify_jwt" json:"verifyJWT"`
+		ImportMap   string   `toml:"import_map" json:"importMapPath,omitempty"`
+		Entrypoint  string   `toml:"entrypoint" json:"entrypointPath,omitempty"`
+		Path        string   `toml:"path" json:"-"`
+		StaticFiles []string `toml:"static_files" json:"-"`
+		Schedule    string   `toml:"schedule" json:"-"`
+		Memory      uint     `toml:"memory,omitzero" json:"memoryLimitMb,omitempty"`
+		Timeout     uint     `toml:"timeout,omitzero" json:"timeoutSec,omitempty"`
 	}
 
 	analytics struct {
@@ -509,8 +530,16 @@ func (c *config) Load(path string, fsys fs.FS) error {
 	}
 	// Resolve functions config
 	for slug, function := range c.Functions {
+		// Function source may live outside supabase/functions, ie. in a monorepo package
+		if len(function.Path) > 0 && !filepath.IsAbs(function.Path) {
+			function.Path = filepath.Join(builder.SupabaseDirPath, function.Path)
+		}
 		if len(function.Entrypoint) == 0 {
-			function.Entrypoint = filepath.Join(builder.FunctionsDir, slug, "index.ts")
+			functionDir := function.Path
+			if len(functionDir) == 0 {
+				functionDir = filepath.Join(builder.FunctionsDir, slug)
+			}
+			function.Entrypoint = filepath.Join(functionDir, "index.ts")
 		} else if !filepath.IsAbs(function.Entrypoint) {
 			// Append supabase/ because paths in configs are specified relative to config.toml
 			function.Entrypoint = filepath.Join(builder.SupabaseDirPath, function.Entrypoint)
@@ -528,11 +557,23 @@ func (c *config) Load(path string, fsys fs.FS) error {
 		} else if !filepath.IsAbs(function.ImportMap) {
 			function.ImportMap = filepath.Join(builder.SupabaseDirPath, function.ImportMap)
 		}
+		for i, staticFile := range function.StaticFiles {
+			if !filepath.IsAbs(staticFile) {
+				function.StaticFiles[i] = filepath.Join(builder.SupabaseDirPath, staticFile)
+			}
+		}
 		c.Functions[slug] = function
 	}
 	if err := c.Db.Seed.loadSeedPaths(builder.SupabaseDirPath, fsys); err != nil {
 		return err
 	}
+	for _, hooks := range [][]string{c.Db.Reset.Before, c.Db.Reset.After} {
+		for i, path := range hooks {
+			if !filepath.IsAbs(path) {
+				hooks[i] = filepath.Join(builder.SupabaseDirPath, path)
+			}
+		}
+	}
 	if err := c.baseConfig.Validate(fsys); err != nil {
 		return err
 	}
@@ -576,6 +617,13 @@ func (c *baseConfig) Validate(fsys fs.FS) error {
 		fmt.Fprintln(os.Stderr, "WARN: project_id field in config is invalid. Auto-fixing to", sanitized)
 		c.ProjectId = sanitized
 	}
+	// Validate services config
+	allowedServices := serviceNames()
+	for _, name := range c.Services.Disabled {
+		if !sliceContains(allowedServices, name) {
+			return errors.Errorf("Invalid config for services.disabled: %s. Must be one of: %v", name, allowedServices)
+		}
+	}
 	// Validate api config
 	if c.Api.Enabled {
 		if c.Api.Port == 0 {
@@ -619,7 +667,21 @@ func (c *baseConfig) Validate(fsys fs.FS) error {
 			}
 		}
 	default:
-		return errors.Errorf("Failed reading config: Invalid %s: %v.", "db.major_version", c.Db.MajorVersion)
+		return errors.Errorf("Failed reading config: Invalid %s: %v. Supported versions are 13, 14 and 15.", "db.major_version", c.Db.MajorVersion)
+	}
+	allowedKinds := []string{"extension", "schema", "table", "view", "function", "type"}
+	for _, rule := range c.Db.Diff.Exclude {
+		if kind, pattern, _ := strings.Cut(rule, ":"); !sliceContains(allowedKinds, kind) || len(pattern) == 0 {
+			return errors.Errorf("Invalid config for db.diff.exclude: %s. Must be of the form <kind>:<pattern>, where kind is one of: %v", rule, allowedKinds)
+		} else if _, err := path.Match(pattern, ""); err != nil {
+			return errors.Errorf("Invalid config for db.diff.exclude: %s. %w", rule, err)
+		}
+	}
+	allowedSeverities := []string{"error", "warn", "off"}
+	for rule, severity := range c.Db.Lint.Rules {
+		if !sliceContains(allowedSeverities, severity) {
+			return errors.Errorf("Invalid config for db.lint.rules.%s. Must be one of: %v", rule, allowedSeverities)
+		}
 	}
 	// Validate pooler config
 	if c.Db.Pooler.Enabled {
@@ -702,11 +764,23 @@ func (c *baseConfig) Validate(fsys fs.FS) error {
 		if !sliceContains(allowed, c.EdgeRuntime.Policy) {
 			return errors.Errorf("Invalid config for edge_runtime.policy. Must be one of: %v", allowed)
 		}
+		if c.EdgeRuntime.Cors.Enabled && len(c.EdgeRuntime.Cors.AllowedOrigins) == 0 {
+			return errors.New("Missing required field in config: edge_runtime.cors.allowed_origins")
+		}
 	}
-	for name := range c.Functions {
+	for name, function := range c.Functions {
 		if err := ValidateFunctionSlug(name); err != nil {
 			return err
 		}
+		if len(function.Schedule) > 0 && !cronSchedulePattern.MatchString(function.Schedule) {
+			return errors.Errorf("Invalid config for functions.%s.schedule: %s. Must be a cron expression with 5 fields or an interval like '30 seconds'.", name, function.Schedule)
+		}
+		if function.Memory > 0 && (function.Memory < DefaultFunctionMemoryMb || function.Memory > MaxFunctionMemoryMb) {
+			return errors.Errorf("Invalid config for functions.%s.memory: %d. Must be between %d and %d MB.", name, function.Memory, DefaultFunctionMemoryMb, MaxFunctionMemoryMb)
+		}
+		if function.Timeout > MaxFunctionTimeoutSec {
+			return errors.Errorf("Invalid config for functions.%s.timeout: %d. Must be at most %d seconds.", name, function.Timeout, MaxFunctionTimeoutSec)
+		}
 	}
 	// Validate logflare config
 	if c.Analytics.Enabled {
@@ -731,6 +805,39 @@ func (c *baseConfig) Validate(fsys fs.FS) error {
 	if err := c.Experimental.validate(); err != nil {
 		return err
 	}
+	return c.validatePorts()
+}
+
+// Host ports must be unique so that multiple services can be published at the same time.
+func (c *baseConfig) validatePorts() error {
+	ports := []struct {
+		name    string
+		port    uint16
+		enabled bool
+	}{
+		{"api.port", c.Api.Port, c.Api.Enabled},
+		{"db.port", c.Db.Port, true},
+		{"db.shadow_port", c.Db.ShadowPort, true},
+		{"db.pooler.port", c.Db.Pooler.Port, c.Db.Pooler.Enabled},
+		{"studio.port", c.Studio.Port, c.Studio.Enabled},
+		{"inbucket.port", c.Inbucket.Port, c.Inbucket.Enabled},
+		{"inbucket.smtp_port", c.Inbucket.SmtpPort, c.Inbucket.Enabled},
+		{"inbucket.pop3_port", c.Inbucket.Pop3Port, c.Inbucket.Enabled},
+		{"edge_runtime.inspector_port", c.EdgeRuntime.InspectorPort, c.EdgeRuntime.Enabled},
+		{"edge_runtime.tls_port", c.EdgeRuntime.TlsPort, c.EdgeRuntime.Enabled},
+		{"analytics.port", c.Analytics.Port, c.Analytics.Enabled},
+		{"storage.port", c.Storage.Port, c.Storage.Enabled},
+	}
+	used := map[uint16]string{}
+	for _, p := range ports {
+		if !p.enabled || p.port == 0 {
+			continue
+		}
+		if name, ok := used[p.port]; ok {
+			return errors.Errorf("Invalid config for %s: port %d is already used by %s", p.name, p.port, name)
+		}
+		used[p.port] = p.name
+	}
 	return nil
 }
 
@@ -825,9 +932,42 @@ func (c *seed) loadSeedPaths(basePath string, fsys fs.FS) error {
 			}
 		}
 	}
+	// Numbered files in the seed directory are applied after sql_paths
+	seedDir := path.Join(basePath, "seed")
+	entries, err := fs.ReadDir(fsys, seedDir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Errorf("failed to read seed directory: %w", err)
+	}
+	var numbered []string
+	for _, e := range entries {
+		if ext := path.Ext(e.Name()); !e.IsDir() && (ext == ".sql" || ext == ".csv" || ext == ".json") {
+			numbered = append(numbered, e.Name())
+		}
+	}
+	sort.SliceStable(numbered, func(i, j int) bool {
+		return seedOrder(numbered[i]) < seedOrder(numbered[j])
+	})
+	for _, name := range numbered {
+		item := path.Join(seedDir, name)
+		if _, exists := set[item]; !exists {
+			set[item] = struct{}{}
+			c.SqlPaths = append(c.SqlPaths, item)
+		}
+	}
 	return nil
 }
 
+// Sorts by leading number so that 2_users.sql comes before 10_posts.sql.
+func seedOrder(name string) int {
+	digits := strings.IndexFunc(name, func(r rune) bool {
+		return r < '0' || r > '9'
+	})
+	if n, err := strconv.Atoi(name[:max(digits, 0)]); err == nil {
+		return n
+	}
+	return math.MaxInt
+}
+
 func (e *email) validate(fsys fs.FS) (err error) {
 	for name, tmpl := range e.Template {
 		if len(tmpl.ContentPath) == 0 {
@@ -1039,6 +1179,25 @@ func ValidateFunctionSlug(slug string) error {
 	return nil
 }
 
+// Ref: https://github.com/citusdata/pg_cron#what-is-pg_cron
+var cronSchedulePattern = regexp.MustCompile(`^([1-5]?[0-9] seconds|[0-9A-Za-z*,/\-]+( [0-9A-Za-z*,/\-]+){4})$`)
+
+const (
+	// Ref: https://supabase.com/docs/guides/functions/limits
+	DefaultFunctionMemoryMb = 256
+	MaxFunctionMemoryMb     = 4 * DefaultFunctionMemoryMb
+	MaxFunctionTimeoutSec   = 400
+)
+
+// Deployed functions are scaled in multiples of the default memory limit.
+func (f function) ComputeMultiplier() *float32 {
+	if f.Memory == 0 {
+		return nil
+	}
+	multiplier := float32(f.Memory) / DefaultFunctionMemoryMb
+	return &multiplier
+}
+
 // Ref: https://github.com/supabase/storage/blob/master/src/storage/limits.ts#L59
 var bucketNamePattern = regexp.MustCompile(`^(\w|!|-|\.|\*|'|\(|\)| |&|\$|@|=|;|:|\+|,|\?)*$`)
 
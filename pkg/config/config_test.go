@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 	fs "testing/fstest"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"github.com/stretchr/testify/assert"
@@ -283,6 +284,33 @@ func TestValidateHookURI(t *testing.T) {
 }
 
 func TestLoadSeedPaths(t *testing.T) {
+	t.Run("appends numbered files from seed directory", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := fs.MapFS{
+			"supabase/seed.sql":              &fs.MapFile{Data: []byte("INSERT INTO table1 VALUES (1);")},
+			"supabase/seed/10_posts.sql":     &fs.MapFile{Data: []byte("INSERT INTO posts VALUES (1);")},
+			"supabase/seed/2_countries.csv":  &fs.MapFile{Data: []byte("id,name\n1,NZ\n")},
+			"supabase/seed/1_users.sql":      &fs.MapFile{Data: []byte("INSERT INTO users VALUES (1);")},
+			"supabase/seed/manifest.toml":    &fs.MapFile{Data: []byte(`[files]`)},
+			"supabase/seed/nested/3_tmp.sql": &fs.MapFile{},
+		}
+		// Mock config patterns
+		config := seed{
+			Enabled:      true,
+			GlobPatterns: []string{"seed.sql"},
+		}
+		// Run test
+		err := config.loadSeedPaths("supabase", fsys)
+		// Check error
+		assert.NoError(t, err)
+		// Validate files
+		assert.Equal(t, []string{
+			"supabase/seed.sql",
+			"supabase/seed/1_users.sql",
+			"supabase/seed/2_countries.csv",
+			"supabase/seed/10_posts.sql",
+		}, config.SqlPaths)
+	})
 	t.Run("returns seed files matching patterns", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := fs.MapFS{
@@ -431,3 +459,316 @@ func TestLoadFunctionImportMap(t *testing.T) {
 		assert.Equal(t, "supabase/custom_import_map.json", config.Functions["hello"].ImportMap)
 	})
 }
+
+func TestLoadFunctionStaticFiles(t *testing.T) {
+	config := NewConfig()
+	fsys := fs.MapFS{
+		"supabase/config.toml": &fs.MapFile{Data: []byte(`
+		project_id = "test"
+		[functions.hello]
+		static_files = ["./assets", "/tmp/templates"]
+		`)},
+	}
+	// Run test
+	assert.NoError(t, config.Load("", fsys))
+	// Check that relative paths are resolved from supabase directory
+	assert.Equal(t, []string{"supabase/assets", "/tmp/templates"}, config.Functions["hello"].StaticFiles)
+}
+
+func TestLoadEdgeRuntimeCors(t *testing.T) {
+	t.Run("loads cors config", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "test"
+			[edge_runtime.cors]
+			enabled = true
+			allowed_origins = ["http://localhost:3000"]
+			`)},
+		}
+		// Run test
+		assert.NoError(t, config.Load("", fsys))
+		// Check cors config
+		assert.Equal(t, []string{"http://localhost:3000"}, config.EdgeRuntime.Cors.AllowedOrigins)
+	})
+
+	t.Run("throws error on missing origins", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "test"
+			[edge_runtime.cors]
+			enabled = true
+			`)},
+		}
+		// Run test
+		err := config.Load("", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Missing required field in config: edge_runtime.cors.allowed_origins")
+	})
+}
+
+func TestLoadEdgeRuntimeRequestTimeout(t *testing.T) {
+	config := NewConfig()
+	fsys := fs.MapFS{
+		"supabase/config.toml": &fs.MapFile{Data: []byte(`
+		project_id = "test"
+		[edge_runtime]
+		request_timeout = "30s"
+		`)},
+	}
+	// Run test
+	assert.NoError(t, config.Load("", fsys))
+	// Check timeout
+	assert.Equal(t, 30*time.Second, config.EdgeRuntime.RequestTimeout)
+}
+
+func TestLoadFunctionSchedule(t *testing.T) {
+	t.Run("loads cron schedule", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "test"
+			[functions.cleanup]
+			schedule = "*/5 * * * *"
+			`)},
+		}
+		// Run test
+		assert.NoError(t, config.Load("", fsys))
+		// Check schedule
+		assert.Equal(t, "*/5 * * * *", config.Functions["cleanup"].Schedule)
+	})
+
+	t.Run("throws error on invalid schedule", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "test"
+			[functions.cleanup]
+			schedule = "every 5 minutes'"
+			`)},
+		}
+		// Run test
+		err := config.Load("", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for functions.cleanup.schedule")
+	})
+}
+
+func TestLoadFunctionResources(t *testing.T) {
+	t.Run("loads memory and timeout", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "test"
+			[functions.hello]
+			memory = 512
+			timeout = 60
+			`)},
+		}
+		// Run test
+		assert.NoError(t, config.Load("", fsys))
+		// Check resources
+		assert.Equal(t, uint(512), config.Functions["hello"].Memory)
+		assert.Equal(t, uint(60), config.Functions["hello"].Timeout)
+		assert.Equal(t, float32(2), *config.Functions["hello"].ComputeMultiplier())
+	})
+
+	t.Run("throws error on invalid memory", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "test"
+			[functions.hello]
+			memory = 2048
+			`)},
+		}
+		// Run test
+		err := config.Load("", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for functions.hello.memory: 2048. Must be between 256 and 1024 MB.")
+	})
+
+	t.Run("throws error on invalid timeout", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "test"
+			[functions.hello]
+			timeout = 600
+			`)},
+		}
+		// Run test
+		err := config.Load("", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for functions.hello.timeout: 600. Must be at most 400 seconds.")
+	})
+}
+
+func TestLoadFunctionPath(t *testing.T) {
+	t.Run("resolves entrypoint from path", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "test"
+			[functions.foo]
+			path = "../packages/edge/foo"
+			`)},
+			"packages/edge/foo/deno.json": &fs.MapFile{Data: []byte("{}")},
+		}
+		// Run test
+		assert.NoError(t, config.Load("", fsys))
+		// Check paths
+		function := config.Functions["foo"]
+		assert.Equal(t, "packages/edge/foo", function.Path)
+		assert.Equal(t, "packages/edge/foo/index.ts", function.Entrypoint)
+		assert.Equal(t, "packages/edge/foo/deno.json", function.ImportMap)
+	})
+
+	t.Run("entrypoint takes precedence over path", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "test"
+			[functions.foo]
+			path = "../packages/edge/foo"
+			entrypoint = "../packages/edge/foo/main.ts"
+			`)},
+		}
+		// Run test
+		assert.NoError(t, config.Load("", fsys))
+		// Check paths
+		assert.Equal(t, "packages/edge/foo/main.ts", config.Functions["foo"].Entrypoint)
+	})
+}
+
+func TestLoadDiffExclude(t *testing.T) {
+	t.Run("loads exclusion rules", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "test"
+			[db.diff]
+			exclude = ["extension:pg_stat_statements", "table:audit.*"]
+			`)},
+		}
+		// Run test
+		assert.NoError(t, config.Load("", fsys))
+		// Check rules
+		assert.Equal(t, []string{"extension:pg_stat_statements", "table:audit.*"}, config.Db.Diff.Exclude)
+	})
+
+	t.Run("throws error on unknown kind", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "test"
+			[db.diff]
+			exclude = ["index:public.*"]
+			`)},
+		}
+		// Run test
+		err := config.Load("", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for db.diff.exclude: index:public.*")
+	})
+}
+
+func TestLoadMajorVersion(t *testing.T) {
+	t.Run("selects image by major version", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "test"
+			[db]
+			major_version = 14
+			`)},
+		}
+		// Run test
+		assert.NoError(t, config.Load("", fsys))
+		// Check image
+		assert.Equal(t, pg14Image, config.Db.Image)
+	})
+
+	t.Run("throws error on unsupported version", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "test"
+			[db]
+			major_version = 16
+			`)},
+		}
+		// Run test
+		err := config.Load("", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid db.major_version: 16. Supported versions are 13, 14 and 15.")
+	})
+}
+
+func TestLoadDisabledServices(t *testing.T) {
+	t.Run("loads disabled services", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "test"
+			[services]
+			disabled = ["studio", "imgproxy", "inbucket"]
+			`)},
+		}
+		// Run test
+		assert.NoError(t, config.Load("", fsys))
+		// Check services
+		assert.Equal(t, []string{"studio", "imgproxy", "inbucket"}, config.Services.Disabled)
+	})
+
+	t.Run("throws error on unknown service", func(t *testing.T) {
+		config := NewConfig()
+		fsys := fs.MapFS{
+			"supabase/config.toml": &fs.MapFile{Data: []byte(`
+			project_id = "test"
+			[services]
+			disabled = ["postgres"]
+			`)},
+		}
+		// Run test
+		err := config.Load("", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "Invalid config for services.disabled: postgres.")
+	})
+}
+
+func TestValidatePorts(t *testing.T) {
+	config := NewConfig()
+	fsys := fs.MapFS{
+		"supabase/config.toml": &fs.MapFile{Data: []byte(`
+		project_id = "test"
+		[db]
+		port = 54322
+		[studio]
+		enabled = true
+		port = 54322
+		`)},
+	}
+	// Run test
+	err := config.Load("", fsys)
+	// Check error
+	assert.ErrorContains(t, err, "Invalid config for studio.port: port 54322 is already used by db.port")
+}
+
+func TestLoadResetHooks(t *testing.T) {
+	config := NewConfig()
+	fsys := fs.MapFS{
+		"supabase/config.toml": &fs.MapFile{Data: []byte(`
+		project_id = "test"
+		[db.reset]
+		before = ["./scripts/pre.sql"]
+		after = ["/tmp/post-seed.sh"]
+		`)},
+	}
+	// Run test
+	assert.NoError(t, config.Load("", fsys))
+	// Check hooks
+	assert.Equal(t, []string{"supabase/scripts/pre.sql"}, config.Db.Reset.Before)
+	assert.Equal(t, []string{"/tmp/post-seed.sh"}, config.Db.Reset.After)
+}
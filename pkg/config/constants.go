@@ -1,5 +1,7 @@
 package config
 
+import "strings"
+
 const (
 	pg13Image = "supabase/postgres:13.3.0"
 	pg14Image = "supabase/postgres:14.1.0.89"
@@ -41,6 +43,16 @@ var ServiceImages = []string{
 	supavisorImage,
 }
 
+// Short names of service images, ie. supabase/studio:latest becomes studio.
+func serviceNames() []string {
+	var result []string
+	for _, image := range ServiceImages {
+		name, _, _ := strings.Cut(image[strings.LastIndex(image, "/")+1:], ":")
+		result = append(result, name)
+	}
+	return result
+}
+
 var JobImages = []string{
 	DifferImage,
 	MigraImage,
@@ -53,12 +53,30 @@ type (
 		Image        string   `toml:"-"`
 		Port         uint16   `toml:"port"`
 		ShadowPort   uint16   `toml:"shadow_port"`
+		ShadowCache  bool     `toml:"shadow_cache"`
 		MajorVersion uint     `toml:"major_version"`
 		Password     string   `toml:"-"`
 		RootKey      string   `toml:"-" mapstructure:"root_key"`
 		Pooler       pooler   `toml:"pooler"`
 		Seed         seed     `toml:"seed"`
 		Settings     settings `toml:"settings"`
+		Extensions   []string `toml:"extensions,omitempty"`
+		Diff         dbDiff   `toml:"diff"`
+		Reset        dbReset  `toml:"reset"`
+		Lint         dbLint   `toml:"lint"`
+	}
+
+	dbLint struct {
+		Rules map[string]string `toml:"rules,omitempty"`
+	}
+
+	dbReset struct {
+		Before []string `toml:"before,omitempty"`
+		After  []string `toml:"after,omitempty"`
+	}
+
+	dbDiff struct {
+		Exclude []string `toml:"exclude,omitempty"`
 	}
 
 	seed struct {
@@ -0,0 +1,166 @@
+package function
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"sort"
+
+	"github.com/andybalholm/brotli"
+	"github.com/docker/go-units"
+	"github.com/go-errors/errors"
+)
+
+// Platform limit on the size of a deployed eszip bundle.
+const MaxBundleSize = 20 * units.MB
+
+type ModuleSize struct {
+	Specifier string
+	Size      int
+}
+
+const (
+	eszipEntryModule   = 0
+	eszipEntryRedirect = 1
+	eszipEntryNpm      = 2
+)
+
+// Lists the source size of every module in an eszip bundle, largest first. Both
+// compressed bundles and raw eszip v2 archives are accepted.
+func AnalyzeEszip(r io.Reader) ([]ModuleSize, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(len(compressedEszipMagicID)); err == nil && string(magic) == compressedEszipMagicID {
+		if _, err := br.Discard(len(magic)); err != nil {
+			return nil, errors.Errorf("failed to read eszip: %w", err)
+		}
+		br = bufio.NewReader(brotli.NewReader(br))
+	}
+	magic := make([]byte, 8)
+	if _, err := io.ReadFull(br, magic); err != nil {
+		return nil, errors.Errorf("failed to read eszip magic: %w", err)
+	}
+	checksumSize := 32
+	switch string(magic) {
+	case "ESZIP_V2", "ESZIP2.1":
+	case "ESZIP2.2", "ESZIP2.3":
+		options, err := readSection(br, 0)
+		if err != nil {
+			return nil, err
+		}
+		if checksumSize, err = parseChecksumSize(options); err != nil {
+			return nil, err
+		}
+		if _, err := br.Discard(checksumSize); err != nil {
+			return nil, errors.Errorf("failed to read eszip options: %w", err)
+		}
+	default:
+		return nil, errors.Errorf("unsupported eszip version: %q", magic)
+	}
+	header, err := readSection(br, checksumSize)
+	if err != nil {
+		return nil, err
+	}
+	return parseModulesHeader(header)
+}
+
+// Reads a length prefixed section followed by its checksum.
+func readSection(r io.Reader, checksumSize int) ([]byte, error) {
+	var size uint32
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		return nil, errors.Errorf("failed to read eszip section: %w", err)
+	}
+	// Avoid allocating the declared size upfront in case the header is corrupt
+	content, err := io.ReadAll(io.LimitReader(r, int64(size)+int64(checksumSize)))
+	if err != nil {
+		return nil, errors.Errorf("failed to read eszip section: %w", err)
+	} else if len(content) < int(size)+checksumSize {
+		return nil, errors.New("failed to read eszip section: unexpected EOF")
+	}
+	return content[:size], nil
+}
+
+func parseChecksumSize(options []byte) (int, error) {
+	if len(options)%2 != 0 {
+		return 0, errors.New("invalid eszip options header")
+	}
+	size := -1
+	checksum := byte(1)
+	for i := 0; i < len(options); i += 2 {
+		switch options[i] {
+		case 0:
+			checksum = options[i+1]
+		case 1:
+			size = int(options[i+1])
+		}
+	}
+	if size >= 0 {
+		return size, nil
+	}
+	switch checksum {
+	case 0:
+		return 0, nil
+	case 1:
+		return 32, nil
+	case 2:
+		return 8, nil
+	}
+	return 0, errors.Errorf("unsupported eszip checksum: %d", checksum)
+}
+
+func parseModulesHeader(header []byte) ([]ModuleSize, error) {
+	r := bytes.NewReader(header)
+	var result []ModuleSize
+	for r.Len() > 0 {
+		specifier, err := readString(r)
+		if err != nil {
+			return nil, err
+		}
+		kind, err := r.ReadByte()
+		if err != nil {
+			return nil, errors.Errorf("failed to read module kind: %w", err)
+		}
+		switch kind {
+		case eszipEntryModule:
+			// Source offset, source length, source map offset, source map length, module kind
+			var fields [4]uint32
+			if err := binary.Read(r, binary.BigEndian, &fields); err != nil {
+				return nil, errors.Errorf("failed to read module entry: %w", err)
+			}
+			if _, err := r.ReadByte(); err != nil {
+				return nil, errors.Errorf("failed to read module entry: %w", err)
+			}
+			result = append(result, ModuleSize{Specifier: specifier, Size: int(fields[1])})
+		case eszipEntryRedirect:
+			if _, err := readString(r); err != nil {
+				return nil, err
+			}
+		case eszipEntryNpm:
+			var id uint32
+			if err := binary.Read(r, binary.BigEndian, &id); err != nil {
+				return nil, errors.Errorf("failed to read npm entry: %w", err)
+			}
+		default:
+			return nil, errors.Errorf("unsupported eszip entry: %d", kind)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Size > result[j].Size
+	})
+	return result, nil
+}
+
+func readString(r *bytes.Reader) (string, error) {
+	var size uint32
+	if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+		return "", errors.Errorf("failed to read specifier: %w", err)
+	}
+	if int(size) > r.Len() {
+		return "", errors.New("invalid eszip specifier length")
+	}
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return "", errors.Errorf("failed to read specifier: %w", err)
+	}
+	return string(buf), nil
+}
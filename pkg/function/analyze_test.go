@@ -0,0 +1,92 @@
+package function
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeString(buf *bytes.Buffer, value string) {
+	_ = binary.Write(buf, binary.BigEndian, uint32(len(value)))
+	buf.WriteString(value)
+}
+
+func writeSection(buf *bytes.Buffer, content []byte, checksumSize int) {
+	_ = binary.Write(buf, binary.BigEndian, uint32(len(content)))
+	buf.Write(content)
+	buf.Write(make([]byte, checksumSize))
+}
+
+func mockModulesHeader() []byte {
+	var header bytes.Buffer
+	writeString(&header, "file:///src/index.ts")
+	header.WriteByte(eszipEntryModule)
+	_ = binary.Write(&header, binary.BigEndian, [4]uint32{0, 120, 120, 0})
+	header.WriteByte(0)
+	writeString(&header, "https://deno.land/std/http/mod.ts")
+	header.WriteByte(eszipEntryRedirect)
+	writeString(&header, "https://deno.land/std@0.224.0/http/mod.ts")
+	writeString(&header, "npm:zod")
+	header.WriteByte(eszipEntryNpm)
+	_ = binary.Write(&header, binary.BigEndian, uint32(1))
+	writeString(&header, "https://deno.land/std@0.224.0/http/mod.ts")
+	header.WriteByte(eszipEntryModule)
+	_ = binary.Write(&header, binary.BigEndian, [4]uint32{120, 4096, 4216, 0})
+	header.WriteByte(0)
+	return header.Bytes()
+}
+
+func TestAnalyzeEszip(t *testing.T) {
+	expected := []ModuleSize{
+		{Specifier: "https://deno.land/std@0.224.0/http/mod.ts", Size: 4096},
+		{Specifier: "file:///src/index.ts", Size: 120},
+	}
+
+	t.Run("parses compressed v2 bundle", func(t *testing.T) {
+		var eszip bytes.Buffer
+		eszip.WriteString("ESZIP_V2")
+		writeSection(&eszip, mockModulesHeader(), 32)
+		var body bytes.Buffer
+		require.NoError(t, Compress(&eszip, &body))
+		// Run test
+		modules, err := AnalyzeEszip(&body)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, expected, modules)
+	})
+
+	t.Run("parses options header", func(t *testing.T) {
+		var eszip bytes.Buffer
+		eszip.WriteString("ESZIP2.2")
+		// Use xxhash3 checksum
+		writeSection(&eszip, []byte{0, 2}, 8)
+		writeSection(&eszip, mockModulesHeader(), 8)
+		// Run test
+		modules, err := AnalyzeEszip(&eszip)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, expected, modules)
+	})
+
+	t.Run("throws error on unknown version", func(t *testing.T) {
+		// Run test
+		modules, err := AnalyzeEszip(bytes.NewReader([]byte("ESZIP_V1")))
+		// Check error
+		assert.ErrorContains(t, err, "unsupported eszip version")
+		assert.Empty(t, modules)
+	})
+
+	t.Run("throws error on truncated header", func(t *testing.T) {
+		var eszip bytes.Buffer
+		eszip.WriteString("ESZIP2.1")
+		writeSection(&eszip, mockModulesHeader()[:30], 32)
+		// Run test
+		modules, err := AnalyzeEszip(&eszip)
+		// Check error
+		assert.Error(t, err)
+		assert.Empty(t, modules)
+	})
+}
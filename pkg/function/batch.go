@@ -4,10 +4,14 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/cenkalti/backoff/v4"
 	"github.com/docker/go-units"
@@ -22,18 +26,11 @@ const (
 )
 
 func (s *EdgeRuntimeAPI) UpsertFunctions(ctx context.Context, functionConfig config.FunctionConfig, filter ...func(string) bool) error {
-	var result []api.FunctionResponse
-	if resp, err := s.client.V1ListAllFunctionsWithResponse(ctx, s.project); err != nil {
-		return errors.Errorf("failed to list functions: %w", err)
-	} else if resp.JSON200 == nil {
-		return errors.Errorf("unexpected status %d: %s", resp.StatusCode(), string(resp.Body))
-	} else {
-		result = *resp.JSON200
-	}
-	exists := make(map[string]struct{}, len(result))
-	for _, f := range result {
-		exists[f.Slug] = struct{}{}
+	exists, err := s.listFunctionSlugs(ctx)
+	if err != nil {
+		return err
 	}
+	var slugs []string
 	for slug, function := range functionConfig {
 		if !function.IsEnabled() {
 			fmt.Fprintln(os.Stderr, "Skipped deploying Function:", slug)
@@ -44,47 +41,159 @@ func (s *EdgeRuntimeAPI) UpsertFunctions(ctx context.Context, functionConfig con
 				continue
 			}
 		}
+		slugs = append(slugs, slug)
+	}
+	// Bundle all functions before uploading so that bundling errors fail fast
+	bundles := make(map[string][]byte, len(slugs))
+	for _, slug := range slugs {
+		function := functionConfig[slug]
 		var body bytes.Buffer
 		if err := s.eszip.Bundle(ctx, function.Entrypoint, function.ImportMap, &body); err != nil {
 			return err
 		}
-		// Update if function already exists
-		upsert := func() error {
-			if _, ok := exists[slug]; ok {
-				if resp, err := s.client.V1UpdateAFunctionWithBodyWithResponse(ctx, s.project, slug, &api.V1UpdateAFunctionParams{
-					VerifyJwt:      function.VerifyJWT,
-					ImportMapPath:  toFileURL(function.ImportMap),
-					EntrypointPath: toFileURL(function.Entrypoint),
-				}, eszipContentType, bytes.NewReader(body.Bytes())); err != nil {
-					return errors.Errorf("failed to update function: %w", err)
-				} else if resp.JSON200 == nil {
-					return errors.Errorf("unexpected status %d: %s", resp.StatusCode(), string(resp.Body))
-				}
-			} else {
-				if resp, err := s.client.V1CreateAFunctionWithBodyWithResponse(ctx, s.project, &api.V1CreateAFunctionParams{
-					Slug:           &slug,
-					Name:           &slug,
-					VerifyJwt:      function.VerifyJWT,
-					ImportMapPath:  toFileURL(function.ImportMap),
-					EntrypointPath: toFileURL(function.Entrypoint),
-				}, eszipContentType, bytes.NewReader(body.Bytes())); err != nil {
-					return errors.Errorf("failed to create function: %w", err)
-				} else if resp.JSON201 == nil {
-					return errors.Errorf("unexpected status %d: %s", resp.StatusCode(), string(resp.Body))
-				}
-			}
-			return nil
-		}
-		functionSize := units.HumanSize(float64(body.Len()))
-		fmt.Fprintf(os.Stderr, "Deploying Function: %s (script size: %s)\n", slug, functionSize)
-		policy := backoff.WithContext(backoff.WithMaxRetries(backoff.NewExponentialBackOff(), maxRetries), ctx)
-		if err := backoff.Retry(upsert, policy); err != nil {
+		bundles[slug] = body.Bytes()
+	}
+	for _, slug := range slugs {
+		if err := s.uploadFunction(ctx, slug, functionConfig, bundles[slug], exists); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+type UpsertResult struct {
+	Slug string
+	Size int
+	Err  error
+}
+
+// Deploys functions using at most maxJobs concurrent workers. Unlike UpsertFunctions,
+// a failed upload does not stop the remaining functions from being deployed.
+func (s *EdgeRuntimeAPI) UpsertFunctionsParallel(ctx context.Context, functionConfig config.FunctionConfig, maxJobs uint) ([]UpsertResult, error) {
+	exists, err := s.listFunctionSlugs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var slugs []string
+	for slug, function := range functionConfig {
+		if !function.IsEnabled() {
+			fmt.Fprintln(os.Stderr, "Skipped deploying Function:", slug)
+			continue
+		}
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+	results := make([]UpsertResult, len(slugs))
+	// Bundle all functions before uploading so that bundling errors fail fast
+	bundles := make([][]byte, len(slugs))
+	forEachParallel(slugs, maxJobs, func(i int, slug string) {
+		function := functionConfig[slug]
+		var body bytes.Buffer
+		err := s.eszip.Bundle(ctx, function.Entrypoint, function.ImportMap, &body)
+		results[i] = UpsertResult{Slug: slug, Size: body.Len(), Err: err}
+		bundles[i] = body.Bytes()
+	})
+	var failed []string
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, r.Slug)
+		}
+	}
+	if len(failed) > 0 {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Err = errors.Errorf("skipped because bundling failed: %s", strings.Join(failed, ", "))
+			}
+		}
+		return results, nil
+	}
+	forEachParallel(slugs, maxJobs, func(i int, slug string) {
+		results[i].Err = s.uploadFunction(ctx, slug, functionConfig, bundles[i], exists)
+	})
+	return results, nil
+}
+
+func forEachParallel(slugs []string, maxJobs uint, run func(i int, slug string)) {
+	sem := make(chan struct{}, max(maxJobs, 1))
+	var wg sync.WaitGroup
+	for i, slug := range slugs {
+		wg.Add(1)
+		go func(i int, slug string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			run(i, slug)
+		}(i, slug)
+	}
+	wg.Wait()
+}
+
+func (s *EdgeRuntimeAPI) listFunctionSlugs(ctx context.Context) (map[string]struct{}, error) {
+	resp, err := s.client.V1ListAllFunctionsWithResponse(ctx, s.project)
+	if err != nil {
+		return nil, errors.Errorf("failed to list functions: %w", err)
+	} else if resp.JSON200 == nil {
+		return nil, errors.Errorf("unexpected status %d: %s", resp.StatusCode(), string(resp.Body))
+	}
+	exists := make(map[string]struct{}, len(*resp.JSON200))
+	for _, f := range *resp.JSON200 {
+		exists[f.Slug] = struct{}{}
+	}
+	return exists, nil
+}
+
+func (s *EdgeRuntimeAPI) uploadFunction(ctx context.Context, slug string, functionConfig config.FunctionConfig, body []byte, exists map[string]struct{}) error {
+	function := functionConfig[slug]
+	// Update if function already exists
+	upsert := func() error {
+		if _, ok := exists[slug]; ok {
+			if resp, err := s.client.V1UpdateAFunctionWithBodyWithResponse(ctx, s.project, slug, &api.V1UpdateAFunctionParams{
+				VerifyJwt:         function.VerifyJWT,
+				ImportMapPath:     toFileURL(function.ImportMap),
+				EntrypointPath:    toFileURL(function.Entrypoint),
+				ComputeMultiplier: function.ComputeMultiplier(),
+			}, eszipContentType, bytes.NewReader(body)); err != nil {
+				return errors.Errorf("failed to update function: %w", err)
+			} else if resp.JSON200 == nil {
+				return newStatusError(resp.StatusCode(), resp.Body)
+			}
+		} else {
+			if resp, err := s.client.V1CreateAFunctionWithBodyWithResponse(ctx, s.project, &api.V1CreateAFunctionParams{
+				Slug:              &slug,
+				Name:              &slug,
+				VerifyJwt:         function.VerifyJWT,
+				ImportMapPath:     toFileURL(function.ImportMap),
+				EntrypointPath:    toFileURL(function.Entrypoint),
+				ComputeMultiplier: function.ComputeMultiplier(),
+			}, eszipContentType, bytes.NewReader(body)); err != nil {
+				return errors.Errorf("failed to create function: %w", err)
+			} else if resp.JSON201 == nil {
+				return newStatusError(resp.StatusCode(), resp.Body)
+			}
+		}
+		return nil
+	}
+	functionSize := units.HumanSize(float64(len(body)))
+	fmt.Fprintf(os.Stderr, "Deploying Function: %s (script size: %s)\n", slug, functionSize)
+	// The Functions API has no timeout parameter, so the platform default applies
+	if function.Timeout > 0 {
+		fmt.Fprintf(os.Stderr, "WARNING: functions.%s.timeout is only applied when serving locally.\n", slug)
+	}
+	policy := backoff.WithContext(backoff.WithMaxRetries(backoff.NewExponentialBackOff(), maxRetries), ctx)
+	return backoff.RetryNotify(upsert, policy, func(err error, d time.Duration) {
+		fmt.Fprintf(os.Stderr, "Retrying deploy of %s in %s: %v\n", slug, d.Round(time.Millisecond), err)
+	})
+}
+
+// Only network errors, rate limits and server errors are retried.
+func newStatusError(status int, body []byte) error {
+	err := errors.Errorf("unexpected status %d: %s", status, string(body))
+	if status == http.StatusTooManyRequests || status >= http.StatusInternalServerError {
+		return err
+	}
+	return backoff.Permanent(err)
+}
+
 func toFileURL(hostPath string) *string {
 	absHostPath, err := filepath.Abs(hostPath)
 	if err != nil {
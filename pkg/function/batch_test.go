@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/cast"
 	"github.com/supabase/cli/pkg/config"
 )
 
@@ -21,6 +22,17 @@ func (b *MockBundler) Bundle(ctx context.Context, entrypoint string, importMap s
 	return nil
 }
 
+type FailingBundler struct {
+	entrypoint string
+}
+
+func (b *FailingBundler) Bundle(ctx context.Context, entrypoint string, importMap string, output io.Writer) error {
+	if entrypoint == b.entrypoint {
+		return errors.New("bundle failed")
+	}
+	return nil
+}
+
 const (
 	mockApiHost = "https://api.supabase.com"
 	mockProject = "test-project"
@@ -105,3 +117,151 @@ func TestUpsertFunctions(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestUploadComputeMultiplier(t *testing.T) {
+	apiClient, err := api.NewClientWithResponses(mockApiHost)
+	require.NoError(t, err)
+	client := NewEdgeRuntimeAPI(mockProject, *apiClient, &MockBundler{})
+	// Setup mock api
+	defer gock.OffAll()
+	gock.New(mockApiHost).
+		Get("/v1/projects/" + mockProject + "/functions").
+		Reply(http.StatusOK).
+		JSON([]api.FunctionResponse{})
+	gock.New(mockApiHost).
+		Post("/v1/projects/"+mockProject+"/functions").
+		MatchParam("compute_multiplier", "2").
+		Reply(http.StatusCreated).
+		JSON(api.FunctionResponse{Slug: "test"})
+	// Run test
+	err = client.UpsertFunctions(context.Background(), config.FunctionConfig{
+		"test": {Memory: 512},
+	})
+	// Check error
+	assert.NoError(t, err)
+	assert.False(t, gock.HasUnmatchedRequest())
+}
+
+func TestBundleBeforeUpload(t *testing.T) {
+	apiClient, err := api.NewClientWithResponses(mockApiHost)
+	require.NoError(t, err)
+	client := NewEdgeRuntimeAPI(mockProject, *apiClient, &FailingBundler{entrypoint: "broken.ts"})
+	// Setup mock api
+	defer gock.OffAll()
+	gock.New(mockApiHost).
+		Get("/v1/projects/" + mockProject + "/functions").
+		Reply(http.StatusOK).
+		JSON([]api.FunctionResponse{})
+	gock.New(mockApiHost).
+		Post("/v1/projects/" + mockProject + "/functions").
+		Reply(http.StatusCreated).
+		JSON(api.FunctionResponse{Slug: "test"})
+	// Run test
+	err = client.UpsertFunctions(context.Background(), config.FunctionConfig{
+		"test":   {Entrypoint: "test.ts"},
+		"broken": {Entrypoint: "broken.ts"},
+	})
+	// Check error
+	assert.ErrorContains(t, err, "bundle failed")
+	// No function should be uploaded
+	assert.True(t, gock.IsPending())
+}
+
+func TestUpsertFunctionsParallel(t *testing.T) {
+	apiClient, err := api.NewClientWithResponses(mockApiHost)
+	require.NoError(t, err)
+	client := NewEdgeRuntimeAPI(mockProject, *apiClient, &MockBundler{})
+
+	t.Run("reports result of each function", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mockApiHost).
+			Get("/v1/projects/" + mockProject + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{{Slug: "broken"}})
+		gock.New(mockApiHost).
+			Post("/v1/projects/" + mockProject + "/functions").
+			Reply(http.StatusCreated).
+			JSON(api.FunctionResponse{Slug: "test"})
+		gock.New(mockApiHost).
+			Patch("/v1/projects/" + mockProject + "/functions/broken").
+			Reply(http.StatusBadRequest)
+		// Run test
+		results, err := client.UpsertFunctionsParallel(context.Background(), config.FunctionConfig{
+			"test":     {},
+			"broken":   {},
+			"disabled": {Enabled: cast.Ptr(false)},
+		}, 2)
+		// Check error
+		assert.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, "broken", results[0].Slug)
+		assert.ErrorContains(t, results[0].Err, "unexpected status 400:")
+		assert.Equal(t, "test", results[1].Slug)
+		assert.NoError(t, results[1].Err)
+		assert.False(t, gock.HasUnmatchedRequest())
+	})
+
+	t.Run("retries on rate limit", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mockApiHost).
+			Get("/v1/projects/" + mockProject + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{})
+		gock.New(mockApiHost).
+			Post("/v1/projects/" + mockProject + "/functions").
+			Reply(http.StatusTooManyRequests)
+		gock.New(mockApiHost).
+			Post("/v1/projects/" + mockProject + "/functions").
+			Reply(http.StatusCreated).
+			JSON(api.FunctionResponse{Slug: "test"})
+		// Run test
+		results, err := client.UpsertFunctionsParallel(context.Background(), config.FunctionConfig{
+			"test": {},
+		}, 1)
+		// Check error
+		assert.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.NoError(t, results[0].Err)
+		assert.Empty(t, gock.Pending())
+	})
+
+	t.Run("uploads nothing on bundle failure", func(t *testing.T) {
+		client := NewEdgeRuntimeAPI(mockProject, *apiClient, &FailingBundler{entrypoint: "broken.ts"})
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mockApiHost).
+			Get("/v1/projects/" + mockProject + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{})
+		gock.New(mockApiHost).
+			Post("/v1/projects/" + mockProject + "/functions").
+			Reply(http.StatusCreated).
+			JSON(api.FunctionResponse{Slug: "test"})
+		// Run test
+		results, err := client.UpsertFunctionsParallel(context.Background(), config.FunctionConfig{
+			"test":   {Entrypoint: "test.ts"},
+			"broken": {Entrypoint: "broken.ts"},
+		}, 2)
+		// Check error
+		assert.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.ErrorContains(t, results[0].Err, "bundle failed")
+		assert.ErrorContains(t, results[1].Err, "skipped because bundling failed: broken")
+		// No function should be uploaded
+		assert.True(t, gock.IsPending())
+	})
+
+	t.Run("throws error on network failure", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mockApiHost).
+			Get("/v1/projects/" + mockProject + "/functions").
+			ReplyError(errors.New("network error"))
+		// Run test
+		_, err := client.UpsertFunctionsParallel(context.Background(), nil, 1)
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+	})
+}
@@ -0,0 +1,121 @@
+package function
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/go-errors/errors"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/config"
+)
+
+type PlanAction string
+
+const (
+	PlanCreate PlanAction = "CREATE"
+	PlanUpdate PlanAction = "UPDATE"
+	// Deployed source cannot be compared, so functions without setting changes may still differ.
+	PlanUnknown PlanAction = "UNKNOWN"
+)
+
+type PlanResult struct {
+	Slug    string
+	Action  PlanAction
+	Size    int
+	Changes []string
+	Err     error
+}
+
+// Bundles functions locally and compares them against deployed functions
+// without uploading anything.
+func (s *EdgeRuntimeAPI) PlanFunctions(ctx context.Context, functionConfig config.FunctionConfig) ([]PlanResult, error) {
+	resp, err := s.client.V1ListAllFunctionsWithResponse(ctx, s.project)
+	if err != nil {
+		return nil, errors.Errorf("failed to list functions: %w", err)
+	} else if resp.JSON200 == nil {
+		return nil, errors.Errorf("unexpected status %d: %s", resp.StatusCode(), string(resp.Body))
+	}
+	deployed := make(map[string]api.FunctionResponse, len(*resp.JSON200))
+	for _, f := range *resp.JSON200 {
+		deployed[f.Slug] = f
+	}
+	var slugs []string
+	for slug, function := range functionConfig {
+		if !function.IsEnabled() {
+			fmt.Fprintln(os.Stderr, "Skipped deploying Function:", slug)
+			continue
+		}
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+	results := make([]PlanResult, len(slugs))
+	for i, slug := range slugs {
+		results[i] = s.planFunction(ctx, slug, functionConfig, deployed)
+	}
+	return results, nil
+}
+
+func (s *EdgeRuntimeAPI) planFunction(ctx context.Context, slug string, functionConfig config.FunctionConfig, deployed map[string]api.FunctionResponse) PlanResult {
+	result := PlanResult{Slug: slug}
+	function := functionConfig[slug]
+	var body bytes.Buffer
+	if result.Err = s.eszip.Bundle(ctx, function.Entrypoint, function.ImportMap, &body); result.Err != nil {
+		return result
+	}
+	result.Size = body.Len()
+	remote, ok := deployed[slug]
+	if !ok {
+		result.Action = PlanCreate
+		return result
+	}
+	result.Changes = diffFunction(remote, functionConfig, slug)
+	result.Action = PlanUpdate
+	if len(result.Changes) == 0 {
+		result.Action = PlanUnknown
+		result.Changes = []string{"source not compared"}
+	}
+	return result
+}
+
+// Deployed eszip bodies are not comparable with local bundles, so only settings are diffed.
+func diffFunction(remote api.FunctionResponse, functionConfig config.FunctionConfig, slug string) []string {
+	function := functionConfig[slug]
+	var changes []string
+	if !equalPtr(remote.EntrypointPath, toFileURL(function.Entrypoint)) {
+		changes = append(changes, "entrypoint")
+	}
+	if !equalPtr(remote.ImportMapPath, toFileURL(function.ImportMap)) {
+		changes = append(changes, "import_map")
+	}
+	if isVerifyJWT(remote.VerifyJwt) != isVerifyJWT(function.VerifyJWT) {
+		changes = append(changes, "verify_jwt")
+	}
+	// Unset memory is not uploaded, so the deployed multiplier is kept as is.
+	if local := function.ComputeMultiplier(); local != nil && computeMultiplier(remote.ComputeMultiplier) != *local {
+		changes = append(changes, "compute_multiplier")
+	}
+	return changes
+}
+
+func equalPtr(remote, local *string) bool {
+	if remote == nil || local == nil {
+		return remote == local
+	}
+	return *remote == *local
+}
+
+// Functions are deployed with the default memory limit when the multiplier is unspecified.
+func computeMultiplier(value *float32) float32 {
+	if value == nil {
+		return 1
+	}
+	return *value
+}
+
+// Functions verify JWT by default when the setting is unspecified.
+func isVerifyJWT(value *bool) bool {
+	return value == nil || *value
+}
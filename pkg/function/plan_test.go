@@ -0,0 +1,72 @@
+package function
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/supabase/cli/pkg/api"
+	"github.com/supabase/cli/pkg/cast"
+	"github.com/supabase/cli/pkg/config"
+)
+
+func TestPlanFunctions(t *testing.T) {
+	apiClient, err := api.NewClientWithResponses(mockApiHost)
+	require.NoError(t, err)
+	client := NewEdgeRuntimeAPI(mockProject, *apiClient, &MockBundler{})
+
+	t.Run("compares local bundles with deployed functions", func(t *testing.T) {
+		functionConfig := config.FunctionConfig{
+			"new-func":     {Entrypoint: "new-func/index.ts"},
+			"same-func":    {Entrypoint: "same-func/index.ts"},
+			"changed-func": {Entrypoint: "changed-func/index.ts", VerifyJWT: cast.Ptr(false)},
+			"scaled-func":  {Entrypoint: "scaled-func/index.ts", Memory: 512},
+		}
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mockApiHost).
+			Get("/v1/projects/" + mockProject + "/functions").
+			Reply(http.StatusOK).
+			JSON([]api.FunctionResponse{{
+				Slug:           "same-func",
+				EntrypointPath: toFileURL("same-func/index.ts"),
+				ImportMapPath:  toFileURL(""),
+			}, {
+				Slug:           "changed-func",
+				EntrypointPath: toFileURL("changed-func/main.ts"),
+				ImportMapPath:  toFileURL(""),
+			}, {
+				Slug:              "scaled-func",
+				EntrypointPath:    toFileURL("scaled-func/index.ts"),
+				ImportMapPath:     toFileURL(""),
+				ComputeMultiplier: cast.Ptr(float32(1)),
+			}})
+		// Run test
+		results, err := client.PlanFunctions(context.Background(), functionConfig)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []PlanResult{
+			{Slug: "changed-func", Action: PlanUpdate, Changes: []string{"entrypoint", "verify_jwt"}},
+			{Slug: "new-func", Action: PlanCreate},
+			{Slug: "same-func", Action: PlanUnknown, Changes: []string{"source not compared"}},
+			{Slug: "scaled-func", Action: PlanUpdate, Changes: []string{"compute_multiplier"}},
+		}, results)
+		assert.Empty(t, gock.Pending())
+	})
+
+	t.Run("throws error on network failure", func(t *testing.T) {
+		// Setup mock api
+		defer gock.OffAll()
+		gock.New(mockApiHost).
+			Get("/v1/projects/" + mockProject + "/functions").
+			ReplyError(errors.New("network error"))
+		// Run test
+		_, err := client.PlanFunctions(context.Background(), nil)
+		// Check error
+		assert.ErrorContains(t, err, "network error")
+	})
+}
@@ -0,0 +1,84 @@
+package migration
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v4"
+)
+
+var ErrModifiedLocal = errors.New("Local migration files have been modified after they were applied to the remote database.")
+
+// Checksums the parsed statements, which are recorded in the history table when a migration is applied.
+// Statements are normalised first so that edits to comments and indentation are not reported.
+func (m *MigrationFile) Checksum() string {
+	hash := sha256.New()
+	for _, stat := range m.Statements {
+		if norm := normaliseStatement(stat); len(norm) > 0 {
+			hash.Write([]byte(norm))
+			hash.Write([]byte{0})
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Drops comment and blank lines, and trims surrounding whitespace on every other line.
+func normaliseStatement(stat string) string {
+	var lines []string
+	for _, line := range strings.Split(stat, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "--") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Find local migrations whose statements differ from those recorded when they were applied.
+func FindModifiedMigrations(ctx context.Context, localMigrations []string, conn *pgx.Conn, fsys fs.FS) ([]string, error) {
+	remote, err := ReadMigrationTable(ctx, conn)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UndefinedTable {
+			return nil, nil
+		}
+		return nil, err
+	}
+	applied := make(map[string]string, len(remote))
+	for _, r := range remote {
+		// Migrations applied by older versions of the CLI have no statements recorded
+		if len(r.Statements) > 0 {
+			applied[r.Version] = r.Checksum()
+		}
+	}
+	var modified []string
+	for _, path := range localMigrations {
+		matches := migrateFilePattern.FindStringSubmatch(filepath.Base(path))
+		if len(matches) < 2 {
+			continue
+		}
+		checksum, ok := applied[matches[1]]
+		if !ok {
+			continue
+		}
+		file, err := NewMigrationFromFile(path, fsys)
+		if err != nil {
+			return nil, err
+		}
+		if file.Checksum() != checksum {
+			modified = append(modified, path)
+		}
+	}
+	if len(modified) > 0 {
+		return modified, errors.New(ErrModifiedLocal)
+	}
+	return nil, nil
+}
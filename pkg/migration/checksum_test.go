@@ -0,0 +1,26 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecksum(t *testing.T) {
+	t.Run("ignores comments and whitespace", func(t *testing.T) {
+		applied := MigrationFile{Statements: []string{"create table t (\n  id int\n);"}}
+		local := MigrationFile{Statements: []string{
+			"-- add table t\ncreate table t (\n\tid int\n);\n",
+			"-- trailing comment",
+		}}
+		// Check checksum
+		assert.Equal(t, applied.Checksum(), local.Checksum())
+	})
+
+	t.Run("detects modified statements", func(t *testing.T) {
+		applied := MigrationFile{Statements: []string{"create table t (id int);"}}
+		local := MigrationFile{Statements: []string{"create table t (id bigint);"}}
+		// Check checksum
+		assert.NotEqual(t, applied.Checksum(), local.Checksum())
+	})
+}
@@ -0,0 +1,80 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+)
+
+const downSuffix = "_down.sql"
+
+// Returns the path of the optional companion file that reverts the migration at path.
+func DownMigrationPath(path string) string {
+	return strings.TrimSuffix(path, ".sql") + downSuffix
+}
+
+// A file is only treated as a down migration when its up migration sits next to it,
+// so that names like 20240101000000_scale_down.sql are still applied.
+func IsDownMigration(filename string, siblings []string) bool {
+	if !strings.HasSuffix(filename, downSuffix) {
+		return false
+	}
+	up := strings.TrimSuffix(filename, downSuffix) + ".sql"
+	return slices.Contains(siblings, up)
+}
+
+// Reverts migrations in the given order, each in its own transaction with the removal of its version from history.
+func RevertMigrations(ctx context.Context, migrations []string, conn *pgx.Conn, fsys fs.FS) error {
+	// Load all down files upfront so a missing one fails before anything is reverted
+	files := make([]*MigrationFile, len(migrations))
+	for i, path := range migrations {
+		down := DownMigrationPath(path)
+		file, err := NewMigrationFromFile(down, fsys)
+		if errors.Is(err, os.ErrNotExist) {
+			return errors.Errorf("Missing down migration for %s: %s", filepath.Base(path), down)
+		} else if err != nil {
+			return err
+		}
+		files[i] = file
+	}
+	for i, path := range migrations {
+		fmt.Fprintf(os.Stderr, "Reverting migration %s...\n", filepath.Base(path))
+		if err := files[i].execDown(ctx, conn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MigrationFile) execDown(ctx context.Context, conn *pgx.Conn) error {
+	batch := &pgconn.Batch{}
+	for _, line := range m.Statements {
+		batch.ExecParams(line, nil, nil, nil, nil)
+	}
+	// Versions are numeric, so the array literal needs no escaping
+	batch.ExecParams(
+		DELETE_MIGRATION_VERSION,
+		[][]byte{[]byte("{" + m.Version + "}")},
+		[]uint32{pgtype.TextArrayOID},
+		[]int16{pgtype.TextFormatCode},
+		nil,
+	)
+	if result, err := conn.PgConn().ExecBatch(ctx, batch).ReadAll(); err != nil {
+		stat := DELETE_MIGRATION_VERSION
+		i := len(result)
+		if i < len(m.Statements) {
+			stat = m.Statements[i]
+		}
+		return errors.Errorf("%w\nAt statement %d: %s", err, i, stat)
+	}
+	return nil
+}
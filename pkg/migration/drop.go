@@ -4,6 +4,7 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"strings"
 
 	"github.com/go-errors/errors"
 	"github.com/jackc/pgx/v4"
@@ -15,6 +16,8 @@ var (
 	DropObjects string
 	//go:embed queries/list.sql
 	ListSchemas string
+	//go:embed queries/drop_schemas.sql
+	DropSchemaObjects string
 
 	// Initialised by postgres image and owned by postgres role
 	ManagedSchemas = []string{
@@ -54,6 +57,18 @@ func DropUserSchemas(ctx context.Context, conn *pgx.Conn) error {
 	return migration.ExecBatch(ctx, conn)
 }
 
+const SET_RESET_SCHEMAS = "SELECT set_config('supabase.reset_schemas', $1, false)"
+
+// Drops objects in the given schemas and truncates migration history. The public schema
+// itself is kept so that its default grants are preserved.
+func DropSchemas(ctx context.Context, conn *pgx.Conn, schemas []string) error {
+	if _, err := conn.Exec(ctx, SET_RESET_SCHEMAS, strings.Join(schemas, ",")); err != nil {
+		return errors.Errorf("failed to set reset schemas: %w", err)
+	}
+	migration := MigrationFile{Statements: []string{DropSchemaObjects}}
+	return migration.ExecBatch(ctx, conn)
+}
+
 func ListUserSchemas(ctx context.Context, conn *pgx.Conn, exclude ...string) ([]string, error) {
 	if len(exclude) == 0 {
 		exclude = ManagedSchemas
@@ -4,11 +4,19 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"io"
 	"io/fs"
+	"maps"
+	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"strings"
 
+	"github.com/BurntSushi/toml"
 	"github.com/go-errors/errors"
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgtype"
@@ -21,9 +29,14 @@ type MigrationFile struct {
 	Version    string
 	Name       string
 	Statements []string
+	// Runs each statement on its own, for commands that cannot run in a transaction block
+	NoTransaction bool `db:"-"`
 }
 
-var migrateFilePattern = regexp.MustCompile(`^([0-9]+)_(.*)\.sql$`)
+var (
+	migrateFilePattern   = regexp.MustCompile(`^([0-9]+)_(.*)\.sql$`)
+	noTransactionPattern = regexp.MustCompile(`(?im)^\s*--\s*supabase:\s*no-transaction\s*$`)
+)
 
 func NewMigrationFromFile(path string, fsys fs.FS) (*MigrationFile, error) {
 	lines, err := parseFile(path, fsys)
@@ -31,6 +44,10 @@ func NewMigrationFromFile(path string, fsys fs.FS) (*MigrationFile, error) {
 		return nil, err
 	}
 	file := MigrationFile{Statements: lines}
+	// Leading comments are kept with the first statement
+	if len(lines) > 0 {
+		file.NoTransaction = noTransactionPattern.MatchString(lines[0])
+	}
 	// Parse version from file name
 	filename := filepath.Base(path)
 	matches := migrateFilePattern.FindStringSubmatch(filename)
@@ -67,6 +84,9 @@ func NewMigrationFromReader(sql io.Reader) (*MigrationFile, error) {
 }
 
 func (m *MigrationFile) ExecBatch(ctx context.Context, conn *pgx.Conn) error {
+	if m.NoTransaction {
+		return m.execEach(ctx, conn)
+	}
 	// Batch migration commands, without using statement cache
 	batch := &pgconn.Batch{}
 	for _, line := range m.Statements {
@@ -91,6 +111,25 @@ func (m *MigrationFile) ExecBatch(ctx context.Context, conn *pgx.Conn) error {
 	return nil
 }
 
+func (m *MigrationFile) execEach(ctx context.Context, conn *pgx.Conn) error {
+	for i, line := range m.Statements {
+		if _, err := conn.PgConn().ExecParams(ctx, line, nil, nil, nil, nil).Close(); err != nil {
+			return errors.Errorf("%w\nAt statement %d: %s", err, i, line)
+		}
+	}
+	if len(m.Version) == 0 {
+		return nil
+	}
+	batch := &pgconn.Batch{}
+	if err := m.insertVersionSQL(conn, batch); err != nil {
+		return err
+	}
+	if _, err := conn.PgConn().ExecBatch(ctx, batch).ReadAll(); err != nil {
+		return errors.Errorf("%w\nAt statement %d: %s", err, len(m.Statements), INSERT_MIGRATION_VERSION)
+	}
+	return nil
+}
+
 func (m *MigrationFile) insertVersionSQL(conn *pgx.Conn, batch *pgconn.Batch) error {
 	value := pgtype.TextArray{}
 	if err := value.Set(m.Statements); err != nil {
@@ -141,6 +180,9 @@ func NewSeedFile(path string, fsys fs.FS) (*SeedFile, error) {
 }
 
 func (m *SeedFile) ExecBatchWithCache(ctx context.Context, conn *pgx.Conn, fsys fs.FS) error {
+	if ext := path.Ext(m.Path); ext == ".csv" || ext == ".json" {
+		return m.copyData(ctx, conn, fsys)
+	}
 	// Parse each file individually to reduce memory usage
 	lines, err := parseFile(m.Path, fsys)
 	if err != nil {
@@ -160,3 +202,143 @@ func (m *SeedFile) ExecBatchWithCache(ctx context.Context, conn *pgx.Conn, fsys
 	}
 	return nil
 }
+
+// Maps data files in a seed directory to the tables they are loaded into.
+type seedManifest struct {
+	Files map[string]seedTarget `toml:"files"`
+	// Deprecated: use [files] instead, which also maps json files.
+	Csv map[string]seedTarget `toml:"csv"`
+}
+
+type seedTarget struct {
+	Table   string   `toml:"table"`
+	Columns []string `toml:"columns"`
+}
+
+// Accepts either a table name or a table with columns.
+func (t *seedTarget) UnmarshalTOML(value any) error {
+	switch v := value.(type) {
+	case string:
+		t.Table = v
+	case map[string]any:
+		t.Table, _ = v["table"].(string)
+		cols, _ := v["columns"].([]any)
+		for _, c := range cols {
+			if name, ok := c.(string); ok {
+				t.Columns = append(t.Columns, name)
+			}
+		}
+	default:
+		return errors.Errorf("invalid seed target: %v", value)
+	}
+	return nil
+}
+
+const INSERT_JSON_RECORDS = "INSERT INTO %s (%s) SELECT %s FROM json_populate_recordset(NULL::%s, $1)"
+
+var seedPrefixPattern = regexp.MustCompile(`^[0-9]+_`)
+
+// Loads the target table from manifest.toml, defaulting to the file name without numeric prefix.
+func loadSeedTarget(seedPath string, fsys fs.FS) (seedTarget, error) {
+	manifestPath := path.Join(path.Dir(seedPath), "manifest.toml")
+	var manifest seedManifest
+	if _, err := toml.DecodeFS(fsys, manifestPath, &manifest); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return seedTarget{}, errors.Errorf("failed to load seed manifest: %w", err)
+	}
+	name := path.Base(seedPath)
+	target, ok := manifest.Files[name]
+	if !ok {
+		target = manifest.Csv[name]
+	}
+	if len(target.Table) == 0 {
+		target.Table = seedPrefixPattern.ReplaceAllString(strings.TrimSuffix(name, path.Ext(name)), "")
+	}
+	if len(target.Table) == 0 {
+		return target, errors.Errorf("missing table for %s in %s", name, manifestPath)
+	}
+	return target, nil
+}
+
+func (t seedTarget) sanitize() (string, string) {
+	table := pgx.Identifier(strings.Split(t.Table, ".")).Sanitize()
+	cols := make([]string, len(t.Columns))
+	for i, c := range t.Columns {
+		cols[i] = pgx.Identifier{c}.Sanitize()
+	}
+	return table, strings.Join(cols, ", ")
+}
+
+func (m *SeedFile) copyData(ctx context.Context, conn *pgx.Conn, fsys fs.FS) error {
+	target, err := loadSeedTarget(m.Path, fsys)
+	if err != nil {
+		return err
+	}
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return errors.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Rollback(context.Background()); err != nil && !errors.Is(err, pgx.ErrTxClosed) {
+			fmt.Fprintln(os.Stderr, "failed to rollback transaction:", err)
+		}
+	}()
+	if !m.Dirty {
+		f, err := fsys.Open(m.Path)
+		if err != nil {
+			return errors.Errorf("failed to open seed file: %w", err)
+		}
+		defer f.Close()
+		if path.Ext(m.Path) == ".json" {
+			err = insertJson(ctx, tx, f, target)
+		} else {
+			err = copyCsv(ctx, tx, f, target)
+		}
+		if err != nil {
+			return errors.Errorf("failed to seed %s: %w", m.Path, err)
+		}
+	}
+	if _, err := tx.Exec(ctx, UPSERT_SEED_FILE, m.Path, m.Hash); err != nil {
+		return errors.Errorf("failed to update seed history: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func copyCsv(ctx context.Context, tx pgx.Tx, r io.Reader, target seedTarget) error {
+	table, cols := target.sanitize()
+	if len(cols) > 0 {
+		table += " (" + cols + ")"
+	}
+	sql := fmt.Sprintf("COPY %s FROM STDIN WITH (FORMAT csv, HEADER true)", table)
+	_, err := tx.Conn().PgConn().CopyFrom(ctx, r, sql)
+	return err
+}
+
+// Inserts a json array of objects, using object keys as columns unless specified.
+func insertJson(ctx context.Context, tx pgx.Tx, r io.Reader, target seedTarget) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if len(target.Columns) == 0 {
+		var rows []map[string]any
+		if err := json.Unmarshal(data, &rows); err != nil {
+			return err
+		}
+		keys := map[string]bool{}
+		for _, row := range rows {
+			for k := range row {
+				keys[k] = true
+			}
+		}
+		target.Columns = slices.Sorted(maps.Keys(keys))
+	}
+	if len(target.Columns) == 0 {
+		return nil
+	}
+	table, cols := target.sanitize()
+	_, err = tx.Exec(ctx, fmt.Sprintf(INSERT_JSON_RECORDS, table, cols, cols, table), string(data))
+	return err
+}
@@ -30,6 +30,20 @@ func TestMigrationFile(t *testing.T) {
 		assert.Equal(t, "20220727064247", migration.Version)
 	})
 
+	t.Run("new from file parses no-transaction directive", func(t *testing.T) {
+		// Setup in-memory fs
+		path := "20220727064247_create_index.sql"
+		query := "-- supabase: no-transaction\ncreate index concurrently test_idx on test (id);"
+		fsys := fs.MapFS{
+			path: &fs.MapFile{Data: []byte(query)},
+		}
+		// Run test
+		migration, err := NewMigrationFromFile(path, fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.True(t, migration.NoTransaction)
+	})
+
 	t.Run("new from reader errors on max token", func(t *testing.T) {
 		viper.Reset()
 		sql := "\tBEGIN; " + strings.Repeat("a", parser.MaxScannerCapacity)
@@ -59,6 +73,45 @@ func TestMigrationFile(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("runs statements separately without transaction", func(t *testing.T) {
+		migration := MigrationFile{
+			Statements:    []string{"create index concurrently a_idx on a (id)", "create index concurrently b_idx on b (id)"},
+			Version:       "0",
+			NoTransaction: true,
+		}
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.Statements[0]).
+			Reply("CREATE INDEX").
+			Query(migration.Statements[1]).
+			Reply("CREATE INDEX").
+			Query(INSERT_MIGRATION_VERSION, "0", "", migration.Statements).
+			Reply("INSERT 0 1")
+		// Run test
+		err := migration.ExecBatch(context.Background(), conn.MockClient(t))
+		// Check error
+		assert.NoError(t, err)
+	})
+
+	t.Run("throws error on statement failure without transaction", func(t *testing.T) {
+		migration := MigrationFile{
+			Statements:    []string{"create index concurrently a_idx on a (id)"},
+			Version:       "0",
+			NoTransaction: true,
+		}
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query(migration.Statements[0]).
+			ReplyError(pgerrcode.UndefinedTable, `relation "a" does not exist`)
+		// Run test
+		err := migration.ExecBatch(context.Background(), conn.MockClient(t))
+		// Check error
+		assert.ErrorContains(t, err, `ERROR: relation "a" does not exist (SQLSTATE 42P01)`)
+		assert.ErrorContains(t, err, "At statement 0: create index concurrently a_idx on a (id)")
+	})
+
 	t.Run("throws error on insert failure", func(t *testing.T) {
 		migration := MigrationFile{
 			Statements: []string{"create schema public"},
@@ -78,3 +131,83 @@ func TestMigrationFile(t *testing.T) {
 		assert.ErrorContains(t, err, "At statement 0: create schema public")
 	})
 }
+
+func TestLoadSeedTarget(t *testing.T) {
+	t.Run("loads table from manifest", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := fs.MapFS{
+			"supabase/seed/manifest.toml": &fs.MapFile{Data: []byte(`[files]
+"20_countries.csv" = "public.countries"
+"30_cities.json" = { table = "public.cities", columns = ["id", "name"] }
+`)},
+		}
+		// Run test
+		target, err := loadSeedTarget("supabase/seed/20_countries.csv", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, seedTarget{Table: "public.countries"}, target)
+		// Run test
+		target, err = loadSeedTarget("supabase/seed/30_cities.json", fsys)
+		// Check error
+		assert.NoError(t, err)
+		table, cols := target.sanitize()
+		assert.Equal(t, `"public"."cities"`, table)
+		assert.Equal(t, `"id", "name"`, cols)
+	})
+
+	t.Run("defaults to file name without manifest", func(t *testing.T) {
+		// Run test
+		target, err := loadSeedTarget("supabase/seed/20_countries.csv", fs.MapFS{})
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, seedTarget{Table: "countries"}, target)
+	})
+
+	t.Run("loads table from deprecated csv section", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := fs.MapFS{
+			"supabase/seed/manifest.toml": &fs.MapFile{Data: []byte(`[csv]
+"20_countries.csv" = "public.countries"
+`)},
+		}
+		// Run test
+		target, err := loadSeedTarget("supabase/seed/20_countries.csv", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, seedTarget{Table: "public.countries"}, target)
+	})
+
+	t.Run("throws error on invalid manifest", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := fs.MapFS{
+			"supabase/seed/manifest.toml": &fs.MapFile{Data: []byte("[files]\n\"a.csv\" = 1")},
+		}
+		// Run test
+		_, err := loadSeedTarget("supabase/seed/a.csv", fsys)
+		// Check error
+		assert.ErrorContains(t, err, "failed to load seed manifest:")
+	})
+}
+
+func TestSeedJson(t *testing.T) {
+	t.Run("inserts json records by keys", func(t *testing.T) {
+		data := `[{"id": 1, "name": "NZ"}, {"id": 2}]`
+		fsys := fs.MapFS{
+			"supabase/seed/countries.json": &fs.MapFile{Data: []byte(data)},
+		}
+		seed := SeedFile{Path: "supabase/seed/countries.json", Hash: "abc"}
+		// Setup mock postgres
+		conn := pgtest.NewConn()
+		defer conn.Close(t)
+		conn.Query("begin").Reply("BEGIN").
+			Query(`INSERT INTO "countries" ("id", "name") SELECT "id", "name" FROM json_populate_recordset(NULL::"countries", $1)`, data).
+			Reply("INSERT 0 2").
+			Query(UPSERT_SEED_FILE, seed.Path, seed.Hash).
+			Reply("INSERT 0 1").
+			Query("commit").Reply("COMMIT")
+		// Run test
+		err := seed.ExecBatchWithCache(context.Background(), conn.MockClient(t), fsys)
+		// Check error
+		assert.NoError(t, err)
+	})
+}
@@ -38,9 +38,16 @@ func ListLocalMigrations(migrationsDir string, fsys fs.FS, filter ...func(string
 	if len(filter) == 0 {
 		filter = append(filter, func(string) bool { return true })
 	}
-	var clean []string
+	names := make([]string, len(localMigrations))
 	for i, migration := range localMigrations {
-		filename := migration.Name()
+		names[i] = migration.Name()
+	}
+	var clean []string
+	for i, filename := range names {
+		// Down migrations are only run by migration down
+		if IsDownMigration(filename, names) {
+			continue
+		}
 		if i == 0 && shouldSkip(filename) {
 			fmt.Fprintf(os.Stderr, "Skipping migration %s... (replace \"init\" with a different file name to apply this migration)\n", filename)
 			continue
@@ -81,6 +81,31 @@ func TestLocalMigrations(t *testing.T) {
 		assert.Empty(t, versions)
 	})
 
+	t.Run("ignores down migrations", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := fs.MapFS{
+			"20220727064246_test.sql":      &fs.MapFile{},
+			"20220727064246_test_down.sql": &fs.MapFile{},
+		}
+		// Run test
+		versions, err := ListLocalMigrations(".", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"20220727064246_test.sql"}, versions)
+	})
+
+	t.Run("keeps migrations named down without an up migration", func(t *testing.T) {
+		// Setup in-memory fs
+		fsys := fs.MapFS{
+			"20240101000000_scale_down.sql": &fs.MapFile{},
+		}
+		// Run test
+		versions, err := ListLocalMigrations(".", fsys)
+		// Check error
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"20240101000000_scale_down.sql"}, versions)
+	})
+
 	t.Run("throws error on open failure", func(t *testing.T) {
 		// Setup in-memory fs
 		fsys := fs.MapFS{"migrations": &fs.MapFile{}}
@@ -0,0 +1,92 @@
+do $$ declare
+  rec record;
+  schemas text[] := string_to_array(current_setting('supabase.reset_schemas'), ',');
+begin
+  -- schemas other than public are recreated by migrations
+  for rec in
+    select *
+    from pg_namespace n
+    where
+      n.nspname = any(schemas)
+      and n.nspname != 'public'
+  loop
+    execute format('drop schema if exists %I cascade', rec.nspname);
+  end loop;
+
+  -- functions
+  for rec in
+    select *
+    from pg_proc p
+    where
+      p.pronamespace::regnamespace::name = any(schemas)
+      and not exists (select 1 from pg_depend d where d.objid = p.oid and d.deptype = 'e')
+  loop
+    -- supports aggregate, function, and procedure
+    execute format('drop routine if exists %I.%I(%s) cascade', rec.pronamespace::regnamespace::name, rec.proname, pg_catalog.pg_get_function_identity_arguments(rec.oid));
+  end loop;
+
+  -- tables (cascade to views)
+  for rec in
+    select *
+    from pg_class c
+    where
+      c.relnamespace::regnamespace::name = any(schemas)
+      and c.relkind not in ('c', 'S', 'v', 'm')
+      and not exists (select 1 from pg_depend d where d.objid = c.oid and d.deptype = 'e')
+    order by c.relkind desc
+  loop
+    -- supports all table like relations, except views, complex types, and sequences
+    execute format('drop table if exists %I.%I cascade', rec.relnamespace::regnamespace::name, rec.relname);
+  end loop;
+
+  -- views that do not depend on any table
+  for rec in
+    select *
+    from pg_class c
+    where
+      c.relnamespace::regnamespace::name = any(schemas)
+      and c.relkind in ('v', 'm')
+      and not exists (select 1 from pg_depend d where d.objid = c.oid and d.deptype = 'e')
+  loop
+    if rec.relkind = 'm' then
+      execute format('drop materialized view if exists %I.%I cascade', rec.relnamespace::regnamespace::name, rec.relname);
+    else
+      execute format('drop view if exists %I.%I cascade', rec.relnamespace::regnamespace::name, rec.relname);
+    end if;
+  end loop;
+
+  -- sequences
+  for rec in
+    select *
+    from pg_class c
+    where
+      c.relnamespace::regnamespace::name = any(schemas)
+      and c.relkind = 'S'
+      and not exists (select 1 from pg_depend d where d.objid = c.oid and d.deptype = 'e')
+  loop
+    execute format('drop sequence if exists %I.%I cascade', rec.relnamespace::regnamespace::name, rec.relname);
+  end loop;
+
+  -- types
+  for rec in
+    select *
+    from pg_type t
+    where
+      t.typnamespace::regnamespace::name = any(schemas)
+      and t.typtype != 'b'
+      and not exists (select 1 from pg_depend d where d.objid = t.oid and d.deptype = 'e')
+  loop
+    execute format('drop type if exists %I.%I cascade', rec.typnamespace::regnamespace::name, rec.typname);
+  end loop;
+
+  -- migration and seed history
+  for rec in
+    select *
+    from pg_class c
+    where
+      c.relnamespace::regnamespace::name = 'supabase_migrations'
+      and c.relkind = 'r'
+  loop
+    execute format('truncate %I.%I', rec.relnamespace::regnamespace::name, rec.relname);
+  end loop;
+end $$;
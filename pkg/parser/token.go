@@ -79,6 +79,15 @@ func (t *tokenizer) ScanToken(data []byte, atEOF bool) (advance int, token []byt
 //
 // Each statement is split as it is, without removing comments or white spaces.
 func Split(sql io.Reader, transform ...func(string) string) (stats []string, err error) {
+	err = Stream(sql, func(stat string) error {
+		stats = append(stats, stat)
+		return nil
+	}, transform...)
+	return stats, err
+}
+
+// Stream calls handle on each statement as soon as it is scanned, without buffering the whole input.
+func Stream(sql io.Reader, handle func(stat string) error, transform ...func(string) string) error {
 	t := tokenizer{state: &ReadyState{}}
 	scanner := bufio.NewScanner(sql)
 
@@ -92,6 +101,7 @@ func Split(sql io.Reader, transform ...func(string) string) (stats []string, err
 	scanner.Split(t.ScanToken)
 
 	var token string
+	var count int
 	for scanner.Scan() {
 		token = scanner.Text()
 		trim := token
@@ -99,17 +109,27 @@ func Split(sql io.Reader, transform ...func(string) string) (stats []string, err
 			trim = apply(trim)
 		}
 		if len(trim) > 0 {
-			stats = append(stats, trim)
+			if err := handle(trim); err != nil {
+				return err
+			}
+			count++
 		}
 	}
-	err = scanner.Err()
+	err := scanner.Err()
 	if err != nil {
-		err = errors.Errorf("%w\nAfter statement %d: %s", err, len(stats), token)
+		err = errors.Errorf("%w\nAfter statement %d: %s", err, count, token)
 	}
 	if errors.Is(err, bufio.ErrTooLong) {
 		err = errors.Errorf("%w\nTry setting SUPABASE_SCANNER_BUFFER_SIZE=5MB (current size is %dKB)", err, maxbuf>>10)
 	}
-	return stats, err
+	return err
+}
+
+// StreamAndTrim is the streaming equivalent of SplitAndTrim.
+func StreamAndTrim(sql io.Reader, handle func(stat string) error) error {
+	return Stream(sql, handle, func(token string) string {
+		return strings.TrimRight(token, ";")
+	}, strings.TrimSpace)
 }
 
 func SplitAndTrim(sql io.Reader) (stats []string, err error) {